@@ -70,7 +70,7 @@ class APIRoutesGenerator:
                         if param.startswith('claims'):
                             interface_params.append('claims')
                             continue
-                        if param.startswith('item'):
+                        if param.split(' ')[0] == 'item':
                             interface_params.append('*item')
                             continue
 
@@ -83,7 +83,7 @@ class DocsLoader:
                             item_param_end = interface_prototype.index(')', item_param_start)
                             interface_prototype = interface_prototype[:item_param_start] + interface_prototype[item_param_end:]
                         if not auth_type:
-                            interface_prototype = interface_prototype.replace('claims *tokenauth.Claims', '').replace('( ,', '(')
+                            interface_prototype = interface_prototype.replace('claims *tokenauth.Claims', '').replace('(, ', '(').replace('( ,', '(')
 
                         core_functions.append({
                             'name': method_data[self.docs_ext_core_function_key],
@@ -18,7 +18,9 @@ import (
 	"lms/core/interfaces"
 	cacheadapter "lms/driven/cache"
 	"lms/driven/corebb"
+	"time"
 
+	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/keys"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
 )
 
@@ -43,10 +45,20 @@ type Application struct {
 
 	logger *logs.Logger
 
+	defaultLocale  string        // locale the default text of custom courses is written in
+	trashRetention time.Duration // time deleted custom course entities may be restored for
+	leaderboardKey []byte        // secret which keys the pseudonyms of users on leaderboards
+
 	//nudges logic
 	nudgesLogic nudgesLogic
 	//streaks and notifications logic
 	streaksNotifications streaksNotifications
+	//achievements logic
+	achievements achievementsLogic
+	//course availability and visibility logic
+	courseAccess courseAccessLogic
+	//completion certificates logic
+	certificates certificatesLogic
 	//delete data logic
 	deleteDataLogic deleteDataLogic
 }
@@ -62,7 +74,8 @@ func (app *Application) Start() {
 
 // NewApplication creates new Application
 func NewApplication(version string, build string, storage interfaces.Storage, provider interfaces.Provider, groupsBB interfaces.GroupsBB,
-	notificationsBB interfaces.NotificationsBB, cacheadapter *cacheadapter.CacheAdapter, coreBB *corebb.Adapter, serciveID string, logger *logs.Logger) *Application {
+	notificationsBB interfaces.NotificationsBB, cacheadapter *cacheadapter.CacheAdapter, coreBB *corebb.Adapter, serciveID string, defaultLocale string,
+	trashRetention time.Duration, certificateKey *keys.PrivKey, retiredCertificateKeys []keys.PubKey, leaderboardKey []byte, logger *logs.Logger) *Application {
 	deleteDataLogic := deleteDataLogic{logger: *logger, core: coreBB, serviceID: serciveID, storage: storage}
 
 	timerDone := make(chan bool)
@@ -76,12 +89,25 @@ func NewApplication(version string, build string, storage interfaces.Storage, pr
 		core:            coreBB,
 	}
 
+	achievements := achievementsLogic{logger: logger, notificationsBB: notificationsBB}
+
+	courseAccess := courseAccessLogic{logger: logger, groupsBB: groupsBB, core: coreBB, cacheAdapter: cacheadapter}
+
+	certificates := newCertificatesLogic(logger, coreBB, serciveID, certificateKey, retiredCertificateKeys)
+
+	if len(leaderboardKey) == 0 {
+		logger.Warn("no leaderboard key configured - leaderboards will not be served")
+	}
+
 	notificationsTimerDone := make(chan bool)
 	streaksTimerDone := make(chan bool)
 	streaksNotifications := streaksNotifications{
 		notificationsBB:        notificationsBB,
 		storage:                storage,
 		logger:                 logger,
+		achievements:           achievements,
+		certificates:           certificates,
+		clock:                  time.Now,
 		notificationsTimerDone: notificationsTimerDone,
 		streaksTimerDone:       streaksTimerDone,
 	}
@@ -95,8 +121,14 @@ func NewApplication(version string, build string, storage interfaces.Storage, pr
 		storage:              storage,
 		cacheAdapter:         cacheadapter,
 		logger:               logger,
+		defaultLocale:        defaultLocale,
+		trashRetention:       trashRetention,
+		leaderboardKey:       leaderboardKey,
 		nudgesLogic:          nudgesLogic,
 		streaksNotifications: streaksNotifications,
+		achievements:         achievements,
+		courseAccess:         courseAccess,
+		certificates:         certificates,
 		core:                 coreBB,
 		deleteDataLogic:      deleteDataLogic,
 	}
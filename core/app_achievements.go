@@ -0,0 +1,88 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"lms/core/interfaces"
+	"lms/core/model"
+	"lms/driven/notifications"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+type achievementsLogic struct {
+	logger *logs.Logger
+
+	notificationsBB interfaces.NotificationsBB
+}
+
+// awardAchievements awards the user all achievements they have earned in the user course but have not been awarded yet
+func (a achievementsLogic) awardAchievements(storage interfaces.Storage, userCourse model.UserCourse, achievements []model.Achievement, now time.Time) ([]model.UserAchievement, error) {
+	earned := make([]model.Achievement, 0)
+	for i, achievement := range achievements {
+		if achievements[i].IsEarned(userCourse) {
+			earned = append(earned, achievement)
+		}
+	}
+	if len(earned) == 0 {
+		return nil, nil
+	}
+
+	awarded, err := storage.FindUserAchievements(userCourse.AppID, userCourse.OrgID, &userCourse.UserID, &userCourse.Course.Key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserAchievement, nil, err)
+	}
+
+	awards := make([]model.UserAchievement, 0)
+	for _, achievement := range earned {
+		alreadyAwarded := false
+		for _, userAchievement := range awarded {
+			if userAchievement.Achievement.Key == achievement.Key {
+				alreadyAwarded = true
+				break
+			}
+		}
+		if !alreadyAwarded {
+			awards = append(awards, model.UserAchievement{ID: uuid.NewString(), AppID: userCourse.AppID, OrgID: userCourse.OrgID, UserID: userCourse.UserID,
+				CourseKey: userCourse.Course.Key, Achievement: achievement, DateAwarded: now})
+		}
+	}
+
+	err = storage.InsertUserAchievements(awards)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserAchievement, nil, err)
+	}
+	return awards, nil
+}
+
+// notifyAchievements sends the notifications configured for newly awarded achievements
+func (a achievementsLogic) notifyAchievements(awards []model.UserAchievement) {
+	for _, award := range awards {
+		notification := award.Achievement.Notification
+		if notification == nil {
+			continue
+		}
+
+		recipients := []notifications.Recipient{{UserID: award.UserID}}
+		err := a.notificationsBB.SendNotifications(recipients, notification.Subject, notification.Body, notification.Params)
+		if err != nil {
+			a.logger.Errorf("notifyAchievements -> error sending notification for achievement %s to user %s: %v", award.Achievement.Key, award.UserID, err)
+		}
+	}
+}
@@ -30,6 +30,17 @@ import (
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
+const (
+	// defaultLearnersLimit is the page size of course learner lists when no limit is given
+	defaultLearnersLimit int = 100
+	// defaultAuditLogsLimit is the page size of audit logs when no limit is given
+	defaultAuditLogsLimit int = 100
+	// defaultAnalyticsInactiveDays is the number of days without responding after which a user counts as a drop-off in course analytics
+	defaultAnalyticsInactiveDays int = 14
+	// userBatchSize is the number of users whose progress is loaded or changed at once when migrating or enrolling many users
+	userBatchSize int = 100
+)
+
 type adminImpl struct {
 	app *Application
 }
@@ -47,11 +58,19 @@ func (s *adminImpl) GetNudgesConfig(claims *tokenauth.Claims) (*model.NudgesConf
 
 // UpdateNudgesConfig(active bool, groupName string, testGroupName string, mode string, processTime *int, blockSize *int) error
 func (s *adminImpl) UpdateNudgesConfig(claims *tokenauth.Claims, item model.NudgesConfig) (*model.NudgesConfig, error) {
-	err := s.app.storage.SaveNudgesConfig(item)
-	if err != nil {
-		return nil, err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		nudgesConfig, err := storageTransaction.FindNudgesConfig()
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.SaveNudgesConfig(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeNudgesConfig, "nudges", nudgesConfig, item)
 	}
-	return nil, nil
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) GetNudges(claims *tokenauth.Claims) ([]model.Nudge, error) {
@@ -65,30 +84,55 @@ func (s *adminImpl) GetNudges(claims *tokenauth.Claims) ([]model.Nudge, error) {
 
 // CreateNudge(ID string, name string, body string, deepLink string, params model.NudgeParams, active bool, usersSourse []model.UsersSource) error
 func (s *adminImpl) CreateNudge(claims *tokenauth.Claims, item model.Nudge) (*model.Nudge, error) {
-	//create and insert nudge
-	err := s.app.storage.InsertNudge(item)
-	if err != nil {
-		return nil, err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		//create and insert nudge
+		err := storageTransaction.InsertNudge(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeNudge, item.ID, nil, item)
 	}
-	return nil, nil
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 // UpdateNudge(ID string, name string, body string, deepLink string, params model.NudgeParams, active bool, usersSourse []model.UsersSources) error
 func (s *adminImpl) UpdateNudge(claims *tokenauth.Claims, id string, item model.Nudge) (*model.Nudge, error) {
 	item.ID = id
-	err := s.app.storage.UpdateNudge(item)
-	if err != nil {
-		return nil, err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		nudge, err := storageTransaction.FindNudge(id)
+		if err != nil {
+			return err
+		}
+		if nudge == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeNudge, &logutils.FieldArgs{"id": id})
+		}
+
+		err = storageTransaction.UpdateNudge(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeNudge, id, nudge, item)
 	}
-	return nil, nil
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) DeleteNudge(claims *tokenauth.Claims, id string) error {
-	err := s.app.storage.DeleteNudge(id)
-	if err != nil {
-		return nil
+	transaction := func(storageTransaction interfaces.Storage) error {
+		nudge, err := storageTransaction.FindNudge(id)
+		if err != nil {
+			return err
+		}
+		if nudge == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeNudge, &logutils.FieldArgs{"id": id})
+		}
+
+		err = storageTransaction.DeleteNudge(id)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeNudge, id, nudge, nil)
 	}
-	return err
+	return s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) FindSentNudges(claims *tokenauth.Claims, nudgeID *string, userID *string, netID *string, mode *string) ([]model.SentNudge, error) {
@@ -105,19 +149,26 @@ func (s *adminImpl) DeleteSentNudges(claims *tokenauth.Claims, ids *string) erro
 		idList = strings.Split(*ids, ",")
 	}
 
-	err := s.app.storage.DeleteSentNudges(idList, "")
-	if err != nil {
-		return err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := storageTransaction.DeleteSentNudges(idList, "")
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeSentNudge, strings.Join(idList, ","), nil, nil)
 	}
-	return nil
+	return s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) ClearTestSentNudges(claims *tokenauth.Claims) error {
-	err := s.app.storage.DeleteSentNudges(nil, "test")
-	if err != nil {
-		return err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := storageTransaction.DeleteSentNudges(nil, "test")
+		if err != nil {
+			return err
+		}
+		// the sent nudges are identified by their mode
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeSentNudge, "test", nil, nil)
 	}
-	return nil
+	return s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) FindNudgesProcesses(claims *tokenauth.Claims, limit *int, offset *int) ([]model.NudgesProcess, error) {
@@ -138,6 +189,43 @@ func (s *adminImpl) FindNudgesProcesses(claims *tokenauth.Claims, limit *int, of
 	return nudgesProcess, nil
 }
 
+// get a page of the audit log, optionally only for an entity type, entity key or actor within a date range
+func (s *adminImpl) GetAuditLogs(claims *tokenauth.Claims, entityType *string, entityKey *string, actor *string, startDate *string, endDate *string, limit *int, offset *int) ([]model.AuditLog, error) {
+	pageLimit := defaultAuditLogsLimit
+	if limit != nil {
+		pageLimit = *limit
+	}
+	pageOffset := 0
+	if offset != nil {
+		pageOffset = *offset
+	}
+	if pageLimit < 1 || pageLimit > model.MaxAuditLogsLimit || pageOffset < 0 {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"limit": pageLimit, "offset": pageOffset})
+	}
+
+	var start, end *time.Time
+	if startDate != nil {
+		parsed, err := time.Parse(time.RFC3339, *startDate)
+		if err != nil {
+			return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("start_date"), err)
+		}
+		start = &parsed
+	}
+	if endDate != nil {
+		parsed, err := time.Parse(time.RFC3339, *endDate)
+		if err != nil {
+			return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("end_date"), err)
+		}
+		end = &parsed
+	}
+
+	auditLogs, err := s.app.storage.FindAuditLogs(claims.AppID, claims.OrgID, entityType, entityKey, actor, start, end, pageLimit, pageOffset)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAuditLog, nil, err)
+	}
+	return auditLogs, nil
+}
+
 func (s *adminImpl) GetCustomCourses(claims *tokenauth.Claims, id *string, name *string, key *string, moduleKey *string) ([]model.Course, error) {
 	var idArr, nameArr, keyArr, moduleKeys []string
 
@@ -163,6 +251,15 @@ func (s *adminImpl) GetCustomCourses(claims *tokenauth.Claims, id *string, name
 }
 
 func (s *adminImpl) CreateCustomCourse(claims *tokenauth.Claims, item model.Course) (*model.Course, error) {
+	err := item.Localizations.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeLocalization, &logutils.FieldArgs{"key": item.Key}, err)
+	}
+	err = item.Availability.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseAvailability, &logutils.FieldArgs{"key": item.Key}, err)
+	}
+
 	transaction := func(storageTransaction interfaces.Storage) error {
 		item.ID = uuid.NewString()
 		item.AppID = claims.AppID
@@ -241,7 +338,11 @@ func (s *adminImpl) CreateCustomCourse(claims *tokenauth.Claims, item model.Cour
 			}
 		}
 
-		return nil
+		err = s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeCourse, item.Key, nil, item)
+		if err != nil {
+			return err
+		}
+		return s.auditCreated(storageTransaction, claims, newModules, newUnits, newContents)
 	}
 	return nil, s.app.storage.PerformTransaction(transaction)
 }
@@ -256,7 +357,17 @@ func (s *adminImpl) GetCustomCourse(claims *tokenauth.Claims, key string) (*mode
 	return course, nil
 }
 
+// UpdateCustomCourse updates the draft of a course, which is applied immediately only for users who are not on a published version
 func (s *adminImpl) UpdateCustomCourse(claims *tokenauth.Claims, key string, item model.Course) (*model.Course, error) {
+	err := item.Localizations.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeLocalization, &logutils.FieldArgs{"key": key}, err)
+	}
+	err = item.Availability.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseAvailability, &logutils.FieldArgs{"key": key}, err)
+	}
+
 	transaction := func(storageTransaction interfaces.Storage) error {
 		item.AppID = claims.AppID
 		item.OrgID = claims.OrgID
@@ -298,31 +409,361 @@ func (s *adminImpl) UpdateCustomCourse(claims *tokenauth.Claims, key string, ite
 		if err != nil {
 			return err
 		}
-		return nil
+
+		updated, err := storageTransaction.FindCustomCourse(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeCourse, key, course, updated)
 	}
 	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) DeleteCustomCourse(claims *tokenauth.Claims, key string) error {
+	// the course is moved to the trash with all derived user courses, achievements, awarded user achievements, published versions and cohorts
+	item := model.NewTrashItem(claims.AppID, claims.OrgID, model.TrashTypeCourse, key, claims.Subject, time.Now(), s.app.trashRetention)
 	transaction := func(storageTransaction interfaces.Storage) error {
-		appID := claims.AppID
-		orgID := claims.OrgID
-		err := storageTransaction.DeleteCustomCourse(appID, orgID, key)
+		course, err := storageTransaction.FindCustomCourse(claims.AppID, claims.OrgID, key)
 		if err != nil {
 			return err
 		}
 
-		// delete all derieved user course
-		err = storageTransaction.DeleteUserCourses(appID, orgID, key)
+		err = storageTransaction.TrashCustomCourse(item)
 		if err != nil {
 			return err
 		}
-
-		return err
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeCourse, key, course, nil)
 	}
 	return s.app.storage.PerformTransaction(transaction)
 }
 
+// CloneCustomCourse deep-copies the draft of a course with all of its modules, units and contents under new keys, optionally into another app/org
+func (s *adminImpl) CloneCustomCourse(claims *tokenauth.Claims, key string, item model.CourseClone) (*model.Course, error) {
+	appID := claims.AppID
+	if item.AppID != nil && *item.AppID != "" {
+		appID = *item.AppID
+	}
+	orgID := claims.OrgID
+	if item.OrgID != nil && *item.OrgID != "" {
+		orgID = *item.OrgID
+	}
+	if (appID != claims.AppID || orgID != claims.OrgID) && !claims.System {
+		return nil, errors.ErrorData(logutils.StatusInvalid, "clone app/org", &logutils.FieldArgs{"app_id": appID, "org_id": orgID, "system": false})
+	}
+	if appID == claims.AppID && orgID == claims.OrgID && item.KeyPrefix == "" && item.KeySuffix == "" {
+		return nil, errors.ErrorData(logutils.StatusMissing, "clone key prefix or suffix", nil)
+	}
+
+	var clone model.Course
+	transaction := func(storageTransaction interfaces.Storage) error {
+		source, err := storageTransaction.FindCustomCourse(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+		}
+
+		var modules []model.Module
+		var units []model.Unit
+		var contents []model.Content
+		clone, modules, units, contents = item.CloneCourse(*source, appID, orgID, time.Now().UTC())
+
+		// the cloned keys must not be in use in the target app/org
+		existingCourses, err := storageTransaction.FindCustomCourses(appID, orgID, nil, nil, []string{clone.Key}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+		}
+		if len(existingCourses) != 0 {
+			return errors.ErrorData(logutils.StatusInvalid, "cloned course key", &logutils.FieldArgs{"key": clone.Key, "exists": true})
+		}
+		moduleKeys := make([]string, len(modules))
+		for i, module := range modules {
+			moduleKeys[i] = module.Key
+		}
+		existingModules, err := storageTransaction.FindCustomModules(appID, orgID, nil, nil, moduleKeys, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeModule, nil, err)
+		}
+		if len(existingModules) != 0 {
+			return errors.ErrorData(logutils.StatusInvalid, "cloned module key", &logutils.FieldArgs{"key": existingModules[0].Key, "exists": true})
+		}
+		unitKeys := make([]string, len(units))
+		for i, unit := range units {
+			unitKeys[i] = unit.Key
+		}
+		existingUnits, err := storageTransaction.FindCustomUnits(appID, orgID, nil, nil, unitKeys, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUnit, nil, err)
+		}
+		if len(existingUnits) != 0 {
+			return errors.ErrorData(logutils.StatusInvalid, "cloned unit key", &logutils.FieldArgs{"key": existingUnits[0].Key, "exists": true})
+		}
+		contentKeys := make([]string, len(contents))
+		for i, content := range contents {
+			contentKeys[i] = content.Key
+		}
+		existingContents, err := storageTransaction.FindCustomContents(appID, orgID, nil, nil, contentKeys)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeContent, nil, err)
+		}
+		if len(existingContents) != 0 {
+			return errors.ErrorData(logutils.StatusInvalid, "cloned content key", &logutils.FieldArgs{"key": existingContents[0].Key, "exists": true})
+		}
+
+		err = storageTransaction.InsertCustomCourse(clone)
+		if err != nil {
+			return err
+		}
+		if len(modules) != 0 {
+			err = storageTransaction.InsertCustomModules(modules)
+			if err != nil {
+				return err
+			}
+		}
+		if len(units) != 0 {
+			err = storageTransaction.InsertCustomUnits(units)
+			if err != nil {
+				return err
+			}
+		}
+		if len(contents) != 0 {
+			err = storageTransaction.InsertCustomContents(contents)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the clone is recorded in the source app/org
+		err = s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeCourse, clone.Key, nil, clone)
+		if err != nil {
+			return err
+		}
+		return s.auditCreated(storageTransaction, claims, modules, units, contents)
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+func (s *adminImpl) GetCustomCourseVersions(claims *tokenauth.Claims, key string) ([]model.CourseVersion, error) {
+	courseVersions, err := s.app.storage.FindCourseVersions(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, nil, err)
+	}
+	return courseVersions, nil
+}
+
+// publish the current draft of a course, including all of its modules, units and contents, as a new version
+func (s *adminImpl) PublishCustomCourse(claims *tokenauth.Claims, key string, item model.CourseVersion) (*model.CourseVersion, error) {
+	var courseVersion model.CourseVersion
+	transaction := func(storageTransaction interfaces.Storage) error {
+		course, err := storageTransaction.FindCustomCourse(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+		}
+		err = course.Validate()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+		}
+
+		latest, err := storageTransaction.FindCourseVersion(claims.AppID, claims.OrgID, key, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, nil, err)
+		}
+		version := 1
+		if latest != nil {
+			if len(model.DiffCourses(&latest.Course, *course)) == 0 {
+				return errors.ErrorData(logutils.StatusInvalid, model.TypeCourseVersion, &logutils.FieldArgs{"key": key, "changes": 0})
+			}
+			version = latest.Version + 1
+		}
+
+		courseVersion = model.CourseVersion{ID: uuid.NewString(), AppID: claims.AppID, OrgID: claims.OrgID, CourseKey: key, Version: version, Notes: item.Notes,
+			Course: *course, DatePublished: time.Now().UTC()}
+		err = storageTransaction.InsertCourseVersion(courseVersion)
+		if err != nil {
+			return err
+		}
+
+		// the published course is the draft at the time, so only the version is recorded
+		published := map[string]interface{}{"version": courseVersion.Version, "notes": courseVersion.Notes}
+		return s.audit(storageTransaction, claims, model.AuditActionPublish, model.TypeCourseVersion, key, nil, published)
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &courseVersion, nil
+}
+
+// preview the draft of a course and the changes it makes to the latest published version
+func (s *adminImpl) GetCustomCoursePreview(claims *tokenauth.Claims, key string) (*model.CourseVersionPreview, error) {
+	course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+	}
+	latest, err := s.app.storage.FindCourseVersion(claims.AppID, claims.OrgID, key, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, nil, err)
+	}
+
+	preview := model.CourseVersionPreview{CourseKey: key, Draft: *course}
+	var published *model.Course
+	if latest != nil {
+		preview.PublishedVersion = latest.Version
+		published = &latest.Course
+	}
+	preview.Changes = model.DiffCourses(published, *course)
+	return &preview, nil
+}
+
+// report the impact of moving users on older versions of a course to a published version (the latest version if version is nil)
+func (s *adminImpl) GetCustomCourseMigration(claims *tokenauth.Claims, key string, version *int) (*model.CourseMigration, error) {
+	return s.migrateUserCourses(claims, key, version, false)
+}
+
+// move users on older versions of a course to a published version (the latest version if version is nil), except those whose current unit was removed
+func (s *adminImpl) MigrateCustomCourse(claims *tokenauth.Claims, key string, version *int) (*model.CourseMigration, error) {
+	return s.migrateUserCourses(claims, key, version, true)
+}
+
+// get a page of the progress of the users enrolled in a course, optionally only those inactive for or with a streak reset within a number of days
+func (s *adminImpl) GetCustomCourseLearners(claims *tokenauth.Claims, key string, cohortKey *string, inactiveDays *int, streakResetDays *int, completed *bool, dropped *bool, limit *int, offset *int) ([]model.LearnerProgress, error) {
+	if (inactiveDays != nil && *inactiveDays < 0) || (streakResetDays != nil && *streakResetDays < 0) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"inactive_days": inactiveDays, "streak_reset_days": streakResetDays})
+	}
+	if (limit != nil && *limit < 0) || (offset != nil && *offset < 0) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"limit": limit, "offset": offset})
+	}
+
+	now := time.Now().UTC()
+	filter := model.LearnerProgressFilter{CohortKey: cohortKey, Completed: completed, Dropped: dropped}
+	if inactiveDays != nil {
+		inactiveSince := now.AddDate(0, 0, -*inactiveDays)
+		filter.InactiveSince = &inactiveSince
+	}
+	if streakResetDays != nil {
+		streakResetSince := now.AddDate(0, 0, -*streakResetDays)
+		filter.StreakResetSince = &streakResetSince
+	}
+	pageLimit := defaultLearnersLimit
+	if limit != nil {
+		pageLimit = *limit
+	}
+	pageOffset := 0
+	if offset != nil {
+		pageOffset = *offset
+	}
+
+	learners, err := s.app.storage.FindLearnerProgress(claims.AppID, claims.OrgID, key, filter, pageLimit, pageOffset)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLearnerProgress, &logutils.FieldArgs{"course_key": key}, err)
+	}
+	return learners, nil
+}
+
+// get the user course of a single user enrolled in a course with their user units and responses to the course content
+func (s *adminImpl) GetCustomCourseLearner(claims *tokenauth.Claims, key string, userID string) (*model.LearnerDetails, error) {
+	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, userID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if userCourse == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userID, "course.key": key})
+	}
+
+	userUnits, err := s.app.storage.FindUserUnits(claims.AppID, claims.OrgID, []string{userID}, key, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+	}
+	userContents, err := s.app.storage.FindUserContents(nil, claims.AppID, claims.OrgID, userID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserContent, nil, err)
+	}
+
+	details := model.LearnerDetails{UserCourse: *userCourse, UserUnits: userUnits, UserContents: make([]model.UserContent, 0)}
+	if details.UserUnits == nil {
+		details.UserUnits = make([]model.UserUnit, 0)
+	}
+	for _, userContent := range userContents {
+		if userContent.CourseKey == key {
+			details.UserContents = append(details.UserContents, userContent)
+		}
+	}
+	details.ProgressArchives, err = s.app.storage.FindProgressArchives(claims.AppID, claims.OrgID, userID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeProgressArchive, nil, err)
+	}
+	return &details, nil
+}
+
+// restart the progress of a learner in a course, a module or a unit, keeping the previous attempt archived
+func (s *adminImpl) RestartCustomCourseLearner(claims *tokenauth.Claims, key string, userID string, moduleKey *string, unitKey *string) (*model.UserCourse, error) {
+	var userCourse *model.UserCourse
+	transaction := func(storageTransaction interfaces.Storage) error {
+		var err error
+		userCourse, err = storageTransaction.FindUserCourse(claims.AppID, claims.OrgID, userID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+		}
+		if userCourse == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userID, "course.key": key})
+		}
+
+		archive, err := restartProgress(storageTransaction, userCourse, moduleKey, unitKey, claims.Subject, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		// the archived progress itself is kept in the archive, so only what was restarted is recorded
+		restarted := model.ProgressArchive{ID: archive.ID, UserID: archive.UserID, CourseKey: archive.CourseKey, Scope: archive.Scope, ModuleKey: archive.ModuleKey, UnitKey: archive.UnitKey,
+			RestartedBy: archive.RestartedBy, DateCreated: archive.DateCreated}
+		return s.audit(storageTransaction, claims, model.AuditActionRestart, model.TypeProgressArchive, archive.ID, nil, restarted)
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return userCourse, nil
+}
+
+// get the completion funnel, drop-off points and streak reset distribution of a course
+func (s *adminImpl) GetCustomCourseAnalytics(claims *tokenauth.Claims, key string, inactiveDays *int) (*model.CourseAnalytics, error) {
+	days := defaultAnalyticsInactiveDays
+	if inactiveDays != nil {
+		if *inactiveDays < 1 {
+			return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"inactive_days": *inactiveDays})
+		}
+		days = *inactiveDays
+	}
+
+	course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+	}
+	stats, err := s.app.storage.FindCourseProgressStats(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseAnalytics, &logutils.FieldArgs{"key": key}, err)
+	}
+	analytics := model.NewCourseAnalytics(*course, *stats)
+
+	yes, no := true, false
+	inactiveSince := time.Now().UTC().AddDate(0, 0, -days)
+	droppedLearners, err := s.app.storage.FindLearnerProgress(claims.AppID, claims.OrgID, key, model.LearnerProgressFilter{Dropped: &yes}, 0, 0)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLearnerProgress, &logutils.FieldArgs{"key": key, "dropped": true}, err)
+	}
+	inactiveLearners, err := s.app.storage.FindLearnerProgress(claims.AppID, claims.OrgID, key, model.LearnerProgressFilter{InactiveSince: &inactiveSince, Completed: &no, Dropped: &no}, 0, 0)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLearnerProgress, &logutils.FieldArgs{"key": key, "inactive_since": inactiveSince}, err)
+	}
+	analytics.SetDropOffs(droppedLearners, inactiveLearners)
+
+	return &analytics, nil
+}
+
 func (s *adminImpl) GetCustomModules(claims *tokenauth.Claims, id *string, name *string, key *string, unitKey *string) ([]model.Module, error) {
 	var idArr, nameArr, keyArr, unitKeys []string
 
@@ -348,6 +789,11 @@ func (s *adminImpl) GetCustomModules(claims *tokenauth.Claims, id *string, name
 }
 
 func (s *adminImpl) CreateCustomModule(claims *tokenauth.Claims, item model.Module) (*model.Module, error) {
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeModule, nil, err)
+	}
+
 	transaction := func(storageTransaction interfaces.Storage) error {
 		item.ID = uuid.NewString()
 		item.AppID = claims.AppID
@@ -409,9 +855,13 @@ func (s *adminImpl) CreateCustomModule(claims *tokenauth.Claims, item model.Modu
 			}
 		}
 
-		return nil
-	}
-	return nil, s.app.storage.PerformTransaction(transaction)
+		err = s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeModule, item.Key, nil, item)
+		if err != nil {
+			return err
+		}
+		return s.auditCreated(storageTransaction, claims, nil, newUnits, newContents)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) GetCustomModule(claims *tokenauth.Claims, key string) (*model.Module, error) {
@@ -425,14 +875,18 @@ func (s *adminImpl) GetCustomModule(claims *tokenauth.Claims, key string) (*mode
 }
 
 func (s *adminImpl) UpdateCustomModule(claims *tokenauth.Claims, key string, item model.Module) (*model.Module, error) {
+	// prevent empty key and key mismatch. current implementation disallow key update
+	item.Key = key
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeModule, nil, err)
+	}
+
 	transaction := func(storageTransaction interfaces.Storage) error {
 
 		item.AppID = claims.AppID
 		item.OrgID = claims.OrgID
 
-		// prevent empty key and key mismatch. current implementation disallow key update
-		item.Key = key
-
 		// checks if updated key correctly associate with existing struct in db
 		module, err := storageTransaction.FindCustomModule(claims.AppID, claims.OrgID, key)
 		if err != nil {
@@ -464,27 +918,29 @@ func (s *adminImpl) UpdateCustomModule(claims *tokenauth.Claims, key string, ite
 			return err
 		}
 
-		return nil
+		updated, err := storageTransaction.FindCustomModule(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeModule, key, module, updated)
 	}
 
 	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) DeleteCustomModule(claims *tokenauth.Claims, key string) error {
+	item := model.NewTrashItem(claims.AppID, claims.OrgID, model.TrashTypeModule, key, claims.Subject, time.Now(), s.app.trashRetention)
 	transaction := func(storageTransaction interfaces.Storage) error {
-		appID := claims.AppID
-		orgID := claims.OrgID
-		err := storageTransaction.DeleteCustomModule(appID, orgID, key)
+		module, err := storageTransaction.FindCustomModule(claims.AppID, claims.OrgID, key)
 		if err != nil {
 			return err
 		}
 
-		err = storageTransaction.DeleteModuleKeyFromCourses(appID, orgID, key)
+		err = storageTransaction.TrashCustomModule(item)
 		if err != nil {
 			return err
 		}
-
-		return err
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeModule, key, module, nil)
 	}
 	return s.app.storage.PerformTransaction(transaction)
 }
@@ -552,7 +1008,12 @@ func (s *adminImpl) CreateCustomUnit(claims *tokenauth.Claims, item model.Unit)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		err = s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeUnit, item.Key, nil, item)
+		if err != nil {
+			return err
+		}
+		return s.auditCreated(storageTransaction, claims, nil, nil, newContents)
 	}
 	return nil, s.app.storage.PerformTransaction(transaction)
 }
@@ -609,6 +1070,15 @@ func (s *adminImpl) UpdateCustomUnit(claims *tokenauth.Claims, key string, item
 			return err
 		}
 
+		updated, err := storageTransaction.FindCustomUnit(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		err = s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeUnit, key, unit, updated)
+		if err != nil {
+			return err
+		}
+
 		// err = storageTransaction.UpdateUserUnits(key, item) //TODO: can cause problems if user unit has data stored in schedule
 		// if err != nil {
 		// 	return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
@@ -620,18 +1090,96 @@ func (s *adminImpl) UpdateCustomUnit(claims *tokenauth.Claims, key string, item
 }
 
 func (s *adminImpl) DeleteCustomUnit(claims *tokenauth.Claims, key string) error {
+	item := model.NewTrashItem(claims.AppID, claims.OrgID, model.TrashTypeUnit, key, claims.Subject, time.Now(), s.app.trashRetention)
 	transaction := func(storageTransaction interfaces.Storage) error {
-		err := storageTransaction.DeleteCustomUnit(claims.AppID, claims.OrgID, key)
+		unit, err := storageTransaction.FindCustomUnit(claims.AppID, claims.OrgID, key)
 		if err != nil {
-			return nil
+			return err
 		}
 
-		err = storageTransaction.DeleteUnitKeyFromModules(claims.AppID, claims.OrgID, key)
+		err = storageTransaction.TrashCustomUnit(item)
 		if err != nil {
 			return err
 		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeUnit, key, unit, nil)
+	}
+	return s.app.storage.PerformTransaction(transaction)
+}
 
-		return nil
+// SearchCustomCourses searches the current drafts of all modules, units and content items, or only those in a course if courseKey is given
+func (s *adminImpl) SearchCustomCourses(claims *tokenauth.Claims, text string, courseKey *string, limit *int) ([]model.SearchResult, error) {
+	var courses []model.Course
+	if courseKey != nil {
+		course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, *courseKey)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": *courseKey}, err)
+		}
+		courses = []model.Course{*course}
+	} else {
+		var err error
+		courses, err = s.app.storage.FindCustomCourses(claims.AppID, claims.OrgID, nil, nil, nil, nil)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+		}
+	}
+
+	// items not in any course are also found when searching all courses so they can be found while authoring
+	return searchCourses(s.app.storage, claims.AppID, claims.OrgID, text, courses, courseKey != nil, limit)
+}
+
+// GetTrashItems gets the deleted items which may still be restored, optionally of a single type
+func (s *adminImpl) GetTrashItems(claims *tokenauth.Claims, itemType *string) ([]model.TrashItem, error) {
+	if itemType != nil && !model.IsValidTrashType(*itemType) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"item_type": *itemType})
+	}
+
+	items, err := s.app.storage.FindTrashItems(claims.AppID, claims.OrgID, itemType)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, nil, err)
+	}
+	return items, nil
+}
+
+// RestoreTrashItem restores a deleted item with everything deleted with it
+func (s *adminImpl) RestoreTrashItem(claims *tokenauth.Claims, id string) (*model.TrashItem, error) {
+	var item *model.TrashItem
+	transaction := func(storageTransaction interfaces.Storage) error {
+		var err error
+		item, err = storageTransaction.FindTrashItem(claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, &logutils.FieldArgs{"id": id}, err)
+		}
+		if item == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeTrashItem, &logutils.FieldArgs{"id": id})
+		}
+
+		err = storageTransaction.RestoreTrashItem(claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return errors.WrapErrorAction("restoring", model.TypeTrashItem, &logutils.FieldArgs{"id": id, "type": item.Type, "key": item.Key}, err)
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionRestore, model.TypeTrashItem, id, item, nil)
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteTrashItem permanently deletes a trash item before it expires
+func (s *adminImpl) DeleteTrashItem(claims *tokenauth.Claims, id string) error {
+	transaction := func(storageTransaction interfaces.Storage) error {
+		item, err := storageTransaction.FindTrashItem(claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, &logutils.FieldArgs{"id": id}, err)
+		}
+
+		err = storageTransaction.DeleteTrashItem(claims.AppID, claims.OrgID, id)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeTrashItem, id, item, nil)
 	}
 	return s.app.storage.PerformTransaction(transaction)
 }
@@ -656,14 +1204,22 @@ func (s *adminImpl) GetCustomContents(claims *tokenauth.Claims, id *string, name
 }
 
 func (s *adminImpl) CreateCustomContent(claims *tokenauth.Claims, item model.Content) (*model.Content, error) {
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeContent, nil, err)
+	}
+
 	item.ID = uuid.NewString()
 	item.AppID = claims.AppID
 	item.OrgID = claims.OrgID
-	err := s.app.storage.InsertCustomContent(item)
-	if err != nil {
-		return nil, err
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := storageTransaction.InsertCustomContent(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeContent, item.Key, nil, item)
 	}
-	return nil, nil
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) GetCustomContent(claims *tokenauth.Claims, key string) (*model.Content, error) {
@@ -675,6 +1231,11 @@ func (s *adminImpl) GetCustomContent(claims *tokenauth.Claims, key string) (*mod
 }
 
 func (s *adminImpl) UpdateCustomContent(claims *tokenauth.Claims, key string, item model.Content) (*model.Content, error) {
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeContent, nil, err)
+	}
+
 	transaction := func(storageTransaction interfaces.Storage) error {
 		item.AppID = claims.AppID
 		item.OrgID = claims.OrgID
@@ -707,29 +1268,29 @@ func (s *adminImpl) UpdateCustomContent(claims *tokenauth.Claims, key string, it
 		if err != nil {
 			return err
 		}
-		return nil
+
+		updated, err := storageTransaction.FindCustomContent(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeContent, key, content, updated)
 	}
 	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) DeleteCustomContent(claims *tokenauth.Claims, key string) error {
+	item := model.NewTrashItem(claims.AppID, claims.OrgID, model.TrashTypeContent, key, claims.Subject, time.Now(), s.app.trashRetention)
 	transaction := func(storageTransaction interfaces.Storage) error {
-		err := storageTransaction.DeleteCustomContent(claims.AppID, claims.OrgID, key)
-		if err != nil {
-			return err
-		}
-
-		err = storageTransaction.DeleteContentKeyFromLinkedContents(claims.AppID, claims.OrgID, key)
+		content, err := storageTransaction.FindCustomContent(claims.AppID, claims.OrgID, key)
 		if err != nil {
 			return err
 		}
 
-		err = storageTransaction.DeleteContentKeyFromUnits(claims.AppID, claims.OrgID, key)
+		err = storageTransaction.TrashCustomContent(item)
 		if err != nil {
 			return err
 		}
-
-		return nil
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeContent, key, content, nil)
 	}
 	return s.app.storage.PerformTransaction(transaction)
 }
@@ -754,7 +1315,14 @@ func (s *adminImpl) CreateCustomCourseConfig(claims *tokenauth.Claims, item mode
 	item.OrgID = claims.OrgID
 	item.DateCreated = time.Now().UTC()
 
-	return nil, s.app.storage.InsertCourseConfig(item)
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := storageTransaction.InsertCourseConfig(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeCourseConfig, item.CourseKey, nil, item)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) GetCustomCourseConfig(claims *tokenauth.Claims, key string) (*model.CourseConfig, error) {
@@ -766,6 +1334,31 @@ func (s *adminImpl) GetCustomCourseConfig(claims *tokenauth.Claims, key string)
 	return courseConfig, nil
 }
 
+func (s *adminImpl) GetStreaksSimulation(claims *tokenauth.Claims, key string, start *string, hours *int) (*model.StreaksSimulation, error) {
+	startTime := time.Now().UTC()
+	if start != nil {
+		parsed, err := time.Parse(time.RFC3339, *start)
+		if err != nil {
+			return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("start"), err)
+		}
+		startTime = parsed
+	}
+
+	simulatedHours := 1
+	if hours != nil {
+		simulatedHours = *hours
+	}
+	if simulatedHours < 1 || simulatedHours > model.MaxStreaksSimulationHours {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"hours": simulatedHours})
+	}
+
+	simulation, err := s.app.streaksNotifications.simulate(claims.AppID, claims.OrgID, key, startTime, simulatedHours)
+	if err != nil {
+		return nil, errors.WrapErrorAction("simulating", model.TypeStreaksSimulation, nil, err)
+	}
+	return simulation, nil
+}
+
 func (s *adminImpl) UpdateCustomCourseConfig(claims *tokenauth.Claims, key string, item model.CourseConfig) (*model.CourseConfig, error) {
 	err := item.StreaksNotificationsConfig.ValidateTimings()
 	if err != nil {
@@ -776,11 +1369,698 @@ func (s *adminImpl) UpdateCustomCourseConfig(claims *tokenauth.Claims, key strin
 	item.OrgID = claims.OrgID
 	item.CourseKey = key
 
-	return nil, s.app.storage.UpdateCourseConfig(item)
+	transaction := func(storageTransaction interfaces.Storage) error {
+		courseConfig, err := storageTransaction.FindCourseConfig(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.UpdateCourseConfig(item)
+		if err != nil {
+			return err
+		}
+
+		updated, err := storageTransaction.FindCourseConfig(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeCourseConfig, key, courseConfig, updated)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
 }
 
 func (s *adminImpl) DeleteCustomCourseConfig(claims *tokenauth.Claims, key string) error {
-	return s.app.storage.DeleteCourseConfig(claims.AppID, claims.OrgID, key)
+	item := model.NewTrashItem(claims.AppID, claims.OrgID, model.TrashTypeCourseConfig, key, claims.Subject, time.Now(), s.app.trashRetention)
+	transaction := func(storageTransaction interfaces.Storage) error {
+		courseConfig, err := storageTransaction.FindCourseConfig(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.TrashCourseConfig(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeCourseConfig, key, courseConfig, nil)
+	}
+	return s.app.storage.PerformTransaction(transaction)
+}
+
+func (s *adminImpl) GetAchievements(claims *tokenauth.Claims, courseKey *string) ([]model.Achievement, error) {
+	achievements, err := s.app.storage.FindAchievements(claims.AppID, claims.OrgID, nil, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAchievement, nil, err)
+	}
+	return achievements, nil
+}
+
+func (s *adminImpl) CreateAchievement(claims *tokenauth.Claims, item model.Achievement) (*model.Achievement, error) {
+	item.ID = uuid.NewString()
+	item.AppID = claims.AppID
+	item.OrgID = claims.OrgID
+	item.DateCreated = time.Now().UTC()
+	item.DateUpdated = nil
+
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := s.validateAchievement(storageTransaction, item)
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.InsertAchievement(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeAchievement, item.Key, nil, item)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
+}
+
+func (s *adminImpl) GetAchievement(claims *tokenauth.Claims, key string) (*model.Achievement, error) {
+	achievement, err := s.app.storage.FindAchievement(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAchievement, nil, err)
+	}
+	return achievement, nil
+}
+
+func (s *adminImpl) UpdateAchievement(claims *tokenauth.Claims, key string, item model.Achievement) (*model.Achievement, error) {
+	item.AppID = claims.AppID
+	item.OrgID = claims.OrgID
+	// prevent empty key and key mismatch. current implementation disallow key update
+	item.Key = key
+
+	transaction := func(storageTransaction interfaces.Storage) error {
+		err := s.validateAchievement(storageTransaction, item)
+		if err != nil {
+			return err
+		}
+
+		achievement, err := storageTransaction.FindAchievement(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.UpdateAchievement(item)
+		if err != nil {
+			return err
+		}
+
+		updated, err := storageTransaction.FindAchievement(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeAchievement, key, achievement, updated)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
+}
+
+func (s *adminImpl) DeleteAchievement(claims *tokenauth.Claims, key string) error {
+	transaction := func(storageTransaction interfaces.Storage) error {
+		achievement, err := storageTransaction.FindAchievement(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+
+		err = storageTransaction.DeleteAchievement(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeAchievement, key, achievement, nil)
+	}
+	return s.app.storage.PerformTransaction(transaction)
+}
+
+func (s *adminImpl) GetCustomIntegrityReport(claims *tokenauth.Claims) (*model.IntegrityReport, error) {
+	return s.checkCustomIntegrity(s.app.storage, claims.AppID, claims.OrgID, false)
+}
+
+// repair all repairable integrity issues in a single transaction
+func (s *adminImpl) RepairCustomIntegrity(claims *tokenauth.Claims) (*model.IntegrityReport, error) {
+	var report *model.IntegrityReport
+	transaction := func(storageTransaction interfaces.Storage) error {
+		var err error
+		report, err = s.checkCustomIntegrity(storageTransaction, claims.AppID, claims.OrgID, true)
+		if err != nil {
+			return err
+		}
+		// the repairs apply to all custom courses of the app/org
+		return s.audit(storageTransaction, claims, model.AuditActionRepair, model.TypeIntegrityReport, "", nil, report)
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *adminImpl) GetCohorts(claims *tokenauth.Claims, courseKey *string) ([]model.Cohort, error) {
+	cohorts, err := s.app.storage.FindCohorts(claims.AppID, claims.OrgID, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, nil, err)
+	}
+	return cohorts, nil
+}
+
+func (s *adminImpl) CreateCohort(claims *tokenauth.Claims, item model.Cohort) (*model.Cohort, error) {
+	item.ID = uuid.NewString()
+	item.AppID = claims.AppID
+	item.OrgID = claims.OrgID
+	item.DateCreated = time.Now().UTC()
+	item.DateUpdated = nil
+
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCohort, nil, err)
+	}
+
+	transaction := func(storageTransaction interfaces.Storage) error {
+		_, err := storageTransaction.FindCustomCourse(item.AppID, item.OrgID, item.CourseKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": item.CourseKey}, err)
+		}
+
+		err = storageTransaction.InsertCohort(item)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeCohort, item.Key, nil, item)
+	}
+	return nil, s.app.storage.PerformTransaction(transaction)
+}
+
+func (s *adminImpl) GetCohort(claims *tokenauth.Claims, key string) (*model.Cohort, error) {
+	cohort, err := s.app.storage.FindCohort(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, nil, err)
+	}
+	if cohort == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCohort, &logutils.FieldArgs{"key": key})
+	}
+	return cohort, nil
+}
+
+// update a cohort and move the start date of its enrolled members if it changed
+func (s *adminImpl) UpdateCohort(claims *tokenauth.Claims, key string, item model.Cohort) (*model.Cohort, error) {
+	transaction := func(storageTransaction interfaces.Storage) error {
+		cohort, err := storageTransaction.FindCohort(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, nil, err)
+		}
+		if cohort == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeCohort, &logutils.FieldArgs{"key": key})
+		}
+		if item.CourseKey != "" && item.CourseKey != cohort.CourseKey {
+			return errors.ErrorData(logutils.StatusInvalid, "cohort course key", &logutils.FieldArgs{"key": key, "course_key": item.CourseKey})
+		}
+
+		item.AppID = claims.AppID
+		item.OrgID = claims.OrgID
+		// prevent empty key and key mismatch. current implementation disallow key update
+		item.Key = key
+		item.CourseKey = cohort.CourseKey
+		err = item.Validate()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, model.TypeCohort, nil, err)
+		}
+
+		err = storageTransaction.UpdateCohort(item)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeCohort, nil, err)
+		}
+		if !item.StartDate.Equal(cohort.StartDate) {
+			err = storageTransaction.UpdateCohortUserCourses(item.AppID, item.OrgID, key, &item.StartDate)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &logutils.FieldArgs{"cohort_key": key}, err)
+			}
+		}
+
+		updated, err := storageTransaction.FindCohort(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, nil, err)
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeCohort, key, cohort, updated)
+	}
+
+	return nil, s.app.storage.PerformTransaction(transaction)
+}
+
+// delete a cohort, leaving its members enrolled in the course without a shared start date
+func (s *adminImpl) DeleteCohort(claims *tokenauth.Claims, key string) error {
+	transaction := func(storageTransaction interfaces.Storage) error {
+		cohort, err := storageTransaction.FindCohort(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, nil, err)
+		}
+
+		err = storageTransaction.DeleteCohort(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return err
+		}
+		err = storageTransaction.UpdateCohortUserCourses(claims.AppID, claims.OrgID, key, nil)
+		if err != nil {
+			return err
+		}
+		return s.audit(storageTransaction, claims, model.AuditActionDelete, model.TypeCohort, key, cohort, nil)
+	}
+
+	return s.app.storage.PerformTransaction(transaction)
+}
+
+// enroll the members of a cohort who are not already taking its course
+//
+//	members are enrolled in batches, each in its own transaction, so members of a failed batch are reported and may be enrolled by repeating the request
+func (s *adminImpl) EnrollCohort(claims *tokenauth.Claims, key string) (*model.CohortEnrollment, error) {
+	cohort, err := s.GetCohort(claims, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the members outside the transactions since group members require requests to the Groups BB
+	userIDs, err := s.app.courseAccess.cohortMembers(*cohort)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, "cohort members", &logutils.FieldArgs{"key": key}, err)
+	}
+
+	now := time.Now().UTC()
+	course, err := s.app.storage.FindCustomCourse(cohort.AppID, cohort.OrgID, cohort.CourseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": cohort.CourseKey}, err)
+	}
+	if course.Availability.HasEnded(now) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeCourseAvailability, &logutils.FieldArgs{"key": course.Key, "course_end": course.Availability.CourseEnd})
+	}
+	// enroll the members in the latest published version if the course has been published
+	published, version, err := publishedCourse(s.app.storage, *course)
+	if err != nil {
+		return nil, err
+	}
+	courseConfig, err := s.app.storage.FindCourseConfig(cohort.AppID, cohort.OrgID, cohort.CourseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+
+	userCourses, err := s.app.storage.FindUserCourses(nil, cohort.AppID, cohort.OrgID, nil, []string{cohort.CourseKey}, nil, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	enrolled := make(map[string]bool)
+	for _, userCourse := range userCourses {
+		enrolled[userCourse.UserID] = true
+	}
+
+	enrollment := model.CohortEnrollment{Enrolled: make([]string, 0), Skipped: make([]string, 0), Failed: make([]string, 0)}
+	pending := make([]string, 0)
+	for _, userID := range userIDs {
+		if enrolled[userID] {
+			enrollment.Skipped = append(enrollment.Skipped, userID)
+			continue
+		}
+		enrolled[userID] = true // members may be listed more than once
+		pending = append(pending, userID)
+	}
+
+	for start := 0; start < len(pending); start += userBatchSize {
+		batch := pending[start:min(start+userBatchSize, len(pending))]
+		var batchEnrolled, batchSkipped []string
+		transaction := func(storageTransaction interfaces.Storage) error {
+			batchEnrolled, batchSkipped = make([]string, 0), make([]string, 0)
+			for _, userID := range batch {
+				// the member may have enrolled since the user courses were loaded
+				existing, err := storageTransaction.FindUserCourse(cohort.AppID, cohort.OrgID, userID, cohort.CourseKey)
+				if err != nil {
+					return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userID}, err)
+				}
+				if existing != nil {
+					batchSkipped = append(batchSkipped, userID)
+					continue
+				}
+
+				userCourse := model.UserCourse{ID: uuid.NewString(), AppID: cohort.AppID, OrgID: cohort.OrgID, UserID: userID, Timezone: cohort.Timezone,
+					Pauses: courseConfig.InitialPauses, Course: published, CourseVersion: version, CohortKey: &cohort.Key, CohortStart: &cohort.StartDate, DateCreated: now}
+				err = storageTransaction.InsertUserCourse(userCourse)
+				if err != nil {
+					return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userID, "cohort_key": key}, err)
+				}
+				batchEnrolled = append(batchEnrolled, userID)
+			}
+			return nil
+		}
+
+		err = s.app.storage.PerformTransaction(transaction)
+		if err != nil {
+			s.app.logger.Errorf("error enrolling %d members of cohort %s: %v", len(batch), key, err)
+			enrollment.Failed = append(enrollment.Failed, batch...)
+			continue
+		}
+		enrollment.Enrolled = append(enrollment.Enrolled, batchEnrolled...)
+		enrollment.Skipped = append(enrollment.Skipped, batchSkipped...)
+	}
+
+	err = s.audit(s.app.storage, claims, model.AuditActionEnroll, model.TypeCohortEnrollment, key, nil, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (s *adminImpl) GetCohortMembers(claims *tokenauth.Claims, key string) ([]model.UserCourse, error) {
+	_, err := s.GetCohort(claims, key)
+	if err != nil {
+		return nil, err
+	}
+
+	userCourses, err := s.app.storage.FindCohortUserCourses(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, &logutils.FieldArgs{"cohort_key": key}, err)
+	}
+	return userCourses, nil
+}
+
+// record the creation of the modules, units and contents created with a course, module or unit
+func (s *adminImpl) auditCreated(storage interfaces.Storage, claims *tokenauth.Claims, modules []model.Module, units []model.Unit, contents []model.Content) error {
+	for _, module := range modules {
+		err := s.audit(storage, claims, model.AuditActionCreate, model.TypeModule, module.Key, nil, module)
+		if err != nil {
+			return err
+		}
+	}
+	for _, unit := range units {
+		err := s.audit(storage, claims, model.AuditActionCreate, model.TypeUnit, unit.Key, nil, unit)
+		if err != nil {
+			return err
+		}
+	}
+	for _, content := range contents {
+		err := s.audit(storage, claims, model.AuditActionCreate, model.TypeContent, content.Key, nil, content)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record a change made by an admin in the audit log, where before and after are nil if the entity did not exist before or after the change
+func (s *adminImpl) audit(storage interfaces.Storage, claims *tokenauth.Claims, action string, entityType logutils.MessageDataType, entityKey string, before interface{}, after interface{}) error {
+	entry, err := model.NewAuditLog(claims.AppID, claims.OrgID, claims.Subject, action, entityType, entityKey, before, after, time.Now())
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCreate, model.TypeAuditLog, &logutils.FieldArgs{"action": action, "entity_type": entityType, "entity_key": entityKey}, err)
+	}
+	return storage.InsertAuditLog(*entry)
+}
+
+// check the achievement requirements and make sure the course and module it references exist
+func (s *adminImpl) validateAchievement(storage interfaces.Storage, item model.Achievement) error {
+	err := item.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, model.TypeAchievement, nil, err)
+	}
+
+	course, err := storage.FindCustomCourse(item.AppID, item.OrgID, item.CourseKey)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": item.CourseKey}, err)
+	}
+	if item.ModuleKey != "" {
+		found := false
+		for _, module := range course.Modules {
+			if module.Key == item.ModuleKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeModule, &logutils.FieldArgs{"course_key": item.CourseKey, "module_key": item.ModuleKey})
+		}
+	}
+	return nil
+}
+
+// report how the users enrolled in a course who have not completed it would move to a published course version (the latest if version is nil),
+// and migrate every user who is not blocked if apply is true (a dry run changes nothing)
+//
+//	users are loaded in batches and each user is migrated in their own transaction, so a failure only affects that user
+func (s *adminImpl) migrateUserCourses(claims *tokenauth.Claims, key string, version *int, apply bool) (*model.CourseMigration, error) {
+	courseVersion, err := s.app.storage.FindCourseVersion(claims.AppID, claims.OrgID, key, version)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, nil, err)
+	}
+	if courseVersion == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCourseVersion, &logutils.FieldArgs{"course_key": key, "version": version})
+	}
+
+	completed := false
+	userCourses, err := s.app.storage.FindUserCourses(nil, claims.AppID, claims.OrgID, nil, []string{key}, nil, nil, &completed)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	outdated := make([]model.UserCourse, 0)
+	for _, userCourse := range userCourses {
+		if userCourse.CourseVersion < courseVersion.Version {
+			outdated = append(outdated, userCourse)
+		}
+	}
+
+	migration := model.CourseMigration{CourseKey: key, Version: courseVersion.Version, Applied: apply, Users: make([]model.UserCourseMigration, 0)}
+	for start := 0; start < len(outdated); start += userBatchSize {
+		batch := outdated[start:min(start+userBatchSize, len(outdated))]
+		userIDs := make([]string, len(batch))
+		for i, userCourse := range batch {
+			userIDs[i] = userCourse.UserID
+		}
+		batchUserUnits, err := s.app.storage.FindUserUnits(claims.AppID, claims.OrgID, userIDs, key, nil, nil)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+		}
+		userUnits := make(map[string][]model.UserUnit)
+		for _, userUnit := range batchUserUnits {
+			userUnits[userUnit.UserID] = append(userUnits[userUnit.UserID], userUnit)
+		}
+
+		for _, userCourse := range batch {
+			var userMigration *model.UserCourseMigration
+			if apply {
+				userMigration = s.applyUserCourseMigration(userCourse, *courseVersion)
+			} else {
+				userMigration, _, _ = migrateUserUnits(userCourse, userUnits[userCourse.UserID], *courseVersion, time.Now().UTC())
+			}
+			if userMigration == nil {
+				// the user has been moved to the version or a later one since the user courses were loaded
+				continue
+			}
+
+			migration.Total++
+			switch userMigration.Status {
+			case model.UserCourseMigrationBlocked:
+				migration.Blocked++
+			case model.UserCourseMigrationFailed:
+				migration.Failed++
+			}
+			migration.Users = append(migration.Users, *userMigration)
+		}
+	}
+
+	if apply {
+		err = s.audit(s.app.storage, claims, model.AuditActionMigrate, model.TypeCourseMigration, key, nil, migration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &migration, nil
+}
+
+// migrateUserUnits moves the user units of a user course to a course version, giving the result with the user units to update and those the user completed by the move
+func migrateUserUnits(userCourse model.UserCourse, userUnits []model.UserUnit, courseVersion model.CourseVersion, now time.Time) (*model.UserCourseMigration, []model.UserUnit, []model.UserUnit) {
+	userMigration := model.UserCourseMigration{UserID: userCourse.UserID, FromVersion: userCourse.CourseVersion, Status: model.UserCourseMigrationReady,
+		Units: make([]model.UserUnitMigration, 0)}
+	migratedUnits := make([]model.UserUnit, 0)
+	completedUnits := make([]model.UserUnit, 0) // units the user completed because their schedule was shortened
+	for _, userUnit := range userUnits {
+		unitMigration := userUnit.MigrateTo(courseVersion.Course.GetUnit(userUnit.ModuleKey, userUnit.Unit.Key), courseVersion.Version, now)
+		userMigration.Units = append(userMigration.Units, unitMigration)
+		switch unitMigration.Status {
+		case model.UserUnitMigrationBlocked:
+			userMigration.Status = model.UserCourseMigrationBlocked
+		case model.UserUnitMigrationRemoved:
+			// keep the user unit as history of the previous version
+		case model.UserUnitMigrationCompleted:
+			completedUnits = append(completedUnits, userUnit)
+			migratedUnits = append(migratedUnits, userUnit)
+		default:
+			migratedUnits = append(migratedUnits, userUnit)
+		}
+	}
+	return &userMigration, migratedUnits, completedUnits
+}
+
+// applyUserCourseMigration migrates a single user to a course version in a transaction, working on the current state of their progress
+//
+//	the result is nil if the user is no longer on an older version, and has the failed status if the migration could not be performed
+func (s *adminImpl) applyUserCourseMigration(userCourse model.UserCourse, courseVersion model.CourseVersion) *model.UserCourseMigration {
+	var userMigration *model.UserCourseMigration
+	transaction := func(storageTransaction interfaces.Storage) error {
+		userMigration = nil
+		currentUserCourse, err := storageTransaction.FindUserCourse(userCourse.AppID, userCourse.OrgID, userCourse.UserID, userCourse.Course.Key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+		}
+		if currentUserCourse == nil || currentUserCourse.CourseVersion >= courseVersion.Version {
+			return nil
+		}
+		userUnits, err := storageTransaction.FindUserUnits(userCourse.AppID, userCourse.OrgID, []string{userCourse.UserID}, userCourse.Course.Key, nil, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+		}
+
+		now := time.Now().UTC()
+		var migratedUnits, completedUnits []model.UserUnit
+		userMigration, migratedUnits, completedUnits = migrateUserUnits(*currentUserCourse, userUnits, courseVersion, now)
+		if userMigration.Status == model.UserCourseMigrationBlocked {
+			return nil
+		}
+		err = s.migrateUserCourse(storageTransaction, *currentUserCourse, courseVersion, userUnits, migratedUnits, completedUnits, now)
+		if err != nil {
+			return err
+		}
+		userMigration.Status = model.UserCourseMigrationMigrated
+		return nil
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		s.app.logger.Errorf("error migrating user %s in course %s to version %d: %v", userCourse.UserID, userCourse.Course.Key, courseVersion.Version, err)
+		return &model.UserCourseMigration{UserID: userCourse.UserID, FromVersion: userCourse.CourseVersion, Status: model.UserCourseMigrationFailed,
+			Units: make([]model.UserUnitMigration, 0)}
+	}
+	return userMigration
+}
+
+// migrateUserCourse stores the migrated user units and moves the user course to the course version
+func (s *adminImpl) migrateUserCourse(storage interfaces.Storage, userCourse model.UserCourse, courseVersion model.CourseVersion, userUnits []model.UserUnit,
+	migratedUnits []model.UserUnit, completedUnits []model.UserUnit, now time.Time) error {
+	for _, userUnit := range migratedUnits {
+		err := storage.MigrateUserUnit(userUnit)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
+		}
+	}
+
+	userCourse.Course = courseVersion.Course
+	userCourse.CourseVersion = courseVersion.Version
+	for moduleKey := range userCourse.CompletedModules {
+		if userCourse.Course.GetModule(moduleKey) == nil {
+			delete(userCourse.CompletedModules, moduleKey)
+		}
+	}
+
+	// move users forward from units which are now complete in the same way as streaks processing does
+	for _, userUnit := range completedUnits {
+		if userCourse.Course.GetNextRequiredScheduleItem(userUnit.ModuleKey, userUnit.Unit.Key, userUnit.Completed, true) == nil {
+			if userCourse.CompletedModules == nil {
+				userCourse.CompletedModules = make(map[string]time.Time)
+			}
+			userCourse.CompletedModules[userUnit.ModuleKey] = now
+		}
+
+		nextUnit := userCourse.Course.GetNextUnit(userUnit.ModuleKey, userUnit.Unit.Key)
+		if nextUnit == nil {
+			continue
+		}
+		started := false
+		for _, existing := range userUnits {
+			if existing.ModuleKey == userUnit.ModuleKey && existing.Unit.Key == nextUnit.Key {
+				started = true
+				break
+			}
+		}
+		if !started {
+			nextUserSchedule := nextUnit.CreateUserSchedule()
+			nextUserSchedule[0].DateStarted = &now
+			nextUserUnit := model.UserUnit{ID: uuid.NewString(), AppID: userCourse.AppID, OrgID: userCourse.OrgID, UserID: userCourse.UserID, CourseKey: userCourse.Course.Key,
+				ModuleKey: userUnit.ModuleKey, Unit: *nextUnit, CourseVersion: courseVersion.Version, Completed: 0, Current: true, UserSchedule: nextUserSchedule, DateCreated: now}
+			err := storage.InsertUserUnit(nextUserUnit)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
+			}
+		}
+	}
+	if userCourse.DateCompleted == nil && userCourse.IsComplete() {
+		userCourse.DateCompleted = &now
+	}
+
+	err := storage.MigrateUserCourse(userCourse)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
+	}
+	return nil
+}
+
+// find dangling keys, invalid unit schedules and user units whose unit snapshot differs from the unit, repairing what can be repaired if repair is true
+//
+//	user units on published course versions are skipped since their snapshots are expected to differ
+func (s *adminImpl) checkCustomIntegrity(storage interfaces.Storage, appID string, orgID string, repair bool) (*model.IntegrityReport, error) {
+	refs, err := storage.FindCustomReferences(appID, orgID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeItemReferences, nil, err)
+	}
+
+	issues, repairedRefs := refs.Check()
+	if repair {
+		err = storage.UpdateCustomReferences(appID, orgID, repairedRefs)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeItemReferences, nil, err)
+		}
+	}
+
+	// load the units after repairing references so that user units are synced to the repaired units
+	units, err := storage.FindCustomUnits(appID, orgID, nil, nil, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUnit, nil, err)
+	}
+	unitsByKey := make(map[string]model.Unit, len(units))
+	for _, unit := range units {
+		unitsByKey[unit.Key] = unit
+	}
+
+	checkedUserUnits := 0
+	for _, course := range refs.Courses {
+		userUnits, err := storage.FindUserUnits(appID, orgID, nil, course.Key, nil, nil)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, &logutils.FieldArgs{"course_key": course.Key}, err)
+		}
+		checkedUserUnits += len(userUnits)
+
+		for _, userUnit := range userUnits {
+			unit, exists := unitsByKey[userUnit.Unit.Key]
+			if !exists {
+				issues = append(issues, model.IntegrityIssue{Type: "user_unit", Key: userUnit.ID, Problem: model.IntegrityMissingUnit, Reference: userUnit.Unit.Key})
+				continue
+			}
+			if userUnit.CourseVersion != 0 || userUnit.MatchesUnit(unit) {
+				continue
+			}
+
+			synced := userUnit.SyncToUnit(unit)
+			issues = append(issues, model.IntegrityIssue{Type: "user_unit", Key: userUnit.ID, Problem: model.IntegritySnapshotMismatch, Reference: unit.Key, Repairable: synced})
+			if repair && synced {
+				err = storage.MigrateUserUnit(userUnit)
+				if err != nil {
+					return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, &logutils.FieldArgs{"id": userUnit.ID}, err)
+				}
+			}
+		}
+	}
+
+	report := model.IntegrityReport{Issues: issues, Checked: map[string]int{"course": len(refs.Courses), "module": len(refs.Modules), "unit": len(refs.Units),
+		"content": len(refs.Contents), "user_unit": checkedUserUnits}}
+	if repair {
+		for _, issue := range issues {
+			if issue.Repairable {
+				report.Repaired++
+			}
+		}
+	}
+	return &report, nil
 }
 
 // return those inside the array that are not present in database determined by key
@@ -801,6 +2081,10 @@ func (s *adminImpl) modulesNotInDB(storage interfaces.Storage, appID string, org
 
 	for _, dataStruct := range modules {
 		if !utils.Exist(returnedKeys, dataStruct.Key) {
+			err = dataStruct.Validate()
+			if err != nil {
+				return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeModule, nil, err)
+			}
 			resultStructs = append(resultStructs, dataStruct)
 		}
 	}
@@ -849,6 +2133,10 @@ func (s *adminImpl) contentsNotInDB(storage interfaces.Storage, appID string, or
 
 	for _, dataStruct := range contents {
 		if !utils.Exist(returnedKeys, dataStruct.Key) {
+			err = dataStruct.Validate()
+			if err != nil {
+				return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeContent, nil, err)
+			}
 			resultStructs = append(resultStructs, dataStruct)
 		}
 	}
@@ -31,6 +31,9 @@ import (
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
+// defaultSearchLimit is the number of course search results returned when no limit is given
+const defaultSearchLimit int = 20
+
 type clientImpl struct {
 	app *Application
 }
@@ -129,7 +132,7 @@ func (s *clientImpl) GetCurrentUser(claims *tokenauth.Claims) (*model.User, erro
 	return user, nil
 }
 
-func (s *clientImpl) GetUserCourses(claims *tokenauth.Claims, id *string, name *string, courseKey *string) ([]model.UserCourse, error) {
+func (s *clientImpl) GetUserCourses(claims *tokenauth.Claims, id *string, name *string, courseKey *string, locale *string, acceptLanguage *string) ([]model.UserCourse, error) {
 	var idArr, nameArr, keyArr []string
 	userID := claims.Subject
 	//parse moduleID comma seperated string into array
@@ -147,15 +150,25 @@ func (s *clientImpl) GetUserCourses(claims *tokenauth.Claims, id *string, name *
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now().UTC()
+	preferences := model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage)
+	for i := range userCourses {
+		s.prepareUserCourse(&userCourses[i], now)
+		userCourses[i].Course.Localize(preferences)
+	}
 	return userCourses, nil
 }
 
 // pass usercourse id to retrieve usercourse struct
-func (s *clientImpl) GetUserCourse(claims *tokenauth.Claims, courseKey string) (*model.UserCourse, error) {
+func (s *clientImpl) GetUserCourse(claims *tokenauth.Claims, courseKey string, locale *string, acceptLanguage *string) (*model.UserCourse, error) {
 	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, courseKey)
 	if err != nil {
 		return nil, err
 	}
+	if userCourse != nil {
+		s.prepareUserCourse(userCourse, time.Now().UTC())
+		userCourse.Course.Localize(model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage))
+	}
 	return userCourse, nil
 }
 
@@ -166,6 +179,26 @@ func (s *clientImpl) CreateUserCourse(claims *tokenauth.Claims, courseKey string
 		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeTimezone, nil, err)
 	}
 
+	// check the enrollment window and visibility outside the transaction since visibility may require requests to other building blocks
+	course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+	}
+
+	// users who dropped the course resume where they left off instead of enrolling again
+	existing, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if existing != nil {
+		return s.resumeUserCourse(claims, *course, *existing, item)
+	}
+
+	err = s.app.courseAccess.forUser(claims.Subject).checkEnrollment(*course, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
 	userCourse := &model.UserCourse{ID: uuid.NewString(), AppID: claims.AppID, OrgID: claims.OrgID, UserID: claims.Subject, Timezone: item, DateCreated: time.Now()}
 	transaction := func(storage interfaces.Storage) error {
 		//retrieve course with coursekey
@@ -173,7 +206,11 @@ func (s *clientImpl) CreateUserCourse(claims *tokenauth.Claims, courseKey string
 		if err != nil {
 			return err
 		}
-		userCourse.Course = *course
+		// enroll the user in the latest published version if the course has been published
+		userCourse.Course, userCourse.CourseVersion, err = publishedCourse(storage, *course)
+		if err != nil {
+			return err
+		}
 
 		courseConfig, err := storage.FindCourseConfig(course.AppID, course.OrgID, course.Key)
 		if err != nil {
@@ -197,10 +234,49 @@ func (s *clientImpl) CreateUserCourse(claims *tokenauth.Claims, courseKey string
 	if err != nil {
 		return nil, err
 	}
+	s.prepareUserCourse(userCourse, time.Now().UTC())
 	return userCourse, nil
 }
 
-func (s *clientImpl) UpdateUserCourse(claims *tokenauth.Claims, key string, drop *bool) (*model.UserCourse, error) {
+// resumeUserCourse undoes dropping a user course, keeping all progress made before it was dropped
+func (s *clientImpl) resumeUserCourse(claims *tokenauth.Claims, course model.Course, userCourse model.UserCourse, timezone model.Timezone) (*model.UserCourse, error) {
+	if userCourse.DateDropped == nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"course.key": course.Key, "date_dropped": nil})
+	}
+
+	// the enrollment window does not apply to users who already enrolled, but the course must still be listed for them
+	now := time.Now().UTC()
+	isListed, err := s.app.courseAccess.forUser(claims.Subject).isListed(course, now)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseVisibility, &logutils.FieldArgs{"key": course.Key}, err)
+	}
+	if !isListed {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCourse, &logutils.FieldArgs{"key": course.Key})
+	}
+
+	userCourse.DateDropped = nil
+	userCourse.Timezone = timezone
+	transaction := func(storage interfaces.Storage) error {
+		err := storage.UpdateUserTimezone(claims.AppID, claims.OrgID, claims.Subject, timezone.Name, timezone.Offset)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeTimezone, nil, err)
+		}
+		err = storage.UpdateUserCourse(userCourse)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &logutils.FieldArgs{"drop": false}, err)
+		}
+		return nil
+	}
+
+	err = s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	s.prepareUserCourse(&userCourse, now)
+	return &userCourse, nil
+}
+
+func (s *clientImpl) UpdateUserCourse(claims *tokenauth.Claims, key string, drop *bool, leaderboardOptIn *bool) (*model.UserCourse, error) {
 	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
@@ -220,11 +296,255 @@ func (s *clientImpl) UpdateUserCourse(claims *tokenauth.Claims, key string, drop
 		if err != nil {
 			return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &logutils.FieldArgs{"drop": true}, err)
 		}
+		userCourse.Version++
+	}
+
+	if leaderboardOptIn != nil {
+		userCourse.LeaderboardName = nil
+		if *leaderboardOptIn {
+			if claims.Name == "" {
+				return nil, errors.ErrorData(logutils.StatusMissing, "user name", &logutils.FieldArgs{"leaderboard_opt_in": true})
+			}
+			name := claims.Name
+			userCourse.LeaderboardName = &name
+		}
+
+		err := s.app.storage.UpdateUserCourse(*userCourse)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &logutils.FieldArgs{"leaderboard_opt_in": *leaderboardOptIn}, err)
+		}
 	}
 
 	return nil, nil
 }
 
+// get the daily activity timeline of a user course within the local dates given by start and end
+func (s *clientImpl) GetUserCourseStreakHistory(claims *tokenauth.Claims, key string, start *string, end *string) (*model.StreakHistory, error) {
+	for name, date := range map[string]*string{"start": start, "end": end} {
+		if date != nil {
+			_, err := time.Parse(model.StreakHistoryDateFormat, *date)
+			if err != nil {
+				return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs(name), err)
+			}
+		}
+	}
+	if start != nil && end != nil && *start > *end {
+		return nil, errors.ErrorData(logutils.StatusInvalid, "date range", &logutils.FieldArgs{"start": *start, "end": *end})
+	}
+
+	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if userCourse == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+	}
+
+	courseConfig, err := s.app.storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+
+	userUnits, err := s.app.storage.FindUserUnits(claims.AppID, claims.OrgID, []string{claims.Subject}, key, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+	}
+
+	history := userCourse.BuildStreakHistory(userUnits, time.Now().UTC(), courseConfig.StreaksNotificationsConfig)
+	if history == nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeStreakHistory, &logutils.FieldArgs{"course.key": key})
+	}
+
+	// local dates compare the same way as strings
+	days := make([]model.StreakHistoryDay, 0)
+	for _, day := range history.Days {
+		if (start == nil || day.Date >= *start) && (end == nil || day.Date <= *end) {
+			days = append(days, day)
+		}
+	}
+	history.Days = days
+
+	return history, nil
+}
+
+// get the overall and per module progress of a user course with the schedule item to work on next and the time left in the current streak day
+func (s *clientImpl) GetUserCourseProgressSummary(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) (*model.ProgressSummary, error) {
+	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if userCourse == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+	}
+
+	courseConfig, err := s.app.storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+
+	userUnits, err := s.app.storage.FindUserUnits(claims.AppID, claims.OrgID, []string{claims.Subject}, key, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+	}
+
+	// the next schedule item content is taken from the course or user units, so it is prepared for the user first
+	preferences := model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage)
+	userCourse.Course.HideAnswers()
+	userCourse.Course.Localize(preferences)
+	for i := range userUnits {
+		userUnits[i].Unit.HideAnswers()
+		userUnits[i].Unit.Localize(preferences)
+	}
+
+	summary := userCourse.BuildProgressSummary(userUnits, time.Now().UTC(), courseConfig.StreaksNotificationsConfig)
+	if summary == nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeProgressSummary, &logutils.FieldArgs{"course.key": key})
+	}
+	return summary, nil
+}
+
+// spend pauses in advance to freeze upcoming streak days of a user course
+func (s *clientImpl) CreateUserCourseStreakFreeze(claims *tokenauth.Claims, key string, item model.StreakFreeze) (*model.UserCourse, error) {
+	var userCourse *model.UserCourse
+	transaction := func(storage interfaces.Storage) error {
+		var err error
+		userCourse, err = storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+		}
+		if userCourse == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+		}
+		if userCourse.DateDropped != nil || userCourse.DateCompleted != nil {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"id": userCourse.ID, "date_dropped": userCourse.DateDropped, "date_completed": userCourse.DateCompleted})
+		}
+
+		courseConfig, err := storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+		}
+		if item.Days < 1 || item.Days > courseConfig.MaxFreezeLength {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeStreakFreeze, &logutils.FieldArgs{"days": item.Days, "max_freeze_length": courseConfig.MaxFreezeLength})
+		}
+		if item.Days > userCourse.Pauses {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeStreakFreeze, &logutils.FieldArgs{"days": item.Days, "pauses": userCourse.Pauses})
+		}
+
+		snConfig := courseConfig.StreaksNotificationsConfig
+		loc := userCourse.StreaksLocation(snConfig)
+		now := time.Now().UTC()
+		currentDayStart := userCourse.MostRecentStreakProcessTime(&now, snConfig)
+		if item.Start.IsZero() {
+			// freeze from the next streak day by default
+			item.Start = currentDayStart.In(loc).AddDate(0, 0, 1).UTC()
+		} else {
+			item.Start = *userCourse.MostRecentStreakProcessTime(&item.Start, snConfig)
+			if item.Start.Before(*currentDayStart) {
+				return errors.ErrorData(logutils.StatusInvalid, model.TypeStreakFreeze, &logutils.FieldArgs{"start": item.Start})
+			}
+		}
+		for _, freeze := range userCourse.StreakFreezes {
+			if item.Start.Before(freeze.End(loc)) && freeze.Start.Before(item.End(loc)) {
+				return errors.ErrorData(logutils.StatusInvalid, model.TypeStreakFreeze, &logutils.FieldArgs{"start": item.Start, "days": item.Days, "overlap_start": freeze.Start})
+			}
+		}
+		item.DateCreated = now
+
+		if userCourse.StreakFreezes == nil {
+			userCourse.StreakFreezes = make([]model.StreakFreeze, 0)
+		}
+		userCourse.StreakFreezes = append(userCourse.StreakFreezes, item)
+		userCourse.Pauses -= item.Days
+
+		err = storage.UpdateUserCourse(*userCourse)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
+		}
+		return nil
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	s.prepareUserCourse(userCourse, time.Now().UTC())
+	return userCourse, nil
+}
+
+// cancel all frozen streak days of a user course that have not started yet and return their pauses, up to the maximum number of pauses
+func (s *clientImpl) DeleteUserCourseStreakFreezes(claims *tokenauth.Claims, key string) error {
+	transaction := func(storage interfaces.Storage) error {
+		userCourse, err := storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+		}
+		if userCourse == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+		}
+
+		courseConfig, err := storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+		}
+
+		snConfig := courseConfig.StreaksNotificationsConfig
+		loc := userCourse.StreaksLocation(snConfig)
+		now := time.Now().UTC()
+		// the current streak day stays frozen since it is already in progress
+		nextDayStart := userCourse.MostRecentStreakProcessTime(&now, snConfig).In(loc).AddDate(0, 0, 1).UTC()
+
+		refunded := 0
+		freezes := make([]model.StreakFreeze, 0)
+		for _, freeze := range userCourse.StreakFreezes {
+			for freeze.Days > 0 && !freeze.Start.In(loc).AddDate(0, 0, freeze.Days-1).Before(nextDayStart) {
+				freeze.Days--
+				refunded++
+			}
+			if freeze.Days > 0 {
+				freezes = append(freezes, freeze)
+			}
+		}
+		if refunded == 0 {
+			return nil
+		}
+
+		userCourse.StreakFreezes = freezes
+		// pauses earned while the freezes were outstanding may already have brought the user to the limit
+		userCourse.Pauses = min(userCourse.Pauses+refunded, max(userCourse.Pauses, courseConfig.MaxPauses))
+		err = storage.UpdateUserCourse(*userCourse)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
+		}
+		return nil
+	}
+
+	return s.app.storage.PerformTransaction(transaction)
+}
+
+// get the completion certificate of a user course, issuing it if the course has been completed but no certificate has been issued yet
+func (s *clientImpl) GetUserCourseCertificate(claims *tokenauth.Claims, key string) (*model.Certificate, error) {
+	certificate, err := s.app.storage.FindCertificate(claims.AppID, claims.OrgID, claims.Subject, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCertificate, nil, err)
+	}
+	if certificate != nil {
+		return certificate, nil
+	}
+
+	userCourse, err := s.app.storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if userCourse == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+	}
+	if userCourse.DateCompleted == nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key, "date_completed": nil})
+	}
+
+	return s.app.certificates.issueCertificate(s.app.storage, *userCourse)
+}
+
 // delete all user course derieved from a custom course
 func (s *clientImpl) DeleteUserCourse(claims *tokenauth.Claims, courseKey string) error {
 	transaction := func(storage interfaces.Storage) error {
@@ -243,34 +563,408 @@ func (s *clientImpl) DeleteUserCourse(claims *tokenauth.Claims, courseKey string
 			return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserContent, nil, err)
 		}
 
+		err = storage.DeleteUserAchievements(claims.AppID, claims.OrgID, &claims.Subject, courseKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserAchievement, nil, err)
+		}
+
+		err = storage.DeleteProgressArchives(claims.AppID, claims.OrgID, claims.Subject, courseKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionDelete, model.TypeProgressArchive, nil, err)
+		}
+
 		return nil
 	}
 
 	return s.app.storage.PerformTransaction(transaction)
 }
 
-func (s *clientImpl) GetCustomCourses(claims *tokenauth.Claims) ([]model.Course, error) {
+// restart the progress of a user in a course, a module or a unit, keeping the previous attempt archived
+func (s *clientImpl) RestartUserCourse(claims *tokenauth.Claims, key string, moduleKey *string, unitKey *string) (*model.UserCourse, error) {
+	var userCourse *model.UserCourse
+	transaction := func(storage interfaces.Storage) error {
+		var err error
+		userCourse, err = storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+		}
+		if userCourse == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"course.key": key})
+		}
+
+		_, err = restartProgress(storage, userCourse, moduleKey, unitKey, claims.Subject, time.Now().UTC())
+		return err
+	}
+
+	err := s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	s.prepareUserCourse(userCourse, time.Now().UTC())
+	return userCourse, nil
+}
+
+// get the previous attempts of a user in a course which were archived when progress was restarted
+func (s *clientImpl) GetUserCourseProgressArchives(claims *tokenauth.Claims, key string) ([]model.ProgressArchive, error) {
+	archives, err := s.app.storage.FindProgressArchives(claims.AppID, claims.OrgID, claims.Subject, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeProgressArchive, nil, err)
+	}
+	for i := range archives {
+		archives[i].HideAnswers()
+	}
+	return archives, nil
+}
+
+// restartProgress archives the user units and responses of a user in a course, a module or a unit and the units following it in its module, then restarts them
+//
+//	A restarted unit is replaced by a new current user unit, while a restarted course or module is started again by the next response as if it were new
+func restartProgress(storage interfaces.Storage, userCourse *model.UserCourse, moduleKey *string, unitKey *string, restartedBy string, now time.Time) (*model.ProgressArchive, error) {
+	scope, err := model.ProgressRestartScope(moduleKey, unitKey)
+	if err != nil {
+		return nil, err
+	}
+	if userCourse.DateDropped != nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"id": userCourse.ID, "date_dropped": userCourse.DateDropped})
+	}
+
+	courseKey := userCourse.Course.Key
+	var restartedUnit *model.Unit
+	restartedUnitKeys := make(map[string]bool) // units restarted with the given unit
+	if moduleKey != nil {
+		module := userCourse.Course.GetModule(*moduleKey)
+		if module == nil {
+			return nil, errors.ErrorData(logutils.StatusMissing, model.TypeModule, &logutils.FieldArgs{"course_key": courseKey, "key": *moduleKey})
+		}
+		if unitKey != nil {
+			for i, unit := range module.Units {
+				if unit.Key == *unitKey {
+					restartedUnit = &module.Units[i]
+				}
+				if restartedUnit != nil {
+					restartedUnitKeys[unit.Key] = true
+				}
+			}
+			if restartedUnit == nil {
+				return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUnit, &logutils.FieldArgs{"module_key": *moduleKey, "key": *unitKey})
+			}
+		}
+	}
+
+	userUnits, err := storage.FindUserUnits(userCourse.AppID, userCourse.OrgID, []string{userCourse.UserID}, courseKey, moduleKey, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+	}
+	userContents, err := storage.FindUserContents(nil, userCourse.AppID, userCourse.OrgID, userCourse.UserID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserContent, nil, err)
+	}
+
+	archive := model.ProgressArchive{ID: uuid.NewString(), AppID: userCourse.AppID, OrgID: userCourse.OrgID, UserID: userCourse.UserID, CourseKey: courseKey, Scope: scope,
+		ModuleKey: moduleKey, UnitKey: unitKey, RestartedBy: restartedBy, UserUnits: make([]model.UserUnit, 0), UserContents: make([]model.UserContent, 0), DateCreated: now}
+	userUnitIDs := make([]string, 0)
+	for _, userUnit := range userUnits {
+		if unitKey == nil || restartedUnitKeys[userUnit.Unit.Key] {
+			archive.UserUnits = append(archive.UserUnits, userUnit)
+			userUnitIDs = append(userUnitIDs, userUnit.ID)
+		}
+	}
+	if unitKey != nil && len(userUnitIDs) == 0 {
+		// units the user has not reached yet cannot be restarted
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &logutils.FieldArgs{"user_id": userCourse.UserID, "module_key": *moduleKey, "unit.key": *unitKey})
+	}
+	userContentIDs := make([]string, 0)
+	for _, userContent := range userContents {
+		if userContent.CourseKey != courseKey || (moduleKey != nil && userContent.ModuleKey != *moduleKey) || (unitKey != nil && !restartedUnitKeys[userContent.UnitKey]) {
+			continue
+		}
+		archive.UserContents = append(archive.UserContents, userContent)
+		userContentIDs = append(userContentIDs, userContent.ID)
+	}
+	if scope == model.ProgressScopeCourse {
+		before := *userCourse
+		archive.UserCourse = &before
+	}
+
+	err = storage.InsertProgressArchive(archive)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeProgressArchive, nil, err)
+	}
+	if len(userUnitIDs) > 0 {
+		err = storage.DeleteUserUnitsByIDs(userCourse.AppID, userCourse.OrgID, userUnitIDs)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserUnit, nil, err)
+		}
+	}
+	if len(userContentIDs) > 0 {
+		err = storage.DeleteUserContentsByIDs(userCourse.AppID, userCourse.OrgID, userContentIDs)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserContent, nil, err)
+		}
+	}
+
+	courseConfig, err := storage.FindCourseConfig(userCourse.AppID, userCourse.OrgID, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+
+	if restartedUnit != nil {
+		// the rest of the module is taken again from the restarted unit, starting from the current streak day
+		userSchedule := restartedUnit.CreateUserSchedule()
+		if len(userSchedule) > 0 {
+			userSchedule[0].DateStarted = userCourse.MostRecentStreakProcessTime(&now, courseConfig.StreaksNotificationsConfig)
+		}
+		userUnit := model.UserUnit{ID: uuid.NewString(), AppID: userCourse.AppID, OrgID: userCourse.OrgID, UserID: userCourse.UserID, CourseKey: courseKey, ModuleKey: *moduleKey,
+			Unit: *restartedUnit, CourseVersion: userCourse.CourseVersion, Completed: 0, Current: true, UserSchedule: userSchedule, DateCreated: now}
+		err = storage.InsertUserUnit(userUnit)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
+		}
+	}
+
+	// the course config decides whether the streak history survives restarting the course
+	userCourse.Restart(moduleKey, courseConfig.ResetStreaksOnRestart, courseConfig.InitialPauses)
+	err = storage.UpdateUserCourse(*userCourse)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
+	}
+
+	return &archive, nil
+}
+
+func (s *clientImpl) GetCustomCourses(claims *tokenauth.Claims, locale *string, acceptLanguage *string) ([]model.Course, error) {
 	courses, err := s.app.storage.FindCustomCourses(claims.AppID, claims.OrgID, nil, nil, nil, nil)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
 	}
+
+	now := time.Now().UTC()
+	access := s.app.courseAccess.forUser(claims.Subject)
+	listed := make([]model.Course, 0)
+	for _, course := range courses {
+		isListed, err := access.isListed(course, now)
+		if err != nil {
+			// hide restricted courses whose visibility cannot be checked rather than failing the whole list
+			s.app.logger.Errorf("GetCustomCourses -> error checking visibility of course %s: %v", course.Key, err)
+			continue
+		}
+		if !isListed {
+			continue
+		}
+
+		published, _, err := publishedCourse(s.app.storage, course)
+		if err != nil {
+			return nil, err
+		}
+		listed = append(listed, s.withAccessRules(published, course))
+	}
+	courses = listed
+
+	preferences := model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage)
+	for i := range courses {
+		courses[i].HideAnswers()
+		courses[i].Localize(preferences)
+	}
 	return courses, nil
 }
 
-func (s *clientImpl) GetCustomCourse(claims *tokenauth.Claims, key string) (*model.Course, error) {
+func (s *clientImpl) GetCustomCourse(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) (*model.Course, error) {
 	course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, key)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
 	}
-	return course, nil
+	isListed, err := s.app.courseAccess.forUser(claims.Subject).isListed(*course, time.Now().UTC())
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseVisibility, &logutils.FieldArgs{"key": key}, err)
+	}
+	if !isListed {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCourse, &logutils.FieldArgs{"key": key})
+	}
+
+	published, _, err := publishedCourse(s.app.storage, *course)
+	if err != nil {
+		return nil, err
+	}
+	published = s.withAccessRules(published, *course)
+	published.HideAnswers()
+	published.Localize(model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage))
+	return &published, nil
+}
+
+// withAccessRules applies the current availability and visibility of a course to its published version, since they take effect without publishing
+func (s *clientImpl) withAccessRules(published model.Course, course model.Course) model.Course {
+	published.Availability = course.Availability
+	published.Visibility = course.Visibility
+	return published
+}
+
+// publishedCourse returns the latest published version of a course and its version number, or the course itself and 0 if it has never been published
+func publishedCourse(storage interfaces.Storage, course model.Course) (model.Course, int, error) {
+	courseVersion, err := storage.FindCourseVersion(course.AppID, course.OrgID, course.Key, nil)
+	if err != nil {
+		return course, 0, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, nil, err)
+	}
+	if courseVersion == nil {
+		return course, 0, nil
+	}
+	return courseVersion.Course, courseVersion.Version, nil
+}
+
+// searchCourses runs a text search for the modules, units and content items of the courses, returning at most limit results ranked by relevance with their paths in the courses
+//
+//	If restrict is set, only items contained in the courses are searched
+func searchCourses(storage interfaces.Storage, appID string, orgID string, text string, courses []model.Course, restrict bool, limit *int) ([]model.SearchResult, error) {
+	text, searchLimit, err := searchParams(text, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var moduleKeys, unitKeys, contentKeys []string
+	if restrict {
+		moduleKeys, unitKeys, contentKeys = model.SearchKeys(courses)
+	}
+
+	// each search is limited since no more than limit results are returned overall
+	results, err := storage.SearchCustomModules(appID, orgID, text, moduleKeys, searchLimit)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeModule, nil, err)
+	}
+	units, err := storage.SearchCustomUnits(appID, orgID, text, unitKeys, searchLimit)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUnit, nil, err)
+	}
+	contents, err := storage.SearchCustomContents(appID, orgID, text, contentKeys, searchLimit)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeContent, nil, err)
+	}
+
+	results = append(append(results, units...), contents...)
+	return model.RankSearchResults(results, courses, searchLimit), nil
+}
+
+// searchParams validates the text and limit of a search, giving the trimmed text and the limit to apply
+func searchParams(text string, limit *int) (string, int, error) {
+	text, err := model.ValidateSearchText(text)
+	if err != nil {
+		return "", 0, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("text"), err)
+	}
+	searchLimit := defaultSearchLimit
+	if limit != nil {
+		if *limit <= 0 {
+			return "", 0, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"limit": *limit})
+		}
+		searchLimit = *limit
+	}
+	return text, searchLimit, nil
+}
+
+// search the modules, units and content items of the courses the user is enrolled in
+//
+//	the courses the user is taking are searched rather than the drafts, since the user may be on a published version which does not contain later draft edits
+func (s *clientImpl) SearchUserCourses(claims *tokenauth.Claims, text string, courseKey *string, limit *int, locale *string, acceptLanguage *string) ([]model.SearchResult, error) {
+	var keyArr []string
+	if courseKey != nil {
+		keyArr = strings.Split(*courseKey, ",")
+	}
+	userCourses, err := s.app.storage.FindUserCourses(nil, claims.AppID, claims.OrgID, nil, keyArr, &claims.Subject, nil, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+
+	text, searchLimit, err := searchParams(text, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := make([]model.Course, 0)
+	for _, userCourse := range userCourses {
+		if userCourse.DateDropped == nil {
+			courses = append(courses, userCourse.Course)
+		}
+	}
+	// search all localized text before the courses are localized to the preferred locale
+	results := model.SearchCourses(courses, text)
+
+	preferences := model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage)
+	for i := range courses {
+		courses[i].Localize(preferences)
+	}
+	return model.RankSearchResults(results, courses, searchLimit), nil
+}
+
+// get the leaderboard of a custom course ranked by metric, where users who have not opted in are shown by pseudonym
+func (s *clientImpl) GetCustomCourseLeaderboard(claims *tokenauth.Claims, key string, metric *string, limit *int, offset *int) (*model.Leaderboard, error) {
+	leaderboardMetric := model.LeaderboardMetricStreak
+	if metric != nil {
+		leaderboardMetric = *metric
+	}
+	if !model.IsValidLeaderboardMetric(leaderboardMetric) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"metric": leaderboardMetric})
+	}
+	if (limit != nil && *limit < 0) || (offset != nil && *offset < 0) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"limit": limit, "offset": offset})
+	}
+	// pseudonyms would not be stable without the key
+	if len(s.app.leaderboardKey) == 0 {
+		return nil, errors.ErrorData(logutils.StatusMissing, "leaderboard key", nil)
+	}
+
+	courseConfig, err := s.app.storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+	if !courseConfig.LeaderboardActive {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeLeaderboard, &logutils.FieldArgs{"course_key": key, "leaderboard_active": false})
+	}
+
+	entries, err := s.app.storage.FindLeaderboardEntries(claims.AppID, claims.OrgID, key, leaderboardMetric)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLeaderboard, nil, err)
+	}
+
+	if entries == nil {
+		entries = make([]model.LeaderboardEntry, 0)
+	}
+
+	leaderboard := model.Leaderboard{CourseKey: key, Metric: leaderboardMetric, Total: len(entries)}
+	for i := range entries {
+		// users with equal values share the same rank
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].Value == entries[i-1].Value {
+			entries[i].Rank = entries[i-1].Rank
+		}
+		entries[i].SetDisplayName(key, s.app.leaderboardKey)
+		if entries[i].UserID == claims.Subject {
+			entries[i].IsSelf = true
+			self := entries[i]
+			leaderboard.Self = &self
+		}
+	}
+
+	start := 0
+	if offset != nil {
+		start = min(*offset, len(entries))
+	}
+	end := len(entries)
+	if limit != nil {
+		end = min(start+*limit, len(entries))
+	}
+	leaderboard.Entries = entries[start:end]
+
+	return &leaderboard, nil
 }
 
 // get all userUnits from a user's given course
-func (s *clientImpl) GetUserCourseUnits(claims *tokenauth.Claims, courseKey string) ([]model.UserUnit, error) {
+func (s *clientImpl) GetUserCourseUnits(claims *tokenauth.Claims, courseKey string, locale *string, acceptLanguage *string) ([]model.UserUnit, error) {
 	userUnits, err := s.app.storage.FindUserUnits(claims.AppID, claims.OrgID, []string{claims.Subject}, courseKey, nil, nil)
 	if err != nil {
 		return nil, err
 	}
+	preferences := model.NewLocalePreferences(s.app.defaultLocale, locale, acceptLanguage)
+	for i := range userUnits {
+		userUnits[i].Unit.HideAnswers()
+		userUnits[i].Unit.Localize(preferences)
+	}
 	return userUnits, nil
 }
 
@@ -281,173 +975,305 @@ func (s *clientImpl) UpdateUserCourseModuleProgress(claims *tokenauth.Claims, co
 	}
 
 	var userUnit *model.UserUnit
+	var awards []model.UserAchievement
+	var completedCourse *model.UserCourse
 	transaction := func(storageTransaction interfaces.Storage) error {
-		userCourse, err := storageTransaction.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, courseKey)
-		if err != nil {
-			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
-		}
-		if userCourse == nil {
-			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "user_id": claims.Subject, "key": courseKey})
-		}
-		if userCourse.DateDropped != nil {
-			return errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"id": userCourse.ID, "date_dropped": userCourse.DateDropped})
-		}
+		userUnit, awards, completedCourse, err = s.applyUserResponse(storageTransaction, claims, courseKey, moduleKey, item, time.Now().UTC())
+		return err
+	}
 
-		courseConfig, err := storageTransaction.FindCourseConfig(userCourse.AppID, userCourse.OrgID, userCourse.Course.Key)
-		if err != nil {
-			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
-		}
+	err = s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
 
-		// update timezone name and offset for all user_course of a user
-		err = storageTransaction.UpdateUserTimezone(userCourse.AppID, userCourse.OrgID, userCourse.UserID, item.Name, item.Offset)
-		if err != nil {
-			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeTimezone, nil, err)
-		}
-		userCourse.Timezone.Name = item.Name
-		userCourse.Timezone.Offset = item.Offset
+	s.app.achievements.notifyAchievements(awards)
+	if completedCourse != nil {
+		s.app.certificates.issueCertificates(s.app.storage, []model.UserCourse{*completedCourse})
+	}
+	if userUnit != nil {
+		userUnit.Unit.HideAnswers()
+	}
+	return userUnit, nil
+}
 
-		// get all userUnits under this module and check for multiple current user units
-		moduleUserUnits, err := storageTransaction.FindUserUnits(claims.AppID, claims.OrgID, []string{claims.Subject}, courseKey, &moduleKey, nil)
-		if err != nil {
-			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+func (s *clientImpl) SyncUserCourseProgress(claims *tokenauth.Claims, key string, item model.ProgressSync) (*model.ProgressSyncResult, error) {
+	err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeProgressSync, nil, err)
+	}
+
+	idempotencyKeys := make([]string, len(item.Items))
+	for i, syncItem := range item.Items {
+		idempotencyKeys[i] = syncItem.IdempotencyKey
+	}
+	receipts, err := s.app.storage.FindProgressSyncReceipts(claims.AppID, claims.OrgID, claims.Subject, idempotencyKeys)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeProgressSyncReceipt, nil, err)
+	}
+	synced := make(map[string]bool, len(receipts))
+	for _, receipt := range receipts {
+		synced[receipt.IdempotencyKey] = true
+	}
+
+	result := model.ProgressSyncResult{CourseKey: key, Items: make([]model.ProgressSyncItemResult, len(item.Items))}
+	var awards []model.UserAchievement
+	var completedCourses []model.UserCourse
+	var lastApplied *time.Time
+	for i, syncItem := range item.Items {
+		itemResult := model.ProgressSyncItemResult{IdempotencyKey: syncItem.IdempotencyKey}
+		if synced[syncItem.IdempotencyKey] {
+			// the response has already been applied by an earlier request or earlier in this one
+			itemResult.Status = model.ProgressSyncStatusDuplicate
+			result.Items[i] = itemResult
+			continue
 		}
-		var currentModuleUserUnit *model.UserUnit
-		for i, uUnit := range moduleUserUnits {
-			if uUnit.Current {
-				if currentModuleUserUnit != nil {
-					return errors.ErrorData(logutils.StatusInvalid, model.TypeUserUnit, &logutils.FieldArgs{"id": uUnit.ID, "module_key": moduleKey, "current": true, "current_id": currentModuleUserUnit.ID})
-				}
-				currentModuleUserUnit = &moduleUserUnits[i]
+
+		var appliedAt time.Time
+		var itemAwards []model.UserAchievement
+		var completedCourse *model.UserCourse
+		transaction := func(storageTransaction interfaces.Storage) error {
+			err := syncItem.UserResponse.Validate()
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionValidate, model.TypeUserResponse, nil, err)
 			}
-		}
 
-		var userScheduleItem *model.UserScheduleItem
-		isCurrent := false  // whether the schedule item being updated is current
-		isRequired := false // whether the schedule item being updated is required
-		updatedUserCourse := false
-		now := time.Now().UTC()
-		var lastStreakProcess *time.Time
-		if len(moduleUserUnits) == 0 {
-			// get the requested module and validate the first unit
-			module, err := storageTransaction.FindCustomModule(claims.AppID, claims.OrgID, moduleKey)
+			userCourse, err := storageTransaction.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, key)
 			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionFind, model.TypeModule, nil, err)
+				return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
 			}
-			if len(module.Units) == 0 {
-				return errors.ErrorData(logutils.StatusInvalid, model.TypeModule, &logutils.FieldArgs{"units.length": 0})
+			earliest := lastApplied
+			if userCourse != nil && userCourse.LastResponded != nil && (earliest == nil || userCourse.LastResponded.After(*earliest)) {
+				earliest = userCourse.LastResponded
 			}
-
-			unit := module.Units[0]
-			if unit.Key != item.UnitKey {
-				return errors.ErrorData(logutils.StatusInvalid, model.TypeUnit, &logutils.FieldArgs{"unit_key": item.UnitKey})
+			appliedAt, err = syncItem.SyncTime(earliest, time.Now().UTC())
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionValidate, model.TypeProgressSyncItem, &logutils.FieldArgs{"idempotency_key": syncItem.IdempotencyKey}, err)
 			}
 
-			err = unit.Validate(nil)
+			_, itemAwards, completedCourse, err = s.applyUserResponse(storageTransaction, claims, key, syncItem.ModuleKey, syncItem.UserResponse, appliedAt)
 			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionValidate, model.TypeUnit, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "key": unit.Key}, err)
+				return err
 			}
 
-			userUnit = &model.UserUnit{ID: uuid.NewString(), AppID: claims.AppID, OrgID: claims.OrgID, UserID: claims.Subject, CourseKey: courseKey, ModuleKey: moduleKey, Unit: unit,
-				Completed: 0, Current: true, UserSchedule: unit.CreateUserSchedule(), DateCreated: now}
+			receipt := model.ProgressSyncReceipt{ID: uuid.NewString(), AppID: claims.AppID, OrgID: claims.OrgID, UserID: claims.Subject, CourseKey: key,
+				IdempotencyKey: syncItem.IdempotencyKey, Timestamp: appliedAt, DateCreated: time.Now().UTC()}
+			return storageTransaction.InsertProgressSyncReceipt(receipt)
+		}
 
-			_, lastStreakProcess, err := s.updateUserContent(storageTransaction, userUnit, item, userCourse, &now, courseConfig.StreaksNotificationsConfig)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserContent, nil, err)
-			}
+		err = s.app.storage.PerformTransaction(transaction)
+		if err != nil {
+			// rejected responses do not stop the rest from being applied
+			s.app.logger.Warnf("error syncing progress %s for user %s in course %s: %v", syncItem.IdempotencyKey, claims.Subject, key, err)
+			reason := err.Error()
+			itemResult.Status = model.ProgressSyncStatusRejected
+			itemResult.Reason = &reason
+			result.Items[i] = itemResult
+			continue
+		}
 
-			userScheduleItem, isCurrent, isRequired, updatedUserCourse, err = s.updateUserScheduleItem(storageTransaction, userUnit, item, userCourse, lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeScheduleItem, &logutils.FieldArgs{"current": true}, err)
-			}
+		synced[syncItem.IdempotencyKey] = true
+		lastApplied = &appliedAt
+		awards = append(awards, itemAwards...)
+		if completedCourse != nil {
+			completedCourses = append(completedCourses, *completedCourse)
+		}
+		itemResult.Status = model.ProgressSyncStatusApplied
+		itemResult.Timestamp = &appliedAt
+		result.Items[i] = itemResult
+	}
 
-			// user started the course so create the first user unit
-			err = storageTransaction.InsertUserUnit(*userUnit)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
-			}
-		} else {
-			// find the user unit the request wants to update
-			for _, moduleUserUnit := range moduleUserUnits {
-				if moduleUserUnit.Unit.Key == item.UnitKey {
-					userUnit = &moduleUserUnit
-					break
-				}
-			}
-			if userUnit == nil {
-				return errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "user_id": claims.Subject, "course_key": courseKey, "module_key": courseKey, "unit.key": item.UnitKey})
-			}
+	s.app.achievements.notifyAchievements(awards)
+	if len(completedCourses) > 0 {
+		s.app.certificates.issueCertificates(s.app.storage, completedCourses)
+	}
+	return &result, nil
+}
 
-			shouldUpdateUserUnit := true
-			shouldUpdateUserUnit, lastStreakProcess, err = s.updateUserContent(storageTransaction, userUnit, item, userCourse, &now, courseConfig.StreaksNotificationsConfig)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserContent, nil, err)
-			}
-			if !shouldUpdateUserUnit {
-				return nil
-			}
+// applyUserResponse saves a user's response to a task in a course module at the given time and updates the user's course progress,
+// returning the updated user unit, any achievements awarded and the user course if the response completed it
+func (s *clientImpl) applyUserResponse(storage interfaces.Storage, claims *tokenauth.Claims, courseKey string, moduleKey string, item model.UserResponse,
+	now time.Time) (*model.UserUnit, []model.UserAchievement, *model.UserCourse, error) {
+	var userUnit *model.UserUnit
+	var awards []model.UserAchievement
+	var completedCourse *model.UserCourse
+	userCourse, err := storage.FindUserCourse(claims.AppID, claims.OrgID, claims.Subject, courseKey)
+	if err != nil {
+		return nil, nil, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+	}
+	if userCourse == nil {
+		return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "user_id": claims.Subject, "key": courseKey})
+	}
+	if userCourse.DateDropped != nil {
+		return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"id": userCourse.ID, "date_dropped": userCourse.DateDropped})
+	}
 
-			userScheduleItem, isCurrent, isRequired, updatedUserCourse, err = s.updateUserScheduleItem(storageTransaction, userUnit, item, userCourse, lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeScheduleItem, &logutils.FieldArgs{"current": true}, err)
-			}
+	courseConfig, err := storage.FindCourseConfig(userCourse.AppID, userCourse.OrgID, userCourse.Course.Key)
+	if err != nil {
+		return nil, nil, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
 
-			err = storageTransaction.UpdateUserUnit(*userUnit)
-			if err != nil {
-				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
+	// update timezone name and offset for all user_course of a user
+	err = storage.UpdateUserTimezone(userCourse.AppID, userCourse.OrgID, userCourse.UserID, item.Name, item.Offset)
+	if err != nil {
+		return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeTimezone, nil, err)
+	}
+	userCourse.Timezone.Name = item.Name
+	userCourse.Timezone.Offset = item.Offset
+
+	// get all userUnits under this module and check for multiple current user units
+	moduleUserUnits, err := storage.FindUserUnits(claims.AppID, claims.OrgID, []string{claims.Subject}, courseKey, &moduleKey, nil)
+	if err != nil {
+		return nil, nil, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, nil, err)
+	}
+	var currentModuleUserUnit *model.UserUnit
+	for i, uUnit := range moduleUserUnits {
+		if uUnit.Current {
+			if currentModuleUserUnit != nil {
+				return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserUnit, &logutils.FieldArgs{"id": uUnit.ID, "module_key": moduleKey, "current": true, "current_id": currentModuleUserUnit.ID})
 			}
+			currentModuleUserUnit = &moduleUserUnits[i]
 		}
+	}
 
-		if isCurrent && isRequired && userScheduleItem.IsComplete() {
-			// update streak when user completes any required current task for the first time since last streak process
-			if userCourse.CanIncrementStreak(lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig) {
-				userCourse.Streak++
+	var userScheduleItem *model.UserScheduleItem
+	isCurrent := false  // whether the schedule item being updated is current
+	isRequired := false // whether the schedule item being updated is required
+	updatedUserCourse := false
+	var lastStreakProcess *time.Time
+	if len(moduleUserUnits) == 0 {
+		// get the requested module from the course the user is on and validate the first unit
+		module := userCourse.Course.GetModule(moduleKey)
+		if module == nil {
+			return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, model.TypeModule, &logutils.FieldArgs{"key": moduleKey})
+		}
+		if len(module.Units) == 0 {
+			return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeModule, &logutils.FieldArgs{"units.length": 0})
+		}
+		if lock := userCourse.GetModuleLock(*module, now); lock.Locked {
+			return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeModule, &logutils.FieldArgs{"key": moduleKey, "locked": true, "reason": lock.Reason})
+		}
 
-				// if the user has no active streak and no remaining pauses, then add a streak restart (user has resumed progress after some extended time)
-				if userCourse.Streak == 1 && userCourse.Pauses == 0 {
-					if userCourse.StreakRestarts == nil {
-						userCourse.StreakRestarts = make([]time.Time, 0)
-					}
-					userCourse.StreakRestarts = append(userCourse.StreakRestarts, now)
-				}
+		unit := module.Units[0]
+		if unit.Key != item.UnitKey {
+			return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUnit, &logutils.FieldArgs{"unit_key": item.UnitKey})
+		}
+
+		err = unit.Validate(nil)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeUnit, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "key": unit.Key}, err)
+		}
+
+		userUnit = &model.UserUnit{ID: uuid.NewString(), AppID: claims.AppID, OrgID: claims.OrgID, UserID: claims.Subject, CourseKey: courseKey, ModuleKey: moduleKey, Unit: unit,
+			CourseVersion: userCourse.CourseVersion, Completed: 0, Current: true, UserSchedule: unit.CreateUserSchedule(), DateCreated: now}
+
+		_, lastStreakProcess, err := s.updateUserContent(storage, userUnit, item, userCourse, &now, courseConfig.StreaksNotificationsConfig)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserContent, nil, err)
+		}
+
+		userScheduleItem, isCurrent, isRequired, updatedUserCourse, err = s.updateUserScheduleItem(storage, userUnit, item, userCourse, lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeScheduleItem, &logutils.FieldArgs{"current": true}, err)
+		}
+
+		// user started the course so create the first user unit
+		err = storage.InsertUserUnit(*userUnit)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
+		}
+	} else {
+		// find the user unit the request wants to update
+		for _, moduleUserUnit := range moduleUserUnits {
+			if moduleUserUnit.Unit.Key == item.UnitKey {
+				userUnit = &moduleUserUnit
+				break
 			}
+		}
+		if userUnit == nil {
+			return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &logutils.FieldArgs{"app_id": claims.AppID, "org_id": claims.OrgID, "user_id": claims.Subject, "course_key": courseKey, "module_key": courseKey, "unit.key": item.UnitKey})
+		}
+
+		shouldUpdateUserUnit := true
+		shouldUpdateUserUnit, lastStreakProcess, err = s.updateUserContent(storage, userUnit, item, userCourse, &now, courseConfig.StreaksNotificationsConfig)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserContent, nil, err)
+		}
+		if !shouldUpdateUserUnit {
+			return userUnit, nil, nil, nil
+		}
+
+		userScheduleItem, isCurrent, isRequired, updatedUserCourse, err = s.updateUserScheduleItem(storage, userUnit, item, userCourse, lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeScheduleItem, &logutils.FieldArgs{"current": true}, err)
+		}
 
-			userCourse.LastCompleted = &now // a current schedule item has been completed
-			updatedUserCourse = true
+		err = storage.UpdateUserUnit(*userUnit)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
 		}
+	}
 
-		if isRequired {
-			// update pause progress and pauses when user responds to any required task for the first time since last streak process
-			if userCourse.CanMakePauseProgress(lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig) {
-				if userCourse.Pauses < courseConfig.MaxPauses {
-					userCourse.PauseProgress++
+	if isCurrent && isRequired && userScheduleItem.IsComplete() {
+		// update streak when user completes any required current task for the first time since last streak process
+		if userCourse.CanIncrementStreak(lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig) {
+			userCourse.Streak++
 
-					// if the user has enough pause progress and has not reached the pause limit, add a pause
-					if userCourse.PauseProgress%courseConfig.PauseProgressReward == 0 {
-						userCourse.Pauses++
-						userCourse.PauseProgress -= courseConfig.PauseProgressReward
-					}
+			// a response synced late may complete a streak day that has already been processed
+			credited := userCourse.CreditProcessedStreakDay(now, courseConfig.StreaksNotificationsConfig)
+
+			// if the user has no active streak and no remaining pauses, then add a streak restart (user has resumed progress after some extended time)
+			if !credited && userCourse.Streak == 1 && userCourse.Pauses == 0 {
+				if userCourse.StreakRestarts == nil {
+					userCourse.StreakRestarts = make([]time.Time, 0)
 				}
+				userCourse.StreakRestarts = append(userCourse.StreakRestarts, now)
 			}
-
-			userCourse.LastResponded = &now // the user has responded to a required task
-			updatedUserCourse = true
 		}
 
-		if updatedUserCourse {
-			err = storageTransaction.UpdateUserCourse(*userCourse)
-			if err != nil {
-				return err
+		userCourse.LastCompleted = &now // a current schedule item has been completed
+		updatedUserCourse = true
+	}
+
+	if isRequired {
+		// update pause progress and pauses when user responds to any required task for the first time since last streak process
+		if userCourse.CanMakePauseProgress(lastStreakProcess, &now, courseConfig.StreaksNotificationsConfig) {
+			if userCourse.Pauses < courseConfig.MaxPauses {
+				userCourse.PauseProgress++
+
+				// if the user has enough pause progress and has not reached the pause limit, add a pause
+				if userCourse.PauseProgress%courseConfig.PauseProgressReward == 0 {
+					userCourse.Pauses++
+					userCourse.PauseProgress -= courseConfig.PauseProgressReward
+				}
 			}
 		}
 
-		return nil
+		userCourse.LastResponded = &now // the user has responded to a required task
+		updatedUserCourse = true
 	}
 
-	err = s.app.storage.PerformTransaction(transaction)
-	if err != nil {
-		return nil, err
+	if updatedUserCourse {
+		err = storage.UpdateUserCourse(*userCourse)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		achievements, err := storage.FindAchievements(claims.AppID, claims.OrgID, nil, &courseKey)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAchievement, nil, err)
+		}
+		awards, err = s.app.achievements.awardAchievements(storage, *userCourse, achievements, now)
+		if err != nil {
+			return nil, nil, nil, errors.WrapErrorAction("awarding", model.TypeAchievement, nil, err)
+		}
+
+		if userCourse.DateCompleted != nil && userCourse.DateCompleted.Equal(now) {
+			completedCourse = userCourse
+		}
 	}
-	return userUnit, nil
+
+	return userUnit, awards, completedCourse, nil
 }
 
 func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *model.UserUnit, userResponse model.UserResponse, userCourse *model.UserCourse,
@@ -457,13 +1283,29 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 		return false, nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserContentReference, &logutils.FieldArgs{"user_unit.id": userUnit.ID, "content_key": userResponse.ContentKey})
 	}
 
-	content, err := storage.FindCustomContent(userUnit.AppID, userUnit.OrgID, userResponse.ContentKey)
-	if err != nil {
-		return false, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeContent, nil, err)
+	var content *model.Content
+	var err error
+	if userUnit.CourseVersion > 0 {
+		// units taken from a published version use the content published with it
+		for i, unitContent := range userUnit.Unit.Contents {
+			if unitContent.Key == userResponse.ContentKey {
+				content = &userUnit.Unit.Contents[i]
+				break
+			}
+		}
+	} else {
+		content, err = storage.FindCustomContent(userUnit.AppID, userUnit.OrgID, userResponse.ContentKey)
+		if err != nil {
+			return false, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeContent, nil, err)
+		}
 	}
 	if content == nil {
 		return false, nil, errors.ErrorData(logutils.StatusMissing, model.TypeUnit, &logutils.FieldArgs{"key": userResponse.ContentKey})
 	}
+	err = content.ValidateResponse(userResponse.Response)
+	if err != nil {
+		return false, nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeUserResponse, &logutils.FieldArgs{"content_key": content.Key}, err)
+	}
 
 	if now == nil {
 		nowVal := time.Now().UTC()
@@ -477,7 +1319,11 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 			return false, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserContentReference, &logutils.FieldArgs{"unit_key": userUnit.Unit.Key, "content_key": content.Key, "current": false})
 		}
 		// the user has not saved any responses for this content yet, so create new user content
-		err = s.createUserContent(storage, userUnit, userContentReference, *content, userResponse.Response, *now)
+		evaluation, err := model.SubmitEvaluation(*content, userResponse.Response, nil, *now)
+		if err != nil {
+			return false, nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeUserEvaluation, nil, err)
+		}
+		err = s.createUserContent(storage, userUnit, userContentReference, *content, userResponse.Response, evaluation, *now)
 		if err != nil {
 			return false, nil, err
 		}
@@ -492,6 +1338,12 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 		}
 
 		lastUserContent := userContents[0] // FindUserContents returns UserContents sorted in reverse chronological order by DateCreated
+		// evaluation attempts carry over between responses
+		evaluation, err := model.SubmitEvaluation(*content, userResponse.Response, lastUserContent.Evaluation, *now)
+		if err != nil {
+			return false, nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeUserEvaluation, nil, err)
+		}
+
 		lastStreakProcess = userCourse.MostRecentStreakProcessTime(now, snConfig)
 		if lastStreakProcess == nil {
 			return false, nil, errors.ErrorData(logutils.StatusInvalid, "last streak process", &logutils.FieldArgs{"user_course.id": userCourse.ID, "process_time": snConfig.StreaksProcessTime, "timezone_name": snConfig.TimezoneName})
@@ -499,7 +1351,7 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 
 		if lastUserContent.DateCreated.Before(*lastStreakProcess) {
 			// last response was created before the most recent streak process, so create new user content
-			err = s.createUserContent(storage, userUnit, userContentReference, *content, userResponse.Response, *now)
+			err = s.createUserContent(storage, userUnit, userContentReference, *content, userResponse.Response, evaluation, *now)
 			if err != nil {
 				return false, nil, err
 			}
@@ -510,6 +1362,10 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 			if updateContent {
 				lastUserContent.Content = *content
 			}
+			if !utils.DeepEqual(evaluation, lastUserContent.Evaluation) {
+				lastUserContent.Evaluation = evaluation
+				updatedResponse = true
+			}
 			userContentReference.Complete = userContentReference.Complete || lastUserContent.IsComplete() // reference is complete if completed now or already complete
 
 			if updatedResponse || updateContent {
@@ -526,10 +1382,17 @@ func (s *clientImpl) updateUserContent(storage interfaces.Storage, userUnit *mod
 	return true, lastStreakProcess, nil // user response was inserted, so update user unit, user course
 }
 
-func (s *clientImpl) createUserContent(storage interfaces.Storage, userUnit *model.UserUnit, userContentReference *model.UserContentReference, content model.Content, response map[string]interface{}, now time.Time) error {
+// prepareUserCourse hides evaluation answers and sets the module lock states of a user course before it is returned to the user
+func (s *clientImpl) prepareUserCourse(userCourse *model.UserCourse, now time.Time) {
+	userCourse.Course.HideAnswers()
+	userCourse.SetModuleLocks(now)
+}
+
+func (s *clientImpl) createUserContent(storage interfaces.Storage, userUnit *model.UserUnit, userContentReference *model.UserContentReference, content model.Content, response map[string]interface{},
+	evaluation *model.UserEvaluation, now time.Time) error {
 	id := uuid.NewString()
 	userContent := model.UserContent{ID: id, AppID: userUnit.AppID, OrgID: userUnit.OrgID, UserID: userUnit.UserID, CourseKey: userUnit.CourseKey,
-		ModuleKey: userUnit.ModuleKey, UnitKey: userUnit.Unit.Key, Content: content, Response: response, DateCreated: now}
+		ModuleKey: userUnit.ModuleKey, UnitKey: userUnit.Unit.Key, Content: content, Response: response, Evaluation: evaluation, DateCreated: now}
 
 	err := storage.InsertUserContent(userContent)
 	if err != nil {
@@ -604,7 +1467,7 @@ func (s *clientImpl) updateUserScheduleItem(storage interfaces.Storage, userUnit
 						nextUserSchedule[0].DateStarted = lastStreakProcess
 					}
 					nextUserUnit := model.UserUnit{ID: uuid.NewString(), AppID: userUnit.AppID, OrgID: userUnit.OrgID, UserID: userUnit.UserID, CourseKey: userUnit.CourseKey,
-						ModuleKey: userUnit.ModuleKey, Unit: *nextUnit, Completed: 0, Current: true, UserSchedule: nextUserSchedule, DateCreated: time.Now().UTC()}
+						ModuleKey: userUnit.ModuleKey, Unit: *nextUnit, CourseVersion: userUnit.CourseVersion, Completed: 0, Current: true, UserSchedule: nextUserSchedule, DateCreated: *now}
 
 					err := storage.InsertUserUnit(nextUserUnit)
 					if err != nil {
@@ -625,9 +1488,21 @@ func (s *clientImpl) GetUserContents(claims *tokenauth.Claims, ids string) ([]mo
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserContent, nil, err)
 	}
 
+	for i := range userContents {
+		userContents[i].Content.HideAnswers()
+	}
 	return userContents, nil
 }
 
+func (s *clientImpl) GetUserAchievements(claims *tokenauth.Claims, courseKey *string) ([]model.UserAchievement, error) {
+	userAchievements, err := s.app.storage.FindUserAchievements(claims.AppID, claims.OrgID, &claims.Subject, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserAchievement, nil, err)
+	}
+
+	return userAchievements, nil
+}
+
 func (s *clientImpl) GetCustomCourseConfig(claims *tokenauth.Claims, key string) (*model.CourseConfig, error) {
 	courseConfig, err := s.app.storage.FindCourseConfig(claims.AppID, claims.OrgID, key)
 	if err != nil {
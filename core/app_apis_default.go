@@ -14,6 +14,13 @@
 
 package core
 
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
 type defaultImpl struct {
 	app *Application
 }
@@ -21,3 +28,27 @@ type defaultImpl struct {
 func (s *defaultImpl) GetVersion() (*string, error) {
 	return &s.app.version, nil
 }
+
+// VerifyCertificate verifies the signature of a certificate token
+func (s *defaultImpl) VerifyCertificate(item model.CertificateVerification) (*model.CertificateVerification, error) {
+	if item.Token == "" {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCertificateToken, nil)
+	}
+
+	verification := s.app.certificates.verify(item.Token)
+	return &verification, nil
+}
+
+// GetCertificateVerification verifies the signature of the token of an issued certificate
+func (s *defaultImpl) GetCertificateVerification(id string) (*model.CertificateVerification, error) {
+	certificate, err := s.app.storage.FindCertificateByID(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCertificate, nil, err)
+	}
+	if certificate == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCertificate, &logutils.FieldArgs{"id": id}).SetStatus(model.ErrorStatusNotFound)
+	}
+
+	verification := s.app.certificates.verifyCertificate(*certificate)
+	return &verification, nil
+}
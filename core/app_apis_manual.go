@@ -15,9 +15,14 @@
 package core
 
 import (
+	"lms/core/interfaces"
 	"lms/core/model"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/tokenauth"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 // appManual contains manual implementations
@@ -25,6 +30,221 @@ type appManual struct {
 	app *Application
 }
 
+// GetCertificateHTML renders an issued certificate as an HTML page
+func (s *appManual) GetCertificateHTML(id string) ([]byte, error) {
+	certificate, err := s.app.storage.FindCertificateByID(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCertificate, nil, err)
+	}
+	if certificate == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCertificate, &logutils.FieldArgs{"id": id}).SetStatus(model.ErrorStatusNotFound)
+	}
+
+	return s.app.certificates.renderCertificate(*certificate)
+}
+
 func (s *appManual) GetUserData(claims *tokenauth.Claims) (*model.UserDataResponse, error) {
 	return s.app.Shared.GetUserData(claims)
 }
+
+// ExportCourseBundle exports the current draft of a course with all of its modules, units and contents
+func (s *appManual) ExportCourseBundle(claims *tokenauth.Claims, key string) (*model.CourseBundle, error) {
+	course, err := s.app.storage.FindCustomCourse(claims.AppID, claims.OrgID, key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+	}
+
+	bundle := model.NewCourseBundle(*course)
+	return &bundle, nil
+}
+
+// ImportCourseBundle creates or replaces a course and all of its modules, units and contents from a bundle in a single transaction
+//
+//	Modules, units and contents which already exist are updated, so changes also apply to other courses sharing them
+//	As when a unit is updated by an admin, user units are not changed, so learners only receive schedule changes through published course versions
+func (s *appManual) ImportCourseBundle(claims *tokenauth.Claims, key string, item model.CourseBundle) (*model.Course, error) {
+	if item.Course.Key != key {
+		return nil, errors.ErrorData(logutils.StatusInvalid, "course key", &logutils.FieldArgs{"key": key, "bundle_key": item.Course.Key})
+	}
+	modules, units, contents, err := item.Validate()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseBundle, &logutils.FieldArgs{"key": key}, err)
+	}
+
+	appID := claims.AppID
+	orgID := claims.OrgID
+	admin := adminImpl{app: s.app} // imports are admin changes, so they are audited the same way
+	var course *model.Course
+	transaction := func(storageTransaction interfaces.Storage) error {
+		now := time.Now().UTC()
+
+		contentKeys := make([]string, len(contents))
+		for i, content := range contents {
+			contentKeys[i] = content.Key
+		}
+		existingContents, err := storageTransaction.FindCustomContents(appID, orgID, nil, nil, contentKeys)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeContent, nil, err)
+		}
+		newContents := make([]model.Content, 0)
+		for _, content := range contents {
+			content.AppID = appID
+			content.OrgID = orgID
+			existing := bundleFind(content.Key, existingContents, func(c model.Content) string { return c.Key })
+			if existing == nil {
+				content.ID = uuid.NewString()
+				content.DateCreated = now
+				newContents = append(newContents, content)
+				continue
+			}
+			content.ID = existing.ID
+			content.DateCreated = existing.DateCreated
+			err = storageTransaction.UpdateCustomContent(content.Key, content)
+			if err != nil {
+				return err
+			}
+			err = admin.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeContent, content.Key, *existing, content)
+			if err != nil {
+				return err
+			}
+		}
+		if len(newContents) != 0 {
+			err = storageTransaction.InsertCustomContents(newContents)
+			if err != nil {
+				return err
+			}
+		}
+
+		unitKeys := make([]string, len(units))
+		for i, unit := range units {
+			unitKeys[i] = unit.Key
+		}
+		existingUnits, err := storageTransaction.FindCustomUnits(appID, orgID, nil, nil, unitKeys, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUnit, nil, err)
+		}
+		newUnits := make([]model.Unit, 0)
+		for _, unit := range units {
+			unit.AppID = appID
+			unit.OrgID = orgID
+			existing := bundleFind(unit.Key, existingUnits, func(u model.Unit) string { return u.Key })
+			if existing == nil {
+				unit.ID = uuid.NewString()
+				unit.DateCreated = now
+				newUnits = append(newUnits, unit)
+				continue
+			}
+			unit.ID = existing.ID
+			unit.DateCreated = existing.DateCreated
+			err = storageTransaction.UpdateCustomUnit(unit.Key, unit)
+			if err != nil {
+				return err
+			}
+			err = admin.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeUnit, unit.Key, *existing, unit)
+			if err != nil {
+				return err
+			}
+		}
+		if len(newUnits) != 0 {
+			err = storageTransaction.InsertCustomUnits(newUnits)
+			if err != nil {
+				return err
+			}
+		}
+
+		moduleKeys := make([]string, len(modules))
+		for i, module := range modules {
+			moduleKeys[i] = module.Key
+		}
+		existingModules, err := storageTransaction.FindCustomModules(appID, orgID, nil, nil, moduleKeys, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeModule, nil, err)
+		}
+		newModules := make([]model.Module, 0)
+		for _, module := range modules {
+			module.AppID = appID
+			module.OrgID = orgID
+			existing := bundleFind(module.Key, existingModules, func(m model.Module) string { return m.Key })
+			if existing == nil {
+				module.ID = uuid.NewString()
+				module.DateCreated = now
+				newModules = append(newModules, module)
+				continue
+			}
+			module.ID = existing.ID
+			module.DateCreated = existing.DateCreated
+			err = storageTransaction.UpdateCustomModule(module.Key, module)
+			if err != nil {
+				return err
+			}
+			err = admin.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeModule, module.Key, *existing, module)
+			if err != nil {
+				return err
+			}
+		}
+		if len(newModules) != 0 {
+			err = storageTransaction.InsertCustomModules(newModules)
+			if err != nil {
+				return err
+			}
+		}
+
+		item.Course.AppID = appID
+		item.Course.OrgID = orgID
+		existingCourses, err := storageTransaction.FindCustomCourses(appID, orgID, nil, nil, []string{key}, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, nil, err)
+		}
+		var before *model.Course
+		if len(existingCourses) == 0 {
+			item.Course.ID = uuid.NewString()
+			item.Course.DateCreated = now
+			err = storageTransaction.InsertCustomCourse(item.Course)
+			if err != nil {
+				return err
+			}
+		} else {
+			before, err = storageTransaction.FindCustomCourse(appID, orgID, key)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+			}
+			err = storageTransaction.UpdateCustomCourse(key, item.Course)
+			if err != nil {
+				return err
+			}
+			err = storageTransaction.UpdateUserCourses(key, item.Course)
+			if err != nil {
+				return err
+			}
+		}
+
+		course, err = storageTransaction.FindCustomCourse(appID, orgID, key)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeCourse, &logutils.FieldArgs{"key": key}, err)
+		}
+		if before != nil {
+			err = admin.audit(storageTransaction, claims, model.AuditActionUpdate, model.TypeCourse, key, before, course)
+		} else {
+			err = admin.audit(storageTransaction, claims, model.AuditActionCreate, model.TypeCourse, key, nil, course)
+		}
+		if err != nil {
+			return err
+		}
+		return admin.auditCreated(storageTransaction, claims, newModules, newUnits, newContents)
+	}
+
+	err = s.app.storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return course, nil
+}
+
+func bundleFind[T any](key string, items []T, itemKey func(T) string) *T {
+	for i, item := range items {
+		if itemKey(item) == key {
+			return &items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"lms/core/interfaces"
+	"lms/core/model"
+	"lms/driven/corebb"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/keys"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+type certificatesLogic struct {
+	logger *logs.Logger
+
+	core      *corebb.Adapter
+	serviceID string
+
+	signingKey       *keys.PrivKey           // signs new certificates (nil if certificates are not issued)
+	verificationKeys map[string]*keys.PubKey // verify certificates signed by the current or a retired signing key, by key ID
+}
+
+// certificateClaims represents the claims of a certificate token
+type certificateClaims struct {
+	jwt.RegisteredClaims
+
+	AppID         string                 `json:"app_id"`
+	OrgID         string                 `json:"org_id"`
+	RecipientName string                 `json:"recipient_name,omitempty"`
+	CourseKey     string                 `json:"course_key"`
+	CourseName    string                 `json:"course_name"`
+	CourseVersion int                    `json:"course_version"`
+	DateCompleted *jwt.NumericDate       `json:"date_completed"`
+	Stats         model.CertificateStats `json:"stats"`
+}
+
+// issueCertificate signs and stores a certificate for a completed user course, or returns the certificate already issued for it
+func (c certificatesLogic) issueCertificate(storage interfaces.Storage, userCourse model.UserCourse) (*model.Certificate, error) {
+	certificate, err := storage.FindCertificate(userCourse.AppID, userCourse.OrgID, userCourse.UserID, userCourse.Course.Key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCertificate, nil, err)
+	}
+	if certificate != nil {
+		return certificate, nil
+	}
+	if c.signingKey == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, "certificate signing key", nil)
+	}
+
+	certificate, err = model.NewCertificate(userCourse, c.recipientName(userCourse.UserID), time.Now())
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeCertificate, nil, err)
+	}
+	certificate.KeyID = c.signingKey.PubKey.KeyID
+	certificate.Token, err = c.sign(*certificate)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionEncode, model.TypeCertificateToken, nil, err)
+	}
+
+	err = storage.InsertCertificate(*certificate)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeCertificate, nil, err)
+	}
+	return certificate, nil
+}
+
+// issueCertificates issues certificates for the completed user courses, logging any failures
+func (c certificatesLogic) issueCertificates(storage interfaces.Storage, userCourses []model.UserCourse) {
+	if c.signingKey == nil {
+		return
+	}
+
+	for _, userCourse := range userCourses {
+		_, err := c.issueCertificate(storage, userCourse)
+		if err != nil {
+			c.logger.Errorf("error issuing certificate for user %s and course %s: %v", userCourse.UserID, userCourse.Course.Key, err)
+		}
+	}
+}
+
+// recipientName returns the name of the user from their core account profile (empty if it cannot be loaded)
+func (c certificatesLogic) recipientName(userID string) string {
+	if c.core == nil {
+		return ""
+	}
+	account, err := c.core.GetAccountByID(userID)
+	if err != nil {
+		c.logger.Warnf("error getting core account %s for certificate: %v", userID, err)
+		return ""
+	}
+	if account == nil {
+		return ""
+	}
+	return strings.TrimSpace(account.Profile.FirstName + " " + account.Profile.LastName)
+}
+
+func (c certificatesLogic) sign(certificate model.Certificate) (string, error) {
+	claims := certificateClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   c.serviceID,
+			Subject:  certificate.UserID,
+			ID:       certificate.ID,
+			IssuedAt: jwt.NewNumericDate(certificate.DateIssued),
+		},
+		AppID: certificate.AppID, OrgID: certificate.OrgID, RecipientName: certificate.RecipientName, CourseKey: certificate.CourseKey,
+		CourseName: certificate.CourseName, CourseVersion: certificate.CourseVersion, DateCompleted: jwt.NewNumericDate(certificate.DateCompleted),
+		Stats: certificate.Stats,
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(c.signingKey.Alg), claims)
+	token.Header["kid"] = c.signingKey.PubKey.KeyID
+	return token.SignedString(c.signingKey.Key)
+}
+
+// verify checks the signature of a certificate token against the current and retired signing keys
+func (c certificatesLogic) verify(token string) model.CertificateVerification {
+	verification := model.CertificateVerification{Token: token}
+
+	algs := make([]string, 0)
+	for _, key := range c.verificationKeys {
+		algs = append(algs, key.Alg)
+	}
+	if len(algs) == 0 {
+		verification.Reason = "no certificate verification keys are configured"
+		return verification
+	}
+
+	var keyID string
+	claims := certificateClaims{}
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ = t.Header["kid"].(string)
+		key := c.verificationKeys[keyID]
+		if key == nil {
+			return nil, fmt.Errorf("unknown signing key %s", keyID)
+		}
+		if t.Method.Alg() != key.Alg {
+			return nil, fmt.Errorf("signing method %s does not match key %s", t.Method.Alg(), keyID)
+		}
+		return key.Key, nil
+	}, jwt.WithValidMethods(algs), jwt.WithIssuer(c.serviceID), jwt.WithIssuedAt())
+	if err != nil {
+		verification.Reason = err.Error()
+		return verification
+	}
+
+	certificate := model.Certificate{ID: claims.ID, AppID: claims.AppID, OrgID: claims.OrgID, UserID: claims.Subject, RecipientName: claims.RecipientName,
+		CourseKey: claims.CourseKey, CourseName: claims.CourseName, CourseVersion: claims.CourseVersion, Stats: claims.Stats, KeyID: keyID, Token: token}
+	if claims.DateCompleted != nil {
+		certificate.DateCompleted = claims.DateCompleted.UTC()
+	}
+	if claims.IssuedAt != nil {
+		certificate.DateIssued = claims.IssuedAt.UTC()
+	}
+
+	verification.Valid = true
+	verification.Certificate = &certificate
+	return verification
+}
+
+// verifyCertificate checks that the token of a stored certificate is valid and was issued for that certificate
+func (c certificatesLogic) verifyCertificate(certificate model.Certificate) model.CertificateVerification {
+	verification := c.verify(certificate.Token)
+	if verification.Valid && verification.Certificate.ID != certificate.ID {
+		return model.CertificateVerification{Token: certificate.Token, Reason: "token was not issued for this certificate"}
+	}
+	return verification
+}
+
+var certificateTemplate = template.Must(template.New("certificate").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Certificate.CourseName}} Certificate of Completion</title>
+<style>
+body { font-family: Georgia, serif; background: #f4f4f4; margin: 0; padding: 2em; }
+.certificate { max-width: 48em; margin: auto; background: #fff; border: 0.5em double #13294b; padding: 3em; text-align: center; }
+.recipient { font-size: 2em; margin: 0.5em 0; }
+.course { font-size: 1.5em; color: #13294b; }
+.stats { margin: 2em 0; }
+.stats span { display: inline-block; margin: 0 1em; }
+.verification { font-family: sans-serif; font-size: 0.8em; color: #555; }
+.valid { color: #2e7d32; }
+.invalid { color: #c62828; }
+</style>
+</head>
+<body>
+<div class="certificate">
+<h1>Certificate of Completion</h1>
+<p>This certifies that</p>
+<p class="recipient">{{if .Certificate.RecipientName}}{{.Certificate.RecipientName}}{{else}}a learner{{end}}</p>
+<p>has completed</p>
+<p class="course">{{.Certificate.CourseName}}</p>
+<p>on {{.Certificate.DateCompleted.Format "January 2, 2006"}}</p>
+<div class="stats">
+<span>{{.Certificate.Stats.CompletedModules}} modules completed</span>
+<span>{{.Certificate.Stats.Days}} days</span>
+<span>{{.Certificate.Stats.Streak}} day streak</span>
+</div>
+<div class="verification">
+{{if .Valid}}<p class="valid">Signature verified</p>{{else}}<p class="invalid">Signature could not be verified: {{.Reason}}</p>{{end}}
+<p>Certificate ID {{.Certificate.ID}} issued {{.Certificate.DateIssued.Format "January 2, 2006"}}</p>
+<p><a href="verification">Verify this certificate</a></p>
+</div>
+</div>
+</body>
+</html>
+`))
+
+// renderCertificate renders a stored certificate as an HTML page showing whether its signature is valid
+func (c certificatesLogic) renderCertificate(certificate model.Certificate) ([]byte, error) {
+	verification := c.verifyCertificate(certificate)
+	data := struct {
+		Certificate model.Certificate
+		Valid       bool
+		Reason      string
+	}{Certificate: certificate, Valid: verification.Valid, Reason: verification.Reason}
+
+	var buf bytes.Buffer
+	err := certificateTemplate.Execute(&buf, data)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGenerate, model.TypeCertificate, &logutils.FieldArgs{"id": certificate.ID}, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newCertificatesLogic creates the certificates logic with the current signing key and the public keys of retired signing keys
+func newCertificatesLogic(logger *logs.Logger, core *corebb.Adapter, serviceID string, signingKey *keys.PrivKey, retiredKeys []keys.PubKey) certificatesLogic {
+	verificationKeys := make(map[string]*keys.PubKey)
+	for i := range retiredKeys {
+		verificationKeys[retiredKeys[i].KeyID] = &retiredKeys[i]
+	}
+	if signingKey != nil && signingKey.PubKey != nil {
+		verificationKeys[signingKey.PubKey.KeyID] = signingKey.PubKey
+	}
+	if signingKey == nil {
+		logger.Warn("no certificate signing key configured - certificates will not be issued")
+	}
+
+	return certificatesLogic{logger: logger, core: core, serviceID: serviceID, signingKey: signingKey, verificationKeys: verificationKeys}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"lms/core/interfaces"
+	"lms/core/model"
+	cacheadapter "lms/driven/cache"
+	"lms/driven/corebb"
+	"sort"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const groupMembersBlockSize int = 500
+
+type courseAccessLogic struct {
+	logger *logs.Logger
+
+	groupsBB     interfaces.GroupsBB
+	core         *corebb.Adapter
+	cacheAdapter *cacheadapter.CacheAdapter
+}
+
+// courseAccess checks the courses available to a single user, loading their roles and group memberships only when a course requires them
+type courseAccess struct {
+	logic  courseAccessLogic
+	userID string
+
+	roles  map[string]bool // role names and IDs, nil until loaded
+	groups map[string]bool // membership by group title
+}
+
+// forUser returns the course access of the user
+func (c courseAccessLogic) forUser(userID string) *courseAccess {
+	return &courseAccess{logic: c, userID: userID, groups: make(map[string]bool)}
+}
+
+// isListed returns whether the course is visible to the user and has not ended
+func (a *courseAccess) isListed(course model.Course, now time.Time) (bool, error) {
+	if course.Availability.HasEnded(now) {
+		return false, nil
+	}
+	return a.isVisible(course)
+}
+
+// checkEnrollment returns an error if the user may not enroll in the course at now
+func (a *courseAccess) checkEnrollment(course model.Course, now time.Time) error {
+	visible, err := a.isVisible(course)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, model.TypeCourseVisibility, &logutils.FieldArgs{"key": course.Key}, err)
+	}
+	if !visible {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeCourse, &logutils.FieldArgs{"key": course.Key})
+	}
+	if !course.Availability.IsEnrollmentOpen(now) {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeCourseAvailability, &logutils.FieldArgs{"key": course.Key, "enrollment_start": course.Availability.EnrollmentStart,
+			"enrollment_end": course.Availability.EnrollmentEnd, "course_end": course.Availability.CourseEnd})
+	}
+	return nil
+}
+
+// isVisible returns whether the user has any of the roles or is a member of any of the groups the course is restricted to
+func (a *courseAccess) isVisible(course model.Course) (bool, error) {
+	if !course.Visibility.IsRestricted() {
+		return true, nil
+	}
+
+	if len(course.Visibility.Roles) > 0 {
+		err := a.loadRoles()
+		if err != nil {
+			return false, err
+		}
+		for _, role := range course.Visibility.Roles {
+			if a.roles[role] {
+				return true, nil
+			}
+		}
+	}
+
+	for _, groupTitle := range course.Visibility.Groups {
+		member, err := a.isGroupMember(groupTitle)
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *courseAccess) loadRoles() error {
+	if a.roles != nil {
+		return nil
+	}
+
+	account, err := a.logic.core.GetAccountByID(a.userID)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionGet, "core account", &logutils.FieldArgs{"id": a.userID}, err)
+	}
+	a.roles = make(map[string]bool)
+	if account != nil {
+		for _, role := range account.Roles {
+			a.roles[role.ID] = true
+			a.roles[role.Name] = true
+		}
+	}
+	return nil
+}
+
+func (a *courseAccess) isGroupMember(groupTitle string) (bool, error) {
+	if member, checked := a.groups[groupTitle]; checked {
+		return member, nil
+	}
+
+	members, err := a.logic.groupMembers(groupTitle)
+	if err != nil {
+		return false, err
+	}
+
+	a.groups[groupTitle] = members[a.userID]
+	return a.groups[groupTitle], nil
+}
+
+// cohortMembers returns the sorted user IDs of the members of a cohort
+func (c courseAccessLogic) cohortMembers(cohort model.Cohort) ([]string, error) {
+	if cohort.GroupTitle == "" {
+		userIDs := make([]string, 0, len(cohort.AccountIDs))
+		seen := make(map[string]bool)
+		for _, accountID := range cohort.AccountIDs {
+			if accountID != "" && !seen[accountID] {
+				seen[accountID] = true
+				userIDs = append(userIDs, accountID)
+			}
+		}
+		sort.Strings(userIDs)
+		return userIDs, nil
+	}
+
+	members, err := c.groupMembers(cohort.GroupTitle)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(members))
+	for userID, member := range members {
+		if member {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+// groupMembers returns the members of a Groups BB group from the cache, loading them if they are not cached
+func (c courseAccessLogic) groupMembers(groupTitle string) (map[string]bool, error) {
+	members, found := c.cacheAdapter.GetGroupMembers(groupTitle)
+	if found {
+		return members, nil
+	}
+
+	members, err := c.loadGroupMembers(groupTitle)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheAdapter.SetGroupMembers(groupTitle, members)
+	return members, nil
+}
+
+// loadGroupMembers loads the user IDs of all members of a Groups BB group
+func (c courseAccessLogic) loadGroupMembers(groupTitle string) (map[string]bool, error) {
+	members := make(map[string]bool)
+	for offset := 0; ; offset += groupMembersBlockSize {
+		users, err := c.groupsBB.GetUsers(groupTitle, offset, groupMembersBlockSize)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionGet, "group members", &logutils.FieldArgs{"group": groupTitle}, err)
+		}
+		for _, user := range users {
+			members[user.UserID] = true
+		}
+		if len(users) < groupMembersBlockSize {
+			return members, nil
+		}
+	}
+}
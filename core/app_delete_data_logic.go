@@ -192,6 +192,41 @@ func (d deleteDataLogic) deleteAppOrgUsersData(appID string, orgID string, accou
 		return
 	}
 
+	// delete user achievements
+	err = d.storage.DeleteUserAchievementsByAccountsIDs(nil, appID, orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting user achievements by account ID - %s", err)
+		return
+	}
+
+	// delete certificates
+	err = d.storage.DeleteCertificatesByAccountsIDs(nil, appID, orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting certificates by account ID - %s", err)
+		return
+	}
+
+	// delete user data kept in the trash
+	err = d.storage.DeleteTrashDocumentsByAccountsIDs(nil, appID, orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting trash documents by account ID - %s", err)
+		return
+	}
+
+	// delete the receipts of synced progress
+	err = d.storage.DeleteProgressSyncReceiptsByAccountsIDs(nil, appID, orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting progress sync receipts by account ID - %s", err)
+		return
+	}
+
+	// delete previous attempts kept when progress was restarted
+	err = d.storage.DeleteProgressArchivesByAccountsIDs(nil, appID, orgID, accountsIDs)
+	if err != nil {
+		d.logger.Errorf("error deleting progress archives by account ID - %s", err)
+		return
+	}
+
 	//delete rgw adaorer users
 	err = d.storage.DeleteUsersByNetIDs(nil, netIDs)
 	if err != nil {
@@ -126,6 +126,17 @@ func (s *appShared) GetUserData(claims *tokenauth.Claims) (*model.UserDataRespon
 		return nil, errList
 	}
 
+	// answer keys of evaluations are never returned to users
+	for i := range courses {
+		courses[i].Course.HideAnswers()
+	}
+	for i := range units {
+		units[i].Unit.HideAnswers()
+	}
+	for i := range contents {
+		contents[i].Content.HideAnswers()
+	}
+
 	// Construct the user data response
 	userData := model.UserDataResponse{
 		ProviderCourses:    providerCourses,
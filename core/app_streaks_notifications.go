@@ -37,6 +37,14 @@ type streaksNotifications struct {
 
 	storage interfaces.Storage
 
+	achievements achievementsLogic
+	certificates certificatesLogic
+
+	// clock returns the current time at which streaks and notifications are processed
+	clock func() time.Time
+	// simulation records the changes made while processing instead of sending notifications if set
+	simulation *model.StreaksSimulationHour
+
 	//notifications timer
 	notificationsTimer     *time.Timer
 	notificationsTimerDone chan bool
@@ -74,8 +82,7 @@ func (n streaksNotifications) setupNotificationsTimer() {
 func (n streaksNotifications) processNotifications() {
 	funcName := "processNotifications"
 	// omit minutes and seconds so that we only need to handle integer multiples of seconds per hour
-	now := time.Now().UTC().Truncate(time.Hour)
-	nowSeconds := utils.SecondsInHour * now.Hour()
+	now := n.clock().UTC().Truncate(time.Hour)
 
 	active := true
 	courseConfigs, err := n.storage.FindCourseConfigs(nil, nil, &active)
@@ -89,46 +96,59 @@ func (n streaksNotifications) processNotifications() {
 	}
 
 	for _, config := range courseConfigs {
-		for _, notification := range config.StreaksNotificationsConfig.Notifications {
-			if notification.Active {
-				_, userUnits, userIDs, err := n.getUserDataForTimezone(config, notification.ProcessTime, nowSeconds)
-				if err != nil {
-					n.logger.Errorf("%s -> error finding user courses and user units for course key %s: %v", funcName, config.CourseKey, err)
-					continue
-				}
+		n.processNotificationsForConfig(config, now)
+	}
+}
 
-				for reqKey, reqVal := range notification.Requirements {
-					if reqKey == "completed" && reqVal == false {
-						userIDs, err = n.filterUsersByIncomplete(userUnits, userIDs, now, config.StreaksNotificationsConfig.StreaksProcessTime, notification.ProcessTime)
-						if err != nil {
-							n.logger.Errorf("%s -> error filtering users by incomplete for notification %s in course config %s: %v", funcName, notification.Subject, config.ID, err)
-							continue
-						}
+func (n streaksNotifications) processNotificationsForConfig(config model.CourseConfig, now time.Time) {
+	funcName := "processNotifications"
+	nowSeconds := utils.SecondsInHour * now.Hour()
+
+	for _, notification := range config.StreaksNotificationsConfig.Notifications {
+		if notification.Active {
+			userCourses, userUnits, userIDs, err := n.getUserDataForTimezone(config, notification.ProcessTime, nowSeconds)
+			if err != nil {
+				n.logger.Errorf("%s -> error finding user courses and user units for course key %s: %v", funcName, config.CourseKey, err)
+				continue
+			}
+
+			for reqKey, reqVal := range notification.Requirements {
+				if reqKey == "completed" && reqVal == false {
+					userIDs, err = n.filterUsersByIncomplete(userUnits, userIDs, now, config.StreaksNotificationsConfig.StreaksProcessTime, notification.ProcessTime)
+					if err != nil {
+						n.logger.Errorf("%s -> error filtering users by incomplete for notification %s in course config %s: %v", funcName, notification.Subject, config.ID, err)
+						continue
 					}
-					//TODO: add more requirement checks as needed here (userIDs, err = n.<function name>(userUnits, userIDs, ...))
 				}
+				//TODO: add more requirement checks as needed here (userIDs, err = n.<function name>(userUnits, userIDs, ...))
+			}
+			// users do not need to be reminded of tasks on days they have frozen
+			userIDs = n.filterUsersByFrozen(userCourses, userIDs, now, config.StreaksNotificationsConfig)
 
-				if len(userIDs) == 0 {
-					n.logger.Infof("%s -> no recipients for notification %s for course key %s", funcName, notification.Subject, config.CourseKey)
-					continue
-				}
+			if len(userIDs) == 0 {
+				n.logger.Infof("%s -> no recipients for notification %s for course key %s", funcName, notification.Subject, config.CourseKey)
+				continue
+			}
+			if n.simulation != nil {
+				n.simulation.Notifications = append(n.simulation.Notifications, model.StreaksSimulationNotification{Subject: notification.Subject, UserIDs: userIDs})
+				continue
+			}
 
-				recipients := make([]notifications.Recipient, len(userIDs))
-				for i, userID := range userIDs {
-					recipients[i] = notifications.Recipient{UserID: userID}
-				}
+			recipients := make([]notifications.Recipient, len(userIDs))
+			for i, userID := range userIDs {
+				recipients[i] = notifications.Recipient{UserID: userID}
+			}
 
-				switch config.StreaksNotificationsConfig.NotificationsMode {
-				case "normal":
-					err = n.notificationsBB.SendNotifications(recipients, notification.Subject, notification.Body, notification.Params)
-					if err != nil {
-						n.logger.Errorf("%s -> error sending notification %s for course key %s: %v", funcName, notification.Subject, config.CourseKey, err)
-					} else {
-						n.logger.Infof("%s -> sent notification %s for course key %s", funcName, notification.Subject, config.CourseKey)
-					}
-				case "test":
-					n.logger.Infof("%s -> (test) notification %s would be sent to %d users for course key %s", funcName, notification.Subject, len(userIDs), config.CourseKey)
+			switch config.StreaksNotificationsConfig.NotificationsMode {
+			case "normal":
+				err = n.notificationsBB.SendNotifications(recipients, notification.Subject, notification.Body, notification.Params)
+				if err != nil {
+					n.logger.Errorf("%s -> error sending notification %s for course key %s: %v", funcName, notification.Subject, config.CourseKey, err)
+				} else {
+					n.logger.Infof("%s -> sent notification %s for course key %s", funcName, notification.Subject, config.CourseKey)
 				}
+			case "test":
+				n.logger.Infof("%s -> (test) notification %s would be sent to %d users for course key %s", funcName, notification.Subject, len(userIDs), config.CourseKey)
 			}
 		}
 	}
@@ -155,8 +175,7 @@ func (n streaksNotifications) setupStreaksTimer() {
 func (n streaksNotifications) processStreaks() {
 	funcName := "processStreaks"
 	// omit minutes and seconds so that we only need to handle integer multiples of seconds per hour
-	now := time.Now().UTC().Truncate(time.Hour)
-	nowSeconds := utils.SecondsInHour * now.Hour()
+	now := n.clock().UTC().Truncate(time.Hour)
 
 	courseConfigs, err := n.storage.FindCourseConfigs(nil, nil, nil)
 	if err != nil {
@@ -171,111 +190,271 @@ func (n streaksNotifications) processStreaks() {
 	// batch the following if number of user courses gets large
 	// careful running this with multiple service instances - not all storage operations used here are idempotent
 	for _, config := range courseConfigs {
-		userCourses, currentUserUnits, _, err := n.getUserDataForTimezone(config, config.StreaksNotificationsConfig.StreaksProcessTime, nowSeconds)
-		if err != nil {
-			n.logger.Errorf("%s -> error finding user courses and user units for course key %s: %v", funcName, config.CourseKey, err)
+		n.processStreaksForConfig(config, now)
+	}
+}
+
+func (n streaksNotifications) processStreaksForConfig(config model.CourseConfig, now time.Time) {
+	funcName := "processStreaks"
+	nowSeconds := utils.SecondsInHour * now.Hour()
+
+	achievements, err := n.storage.FindAchievements(config.AppID, config.OrgID, nil, &config.CourseKey)
+	if err != nil {
+		n.logger.Errorf("%s -> error finding achievements for course key %s: %v", funcName, config.CourseKey, err)
+	}
+
+	userCourses, currentUserUnits, _, err := n.getUserDataForTimezone(config, config.StreaksNotificationsConfig.StreaksProcessTime, nowSeconds)
+	if err != nil {
+		n.logger.Errorf("%s -> error finding user courses and user units for course key %s: %v", funcName, config.CourseKey, err)
+		return
+	}
+
+	usePause := make([]string, 0)    // list of userIDs where pauses should be decremented
+	useFreeze := make([]string, 0)   // list of userIDs where the day has been frozen in advance
+	resetStreak := make([]string, 0) // list of userIDs where streak should be reset
+	advancedUnits := make([]model.StreaksSimulationUnit, 0)
+	completedCourses := make([]model.UserCourse, 0) // user courses completed while processing
+	for userID, userUnits := range currentUserUnits {
+		var userCourse *model.UserCourse
+		for i, uc := range userCourses {
+			if uc.UserID == userID {
+				userCourse = &userCourses[i]
+				break
+			}
+		}
+		if userCourse == nil {
+			n.logger.Errorf("%s -> error matching user course for user ID %s: %v", funcName, userID, err)
 			continue
 		}
 
-		usePause := make([]string, 0)    // list of userIDs where pauses should be decremented
-		resetStreak := make([]string, 0) // list of userIDs where streak should be reset
-		for userID, userUnits := range currentUserUnits {
-			var userCourse *model.UserCourse
-			for i, uc := range userCourses {
-				if uc.UserID == userID {
-					userCourse = &userCourses[i]
-					break
-				}
+		incompleteTaskHandler := func(incompleteUserID string) error {
+			// if task is incomplete, use the freeze for the day that just ended if there is one
+			if userCourse.IsFrozen(now.Add(-time.Second), config.StreaksNotificationsConfig) {
+				useFreeze = append(useFreeze, incompleteUserID)
+				return nil
 			}
-			if userCourse == nil {
-				n.logger.Errorf("%s -> error matching user course for user ID %s: %v", funcName, userID, err)
-				continue
+			// otherwise use a pause or reset the streak depending on the current number of pauses
+			if userCourse.Pauses > 0 {
+				usePause = append(usePause, incompleteUserID)
+			} else {
+				resetStreak = append(resetStreak, incompleteUserID)
 			}
-
-			incompleteTaskHandler := func(incompleteUserID string) error {
-				// if task is incomplete, use a pause or reset the streak depending on the current number of pauses
-				if userCourse.Pauses > 0 {
-					usePause = append(usePause, incompleteUserID)
-				} else {
-					resetStreak = append(resetStreak, incompleteUserID)
+			return nil
+		}
+		// the user course as updated by completing a module, which only applies once the transaction has been committed
+		var completedUserCourse *model.UserCourse
+		completeTaskHandler := func(storage interfaces.Storage, item model.UserUnit, remainsCurrent bool) error {
+			// the previous task was completed, so set the start time of the new task to now (beginning of the day)
+			item.Completed++
+			item.Current = remainsCurrent
+			if remainsCurrent {
+				userScheduleItem, _, _, _ := item.GetScheduleItem("", true)
+				if userScheduleItem == nil {
+					return errors.ErrorData(logutils.StatusMissing, model.TypeScheduleItem, &logutils.FieldArgs{"current": true})
 				}
-				return nil
+				userScheduleItem.DateStarted = &now
 			}
-			completeTaskHandler := func(storage interfaces.Storage, item model.UserUnit, remainsCurrent bool) error {
-				// the previous task was completed, so set the start time of the new task to now (beginning of the day)
-				item.Completed++
-				item.Current = remainsCurrent
-				if remainsCurrent {
-					userScheduleItem, _, _, _ := item.GetScheduleItem("", true)
-					if userScheduleItem == nil {
-						return errors.ErrorData(logutils.StatusMissing, model.TypeScheduleItem, &logutils.FieldArgs{"current": true})
-					}
-					userScheduleItem.DateStarted = &now
-				}
 
-				err := storage.UpdateUserUnit(item)
+			err := storage.UpdateUserUnit(item)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
+			}
+
+			// if there are no more required schedule items to be done in the course, set date completed
+			// allow the new current schedule item to be returned if current schedule item not required because userUnit.Completed has already been incremented
+			if userCourse.Course.GetNextRequiredScheduleItem(item.ModuleKey, item.Unit.Key, item.Completed, true) == nil {
+				// the user course may have been updated by the user since it was loaded for processing
+				currentUserCourse, err := storage.FindUserCourse(userCourse.AppID, userCourse.OrgID, userCourse.UserID, userCourse.Course.Key)
 				if err != nil {
-					return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, nil, err)
+					return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, nil, err)
+				}
+				if currentUserCourse == nil {
+					return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userCourse.UserID, "course.key": userCourse.Course.Key})
 				}
 
-				// if there are no more required schedule items to be done in the course, set date completed
-				// allow the new current schedule item to be returned if current schedule item not required because userUnit.Completed has already been incremented
-				if userCourse.Course.GetNextRequiredScheduleItem(item.ModuleKey, item.Unit.Key, item.Completed, true) == nil {
-					if userCourse.CompletedModules == nil {
-						userCourse.CompletedModules = make(map[string]time.Time)
-					}
-					userCourse.CompletedModules[item.ModuleKey] = now
-
-					if userCourse.DateCompleted == nil && userCourse.IsComplete() {
-						userCourse.DateCompleted = &now // prevents streak timer from operating on any data associated with this UserCourse
-					}
+				if currentUserCourse.CompletedModules == nil {
+					currentUserCourse.CompletedModules = make(map[string]time.Time)
+				}
+				currentUserCourse.CompletedModules[item.ModuleKey] = now
 
-					err := storage.UpdateUserCourse(*userCourse)
-					if err != nil {
-						return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
-					}
+				if currentUserCourse.DateCompleted == nil && currentUserCourse.IsComplete() {
+					currentUserCourse.DateCompleted = &now // prevents streak timer from operating on any data associated with this UserCourse
 				}
 
-				return nil
-			}
-			completeUnitHandler := func(storage interfaces.Storage, item model.UserUnit) error {
-				// insert the next user unit if the unit exists since the current one has been completed
-				nextUnit := userCourse.Course.GetNextUnit(item.ModuleKey, item.Unit.Key)
-				if nextUnit != nil {
-					nextUserSchedule := nextUnit.CreateUserSchedule()
-					nextUserSchedule[0].DateStarted = &now
-					nextUserUnit := model.UserUnit{ID: uuid.NewString(), AppID: config.AppID, OrgID: config.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, ModuleKey: item.ModuleKey,
-						Unit: *nextUnit, Completed: 0, Current: true, UserSchedule: nextUserSchedule, DateCreated: time.Now().UTC()}
-
-					err := storage.InsertUserUnit(nextUserUnit)
-					if err != nil {
-						return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
-					}
+				err = storage.UpdateUserCourse(*currentUserCourse)
+				if err != nil {
+					return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, nil, err)
 				}
+				currentUserCourse.Version++
+				completedUserCourse = currentUserCourse
+			}
 
-				return nil
+			return nil
+		}
+		userAdvancedUnits := make([]model.StreaksSimulationUnit, 0)
+		completeUnitHandler := func(storage interfaces.Storage, item model.UserUnit) error {
+			// insert the next user unit if the unit exists since the current one has been completed
+			nextUnit := userCourse.Course.GetNextUnit(item.ModuleKey, item.Unit.Key)
+			advancedUnit := model.StreaksSimulationUnit{UserID: item.UserID, ModuleKey: item.ModuleKey, UnitKey: item.Unit.Key}
+			if nextUnit != nil {
+				advancedUnit.NextUnitKey = &nextUnit.Key
+				nextUserSchedule := nextUnit.CreateUserSchedule()
+				nextUserSchedule[0].DateStarted = &now
+				nextUserUnit := model.UserUnit{ID: uuid.NewString(), AppID: config.AppID, OrgID: config.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, ModuleKey: item.ModuleKey,
+					Unit: *nextUnit, CourseVersion: userCourse.CourseVersion, Completed: 0, Current: true, UserSchedule: nextUserSchedule, DateCreated: n.clock().UTC()}
+
+				err := storage.InsertUserUnit(nextUserUnit)
+				if err != nil {
+					return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserUnit, nil, err)
+				}
 			}
 
-			err = n.checkScheduleTaskCompletion(userID, userUnits, now, incompleteTaskHandler, 0, completeTaskHandler, completeUnitHandler)
-			if err != nil {
-				n.logger.Errorf("%s -> error checking task completion for user userID %s: %v", funcName, userID, err)
-				continue
+			// the unit has already been recorded if the transaction is being retried after a conflict
+			for _, recorded := range userAdvancedUnits {
+				if recorded.ModuleKey == advancedUnit.ModuleKey && recorded.UnitKey == advancedUnit.UnitKey {
+					return nil
+				}
 			}
+			userAdvancedUnits = append(userAdvancedUnits, advancedUnit)
+			return nil
 		}
 
-		if len(usePause) > 0 {
-			err = n.storage.DecrementUserCoursePauses(config.AppID, config.OrgID, usePause, config.CourseKey)
-			if err != nil {
-				n.logger.Errorf("%s -> error decrementing pauses for course config %s: %v", funcName, config.ID, err)
-			}
+		err = n.checkScheduleTaskCompletion(userID, userUnits, now, incompleteTaskHandler, 0, completeTaskHandler, completeUnitHandler)
+		if err != nil {
+			n.logger.Errorf("%s -> error checking task completion for user userID %s: %v", funcName, userID, err)
+			continue
 		}
-		if len(resetStreak) > 0 {
-			err = n.storage.ResetUserCourseStreaks(config.AppID, config.OrgID, resetStreak, config.CourseKey)
-			if err != nil {
-				n.logger.Errorf("%s -> error reseting streaks for course config %s: %v", funcName, config.ID, err)
-			}
+		if completedUserCourse != nil {
+			*userCourse = *completedUserCourse
+		}
+		advancedUnits = append(advancedUnits, userAdvancedUnits...)
+		if userCourse.DateCompleted != nil && userCourse.DateCompleted.Equal(now) {
+			completedCourses = append(completedCourses, *userCourse)
+		}
+	}
+
+	if len(usePause) > 0 {
+		err = n.storage.DecrementUserCoursePauses(config.AppID, config.OrgID, usePause, config.CourseKey, now)
+		if err != nil {
+			n.logger.Errorf("%s -> error decrementing pauses for course config %s: %v", funcName, config.ID, err)
+			usePause = nil
+		}
+	}
+	if len(useFreeze) > 0 {
+		err = n.storage.UseUserCourseStreakFreezes(config.AppID, config.OrgID, useFreeze, config.CourseKey, now)
+		if err != nil {
+			n.logger.Errorf("%s -> error using streak freezes for course config %s: %v", funcName, config.ID, err)
+			useFreeze = nil
+		}
+	}
+	if len(resetStreak) > 0 {
+		err = n.storage.ResetUserCourseStreaks(config.AppID, config.OrgID, resetStreak, config.CourseKey, now)
+		if err != nil {
+			n.logger.Errorf("%s -> error reseting streaks for course config %s: %v", funcName, config.ID, err)
+			resetStreak = nil
+		}
+	}
+	if n.simulation != nil {
+		n.simulation.UsedPause = append(n.simulation.UsedPause, usePause...)
+		n.simulation.UsedFreeze = append(n.simulation.UsedFreeze, useFreeze...)
+		n.simulation.ResetStreak = append(n.simulation.ResetStreak, resetStreak...)
+		n.simulation.AdvancedUnits = append(n.simulation.AdvancedUnits, advancedUnits...)
+	}
+
+	if len(achievements) > 0 {
+		n.processAchievements(userCourses, currentUserUnits, achievements, usePause, useFreeze, resetStreak, now)
+	}
+	if n.simulation == nil {
+		n.certificates.issueCertificates(n.storage, completedCourses)
+	}
+}
+
+// processAchievements applies the streak changes made in bulk to the user courses processed for streaks, then awards any newly earned achievements
+func (n streaksNotifications) processAchievements(userCourses []model.UserCourse, currentUserUnits map[string][]model.UserUnit, achievements []model.Achievement,
+	usePause []string, useFreeze []string, resetStreak []string, now time.Time) {
+	awards := make([]model.UserAchievement, 0)
+	for _, userCourse := range userCourses {
+		if _, processed := currentUserUnits[userCourse.UserID]; !processed {
+			continue
+		}
+
+		if utils.Exist(usePause, userCourse.UserID) {
+			userCourse.Streak++
+			userCourse.Pauses--
+		} else if utils.Exist(useFreeze, userCourse.UserID) {
+			userCourse.Streak++
+		} else if utils.Exist(resetStreak, userCourse.UserID) {
+			userCourse.Streak = 0
+		}
+
+		userAwards, err := n.achievements.awardAchievements(n.storage, userCourse, achievements, now)
+		if err != nil {
+			n.logger.Errorf("processAchievements -> error awarding achievements for user course %s: %v", userCourse.ID, err)
+			continue
+		}
+		awards = append(awards, userAwards...)
+	}
+
+	if n.simulation != nil {
+		for _, award := range awards {
+			n.simulation.Achievements = append(n.simulation.Achievements, model.StreaksSimulationAchievement{UserID: award.UserID, AchievementKey: award.Achievement.Key})
 		}
+		return
+	}
+	n.achievements.notifyAchievements(awards)
+}
+
+// simulate runs streaks processing followed by notifications processing for a course config once per hour starting at start
+// all changes are made to an in-memory snapshot of the user progress in the course, so nothing is persisted and no live documents are locked
+func (n streaksNotifications) simulate(appID string, orgID string, courseKey string, start time.Time, hours int) (*model.StreaksSimulation, error) {
+	start = start.UTC().Truncate(time.Hour)
+	simulation := model.StreaksSimulation{CourseKey: courseKey, Start: start, Hours: make([]model.StreaksSimulationHour, 0)}
+
+	config, err := n.storage.FindCourseConfig(appID, orgID, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseConfig, nil, err)
+	}
+	if config == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeCourseConfig, &logutils.FieldArgs{"course_key": courseKey})
+	}
+
+	storage, err := newSimulationStorage(n.storage, appID, orgID, courseKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction("simulating", model.TypeStreaksSimulation, &logutils.FieldArgs{"course_key": courseKey}, err)
+	}
+
+	err = n.simulateHours(storage, *config, start, hours, &simulation)
+	if err != nil {
+		return nil, errors.WrapErrorAction("simulating", model.TypeStreaksSimulation, &logutils.FieldArgs{"course_key": courseKey}, err)
 	}
+	return &simulation, nil
+}
+
+// simulateHours processes the streaks and notifications of each hour of a simulation against the simulation storage
+func (n streaksNotifications) simulateHours(storage *simulationStorage, config model.CourseConfig, start time.Time, hours int, simulation *model.StreaksSimulation) (err error) {
+	// the simulation storage panics on any storage call it does not support rather than letting it reach the live storage
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.ErrorData(logutils.StatusInvalid, "simulation storage call", &logutils.FieldArgs{"error": r})
+		}
+	}()
+
+	for i := 0; i < hours; i++ {
+		now := start.Add(time.Duration(i) * time.Hour)
+		hour := model.NewStreaksSimulationHour(now)
+
+		simulator := n
+		simulator.storage = storage
+		simulator.clock = func() time.Time { return now }
+		simulator.simulation = &hour
+
+		simulator.processStreaksForConfig(config, now)
+		if config.StreaksNotificationsConfig.NotificationsActive {
+			simulator.processNotificationsForConfig(config, now)
+		}
+		simulation.Hours = append(simulation.Hours, hour)
+	}
+	return nil
 }
 
 func (n streaksNotifications) getUserDataForTimezone(config model.CourseConfig, processTime int, nowSeconds int) ([]model.UserCourse, map[string][]model.UserUnit, []string, error) {
@@ -368,6 +547,24 @@ func (n streaksNotifications) filterUsersByIncomplete(currentUserUnits map[strin
 	return filtered, nil
 }
 
+func (n streaksNotifications) filterUsersByFrozen(userCourses []model.UserCourse, userIDs []string, now time.Time, snConfig model.StreaksNotificationsConfig) []string {
+	filtered := make([]string, 0)
+	for _, userID := range userIDs {
+		frozen := false
+		for i, userCourse := range userCourses {
+			if userCourse.UserID == userID {
+				frozen = userCourses[i].IsFrozen(now, snConfig)
+				break
+			}
+		}
+		if !frozen {
+			filtered = append(filtered, userID)
+		}
+	}
+
+	return filtered
+}
+
 // iterate through array of current userUnits
 // run completeTaskHandler on all qualifying userUnits, run incompleteTaskHandler if none qualify
 func (n streaksNotifications) checkScheduleTaskCompletion(userID string, userUnits []model.UserUnit, now time.Time, incompleteTaskHandler func(string) error, incompleteTaskPeriodOffset int,
@@ -376,10 +573,22 @@ func (n streaksNotifications) checkScheduleTaskCompletion(userID string, userUni
 		return errors.ErrorData(logutils.StatusMissing, "incomplete task handler", nil)
 	}
 
+	if len(userUnits) == 0 {
+		return incompleteTaskHandler(userID)
+	}
+
 	allIncomplete := true
 	// all storage operations for completed schedule items done here must be atomic
 	transaction := func(storage interfaces.Storage) error {
-		for _, userUnit := range userUnits {
+		// the user may have made progress since the user units were loaded, so the transaction works on their current state and can be retried after a conflict
+		allIncomplete = true
+		current := true
+		currentUserUnits, err := storage.FindUserUnits(userUnits[0].AppID, userUnits[0].OrgID, []string{userID}, userUnits[0].CourseKey, nil, &current)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, &logutils.FieldArgs{"user_id": userID, "current": true}, err)
+		}
+
+		for _, userUnit := range currentUserUnits {
 			userScheduleItem, scheduleItem, _, isRequired := userUnit.GetScheduleItem("", true)
 			if userScheduleItem == nil || scheduleItem == nil {
 				return errors.ErrorData(logutils.StatusMissing, model.TypeScheduleItem, &logutils.FieldArgs{"current": true})
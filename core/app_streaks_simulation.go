@@ -0,0 +1,350 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"lms/core/interfaces"
+	"lms/core/model"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+// simulationReader is the part of the live storage a simulation may read from
+type simulationReader interface {
+	FindCourseConfig(appID string, orgID string, key string) (*model.CourseConfig, error)
+	FindCourseConfigs(appID *string, orgID *string, notificationsActive *bool) ([]model.CourseConfig, error)
+	FindAchievements(appID string, orgID string, keys []string, courseKey *string) ([]model.Achievement, error)
+}
+
+// simulationStorage applies the streak processing changes made during a simulation to an in-memory snapshot of the user progress in a course,
+// so a simulation never writes to or locks the live user progress
+//
+//	Only the reads of simulationReader are passed through to the live storage. The embedded storage is always nil, so any other storage call
+//	panics instead of reaching the live storage, and the simulation reports it as an error
+type simulationStorage struct {
+	interfaces.Storage
+
+	live simulationReader
+
+	userCourses      []model.UserCourse
+	userUnits        []model.UserUnit
+	userAchievements []model.UserAchievement
+
+	inTransaction bool
+}
+
+// newSimulationStorage takes a snapshot of the user courses, user units and user achievements in a course
+func newSimulationStorage(storage interfaces.Storage, appID string, orgID string, courseKey string) (*simulationStorage, error) {
+	s := simulationStorage{live: storage, userCourses: make([]model.UserCourse, 0), userUnits: make([]model.UserUnit, 0), userAchievements: make([]model.UserAchievement, 0)}
+
+	// the snapshot is read in a transaction so that it is consistent across collections
+	transaction := func(storage interfaces.Storage) error {
+		userCourses, err := storage.FindUserCourses(nil, appID, orgID, nil, []string{courseKey}, nil, nil, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, &logutils.FieldArgs{"course_key": courseKey}, err)
+		}
+		if len(userCourses) == 0 {
+			return nil
+		}
+		s.userCourses = userCourses
+
+		userIDs := make([]string, len(userCourses))
+		for i, userCourse := range userCourses {
+			userIDs[i] = userCourse.UserID
+		}
+		s.userUnits, err = storage.FindUserUnits(appID, orgID, userIDs, courseKey, nil, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, &logutils.FieldArgs{"course_key": courseKey}, err)
+		}
+		s.userAchievements, err = storage.FindUserAchievements(appID, orgID, nil, &courseKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserAchievement, &logutils.FieldArgs{"course_key": courseKey}, err)
+		}
+		return nil
+	}
+
+	err := storage.PerformTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// PerformTransaction performs a transaction on the snapshot, discarding all of its changes if it fails
+func (s *simulationStorage) PerformTransaction(transaction func(storage interfaces.Storage) error) error {
+	if s.inTransaction {
+		return transaction(s)
+	}
+
+	// copy the items as well as the lists, so no change made during the transaction can reach the copies used to restore them
+	userCourses := cloneItems(s.userCourses, cloneUserCourse)
+	userUnits := cloneItems(s.userUnits, cloneUserUnit)
+	userAchievements := cloneItems(s.userAchievements, cloneUserAchievement)
+
+	s.inTransaction = true
+	err := transaction(s)
+	s.inTransaction = false
+	if err != nil {
+		s.userCourses = userCourses
+		s.userUnits = userUnits
+		s.userAchievements = userAchievements
+	}
+	return err
+}
+
+// FindCourseConfig finds a course config in the live storage
+func (s *simulationStorage) FindCourseConfig(appID string, orgID string, key string) (*model.CourseConfig, error) {
+	return s.live.FindCourseConfig(appID, orgID, key)
+}
+
+// FindCourseConfigs finds course configs in the live storage
+func (s *simulationStorage) FindCourseConfigs(appID *string, orgID *string, notificationsActive *bool) ([]model.CourseConfig, error) {
+	return s.live.FindCourseConfigs(appID, orgID, notificationsActive)
+}
+
+// FindAchievements finds achievements in the live storage
+func (s *simulationStorage) FindAchievements(appID string, orgID string, keys []string, courseKey *string) ([]model.Achievement, error) {
+	return s.live.FindAchievements(appID, orgID, keys, courseKey)
+}
+
+// FindUserCourses finds user courses in the snapshot
+func (s *simulationStorage) FindUserCourses(id []string, appID string, orgID string, name []string, key []string, userID *string, timezoneOffsetPairs []model.TZOffsetPair, completed *bool) ([]model.UserCourse, error) {
+	var result []model.UserCourse
+	for _, userCourse := range s.userCourses {
+		if userCourse.AppID != appID || userCourse.OrgID != orgID {
+			continue
+		}
+		if (len(id) != 0 && !slices.Contains(id, userCourse.ID)) || (len(name) != 0 && !slices.Contains(name, userCourse.Course.Name)) ||
+			(len(key) != 0 && !slices.Contains(key, userCourse.Course.Key)) || (userID != nil && *userID != userCourse.UserID) {
+			continue
+		}
+		if len(timezoneOffsetPairs) > 0 && !slices.ContainsFunc(timezoneOffsetPairs, func(pair model.TZOffsetPair) bool {
+			return userCourse.Timezone.Offset >= pair.Lower && userCourse.Timezone.Offset <= pair.Upper
+		}) {
+			continue
+		}
+		if completed != nil && *completed != (userCourse.DateCompleted != nil) {
+			continue
+		}
+		result = append(result, cloneUserCourse(userCourse))
+	}
+	return result, nil
+}
+
+// FindUserCourse finds a user course in the snapshot
+func (s *simulationStorage) FindUserCourse(appID string, orgID string, userID string, courseKey string) (*model.UserCourse, error) {
+	i := s.userCourseIndex(appID, orgID, userID, courseKey)
+	if i < 0 {
+		return nil, nil
+	}
+	userCourse := cloneUserCourse(s.userCourses[i])
+	return &userCourse, nil
+}
+
+// UpdateUserCourse updates a user course in the snapshot
+func (s *simulationStorage) UpdateUserCourse(item model.UserCourse) error {
+	i := s.userCourseIndex(item.AppID, item.OrgID, item.UserID, item.Course.Key)
+	if i < 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"user_id": item.UserID, "course.key": item.Course.Key})
+	}
+	if s.userCourses[i].Version != item.Version {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeUserCourse, &logutils.FieldArgs{"user_id": item.UserID, "course.key": item.Course.Key, "version": item.Version}).SetStatus(model.ErrorStatusConflict)
+	}
+
+	item = cloneUserCourse(item)
+	item.Version++
+	s.userCourses[i] = item
+	return nil
+}
+
+// DecrementUserCoursePauses uses a pause for all matching user courses in the snapshot
+func (s *simulationStorage) DecrementUserCoursePauses(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
+	return s.updateUserCourses(appID, orgID, userIDs, key, func(userCourse *model.UserCourse) {
+		userCourse.Streak++
+		userCourse.Pauses--
+		userCourse.PauseUses = append(userCourse.PauseUses, processTime.Truncate(time.Hour))
+	})
+}
+
+// UseUserCourseStreakFreezes uses a frozen day for all matching user courses in the snapshot
+func (s *simulationStorage) UseUserCourseStreakFreezes(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
+	return s.updateUserCourses(appID, orgID, userIDs, key, func(userCourse *model.UserCourse) {
+		userCourse.Streak++
+		userCourse.PauseUses = append(userCourse.PauseUses, processTime.Truncate(time.Hour))
+	})
+}
+
+// ResetUserCourseStreaks resets the streaks of all matching user courses in the snapshot
+func (s *simulationStorage) ResetUserCourseStreaks(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
+	return s.updateUserCourses(appID, orgID, userIDs, key, func(userCourse *model.UserCourse) {
+		streak := userCourse.Streak
+		userCourse.StreakResets = append(userCourse.StreakResets, processTime.Truncate(time.Hour))
+		userCourse.StreakBeforeReset = &streak
+		userCourse.Streak = 0
+	})
+}
+
+// FindUserUnits finds user units in the snapshot
+func (s *simulationStorage) FindUserUnits(appID string, orgID string, userIDs []string, courseKey string, moduleKey *string, current *bool) ([]model.UserUnit, error) {
+	result := make([]model.UserUnit, 0)
+	for _, userUnit := range s.userUnits {
+		if userUnit.AppID != appID || userUnit.OrgID != orgID || userUnit.CourseKey != courseKey {
+			continue
+		}
+		if (len(userIDs) != 0 && !slices.Contains(userIDs, userUnit.UserID)) || (moduleKey != nil && *moduleKey != userUnit.ModuleKey) || (current != nil && *current != userUnit.Current) {
+			continue
+		}
+		result = append(result, cloneUserUnit(userUnit))
+	}
+	return result, nil
+}
+
+// InsertUserUnit inserts a user unit into the snapshot
+func (s *simulationStorage) InsertUserUnit(item model.UserUnit) error {
+	s.userUnits = append(s.userUnits, cloneUserUnit(item))
+	return nil
+}
+
+// UpdateUserUnit updates a user unit in the snapshot
+func (s *simulationStorage) UpdateUserUnit(item model.UserUnit) error {
+	i := slices.IndexFunc(s.userUnits, func(userUnit model.UserUnit) bool {
+		return userUnit.AppID == item.AppID && userUnit.OrgID == item.OrgID && userUnit.UserID == item.UserID && userUnit.CourseKey == item.CourseKey &&
+			userUnit.ModuleKey == item.ModuleKey && userUnit.Unit.Key == item.Unit.Key
+	})
+	if i < 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &logutils.FieldArgs{"user_id": item.UserID, "module_key": item.ModuleKey, "unit.key": item.Unit.Key})
+	}
+	if s.userUnits[i].Version != item.Version {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeUserUnit, &logutils.FieldArgs{"user_id": item.UserID, "unit.key": item.Unit.Key, "version": item.Version}).SetStatus(model.ErrorStatusConflict)
+	}
+
+	item = cloneUserUnit(item)
+	item.Version++
+	s.userUnits[i] = item
+	return nil
+}
+
+// FindUserAchievements finds user achievements in the snapshot
+func (s *simulationStorage) FindUserAchievements(appID string, orgID string, userID *string, courseKey *string) ([]model.UserAchievement, error) {
+	result := make([]model.UserAchievement, 0)
+	for _, userAchievement := range s.userAchievements {
+		if userAchievement.AppID != appID || userAchievement.OrgID != orgID || (userID != nil && *userID != userAchievement.UserID) ||
+			(courseKey != nil && *courseKey != userAchievement.CourseKey) {
+			continue
+		}
+		result = append(result, cloneUserAchievement(userAchievement))
+	}
+	return result, nil
+}
+
+// InsertUserAchievements inserts user achievements into the snapshot
+func (s *simulationStorage) InsertUserAchievements(items []model.UserAchievement) error {
+	s.userAchievements = append(s.userAchievements, cloneItems(items, cloneUserAchievement)...)
+	return nil
+}
+
+func (s *simulationStorage) userCourseIndex(appID string, orgID string, userID string, courseKey string) int {
+	return slices.IndexFunc(s.userCourses, func(userCourse model.UserCourse) bool {
+		return userCourse.AppID == appID && userCourse.OrgID == orgID && userCourse.UserID == userID && userCourse.Course.Key == courseKey
+	})
+}
+
+func (s *simulationStorage) updateUserCourses(appID string, orgID string, userIDs []string, key string, update func(userCourse *model.UserCourse)) error {
+	// should never update all user courses in a course at once, as in the live storage
+	if len(userIDs) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, "user ids", nil)
+	}
+
+	for _, userID := range userIDs {
+		i := s.userCourseIndex(appID, orgID, userID, key)
+		if i < 0 {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &logutils.FieldArgs{"user_id": userID, "course.key": key})
+		}
+		userCourse := cloneUserCourse(s.userCourses[i])
+		update(&userCourse)
+		userCourse.Version++
+		s.userCourses[i] = userCourse
+	}
+	return nil
+}
+
+// cloneItems deep copies each item of a list with clone
+func cloneItems[T any](items []T, clone func(T) T) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, len(items))
+	for i, item := range items {
+		result[i] = clone(item)
+	}
+	return result
+}
+
+// clonePointer copies the value a pointer refers to
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// cloneUserCourse deep copies the progress of a user course so that changes to the copy do not affect the snapshot
+//
+//	the course itself is shared since streak processing never changes it
+func cloneUserCourse(item model.UserCourse) model.UserCourse {
+	item.StreakResets = slices.Clone(item.StreakResets)
+	item.StreakRestarts = slices.Clone(item.StreakRestarts)
+	item.PauseUses = slices.Clone(item.PauseUses)
+	item.StreakBeforeReset = clonePointer(item.StreakBeforeReset)
+	item.StreakFreezes = slices.Clone(item.StreakFreezes)
+	item.LeaderboardName = clonePointer(item.LeaderboardName)
+	item.LastCompleted = clonePointer(item.LastCompleted)
+	item.LastResponded = clonePointer(item.LastResponded)
+	item.CompletedModules = maps.Clone(item.CompletedModules)
+	item.CohortKey = clonePointer(item.CohortKey)
+	item.CohortStart = clonePointer(item.CohortStart)
+	item.DateUpdated = clonePointer(item.DateUpdated)
+	item.DateCompleted = clonePointer(item.DateCompleted)
+	item.DateDropped = clonePointer(item.DateDropped)
+	return item
+}
+
+// cloneUserUnit deep copies the schedule of a user unit so that changes to the copy do not affect the snapshot
+//
+//	the unit itself is shared since streak processing never changes it
+func cloneUserUnit(item model.UserUnit) model.UserUnit {
+	item.UserSchedule = slices.Clone(item.UserSchedule)
+	for i := range item.UserSchedule {
+		userScheduleItem := &item.UserSchedule[i]
+		userScheduleItem.UserContent = slices.Clone(userScheduleItem.UserContent)
+		for j := range userScheduleItem.UserContent {
+			userScheduleItem.UserContent[j].IDs = slices.Clone(userScheduleItem.UserContent[j].IDs)
+		}
+		userScheduleItem.DateStarted = clonePointer(userScheduleItem.DateStarted)
+		userScheduleItem.DateCompleted = clonePointer(userScheduleItem.DateCompleted)
+	}
+	item.DateUpdated = clonePointer(item.DateUpdated)
+	return item
+}
+
+// cloneUserAchievement copies a user achievement, whose achievement is shared since streak processing never changes it
+func cloneUserAchievement(item model.UserAchievement) model.UserAchievement {
+	return item
+}
@@ -12,6 +12,11 @@ type Default interface {
 	// string
 
 	GetVersion() (*string, error)
+
+	// model.CertificateVerification
+
+	VerifyCertificate(item model.CertificateVerification) (*model.CertificateVerification, error)
+	GetCertificateVerification(id string) (*model.CertificateVerification, error)
 }
 
 // Client exposes client APIs to the driver adapters
@@ -32,25 +37,60 @@ type Client interface {
 
 	// model.UserCourse
 
-	GetUserCourses(claims *tokenauth.Claims, id *string, name *string, key *string) ([]model.UserCourse, error)
-	GetUserCourse(claims *tokenauth.Claims, key string) (*model.UserCourse, error)
+	GetUserCourses(claims *tokenauth.Claims, id *string, name *string, key *string, locale *string, acceptLanguage *string) ([]model.UserCourse, error)
+	GetUserCourse(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) (*model.UserCourse, error)
 	CreateUserCourse(claims *tokenauth.Claims, key string, item model.Timezone) (*model.UserCourse, error)
 	DeleteUserCourse(claims *tokenauth.Claims, key string) error
-	UpdateUserCourse(claims *tokenauth.Claims, key string, drop *bool) (*model.UserCourse, error)
+	UpdateUserCourse(claims *tokenauth.Claims, key string, drop *bool, leaderboardOptIn *bool) (*model.UserCourse, error)
+
+	// model.StreakHistory
+
+	GetUserCourseStreakHistory(claims *tokenauth.Claims, key string, start *string, end *string) (*model.StreakHistory, error)
+	CreateUserCourseStreakFreeze(claims *tokenauth.Claims, key string, item model.StreakFreeze) (*model.UserCourse, error)
+	DeleteUserCourseStreakFreezes(claims *tokenauth.Claims, key string) error
+
+	// model.Certificate
+
+	GetUserCourseCertificate(claims *tokenauth.Claims, key string) (*model.Certificate, error)
+
+	// model.ProgressSyncResult
+
+	SyncUserCourseProgress(claims *tokenauth.Claims, key string, item model.ProgressSync) (*model.ProgressSyncResult, error)
+	RestartUserCourse(claims *tokenauth.Claims, key string, moduleKey *string, unitKey *string) (*model.UserCourse, error)
+
+	// model.ProgressArchive
+
+	GetUserCourseProgressArchives(claims *tokenauth.Claims, key string) ([]model.ProgressArchive, error)
+
+	// model.ProgressSummary
+
+	GetUserCourseProgressSummary(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) (*model.ProgressSummary, error)
 
 	// model.UserUnit
 
 	UpdateUserCourseModuleProgress(claims *tokenauth.Claims, courseKey string, moduleKey string, item model.UserResponse) (*model.UserUnit, error)
 
+	// model.UserAchievement
+
+	GetUserAchievements(claims *tokenauth.Claims, courseKey *string) ([]model.UserAchievement, error)
+
 	// model.UserContent
 
 	GetUserContents(claims *tokenauth.Claims, ids string) ([]model.UserContent, error)
-	GetUserCourseUnits(claims *tokenauth.Claims, key string) ([]model.UserUnit, error)
+	GetUserCourseUnits(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) ([]model.UserUnit, error)
+
+	// model.SearchResult
+
+	SearchUserCourses(claims *tokenauth.Claims, text string, courseKey *string, limit *int, locale *string, acceptLanguage *string) ([]model.SearchResult, error)
 
 	// model.Course
 
-	GetCustomCourses(claims *tokenauth.Claims) ([]model.Course, error)
-	GetCustomCourse(claims *tokenauth.Claims, key string) (*model.Course, error)
+	GetCustomCourses(claims *tokenauth.Claims, locale *string, acceptLanguage *string) ([]model.Course, error)
+	GetCustomCourse(claims *tokenauth.Claims, key string, locale *string, acceptLanguage *string) (*model.Course, error)
+
+	// model.Leaderboard
+
+	GetCustomCourseLeaderboard(claims *tokenauth.Claims, key string, metric *string, limit *int, offset *int) (*model.Leaderboard, error)
 
 	// model.CourseConfig
 
@@ -81,6 +121,10 @@ type Admin interface {
 
 	FindNudgesProcesses(claims *tokenauth.Claims, limit *int, offset *int) ([]model.NudgesProcess, error)
 
+	// model.AuditLog
+
+	GetAuditLogs(claims *tokenauth.Claims, entityType *string, entityKey *string, actor *string, startDate *string, endDate *string, limit *int, offset *int) ([]model.AuditLog, error)
+
 	// model.Course
 
 	GetCustomCourses(claims *tokenauth.Claims, id *string, name *string, key *string, moduleKey *string) ([]model.Course, error)
@@ -89,6 +133,37 @@ type Admin interface {
 	UpdateCustomCourse(claims *tokenauth.Claims, key string, item model.Course) (*model.Course, error)
 	DeleteCustomCourse(claims *tokenauth.Claims, key string) error
 
+	// model.CourseVersion
+
+	GetCustomCourseVersions(claims *tokenauth.Claims, key string) ([]model.CourseVersion, error)
+	PublishCustomCourse(claims *tokenauth.Claims, key string, item model.CourseVersion) (*model.CourseVersion, error)
+
+	// model.CourseVersionPreview
+
+	GetCustomCoursePreview(claims *tokenauth.Claims, key string) (*model.CourseVersionPreview, error)
+
+	// model.CourseMigration
+
+	GetCustomCourseMigration(claims *tokenauth.Claims, key string, version *int) (*model.CourseMigration, error)
+	MigrateCustomCourse(claims *tokenauth.Claims, key string, version *int) (*model.CourseMigration, error)
+	CloneCustomCourse(claims *tokenauth.Claims, key string, item model.CourseClone) (*model.Course, error)
+
+	// model.LearnerProgress
+
+	GetCustomCourseLearners(claims *tokenauth.Claims, key string, cohortKey *string, inactiveDays *int, streakResetDays *int, completed *bool, dropped *bool, limit *int, offset *int) ([]model.LearnerProgress, error)
+
+	// model.LearnerDetails
+
+	GetCustomCourseLearner(claims *tokenauth.Claims, key string, userID string) (*model.LearnerDetails, error)
+
+	// model.UserCourse
+
+	RestartCustomCourseLearner(claims *tokenauth.Claims, key string, userID string, moduleKey *string, unitKey *string) (*model.UserCourse, error)
+
+	// model.CourseAnalytics
+
+	GetCustomCourseAnalytics(claims *tokenauth.Claims, key string, inactiveDays *int) (*model.CourseAnalytics, error)
+
 	// model.Module
 
 	GetCustomModules(claims *tokenauth.Claims, id *string, name *string, key *string, unitKey *string) ([]model.Module, error)
@@ -113,6 +188,16 @@ type Admin interface {
 	UpdateCustomContent(claims *tokenauth.Claims, key string, item model.Content) (*model.Content, error)
 	DeleteCustomContent(claims *tokenauth.Claims, key string) error
 
+	// model.SearchResult
+
+	SearchCustomCourses(claims *tokenauth.Claims, text string, courseKey *string, limit *int) ([]model.SearchResult, error)
+
+	// model.TrashItem
+
+	GetTrashItems(claims *tokenauth.Claims, itemType *string) ([]model.TrashItem, error)
+	DeleteTrashItem(claims *tokenauth.Claims, id string) error
+	RestoreTrashItem(claims *tokenauth.Claims, id string) (*model.TrashItem, error)
+
 	// model.CourseConfig
 
 	GetCustomCourseConfigs(claims *tokenauth.Claims) ([]model.CourseConfig, error)
@@ -120,4 +205,34 @@ type Admin interface {
 	GetCustomCourseConfig(claims *tokenauth.Claims, key string) (*model.CourseConfig, error)
 	UpdateCustomCourseConfig(claims *tokenauth.Claims, key string, item model.CourseConfig) (*model.CourseConfig, error)
 	DeleteCustomCourseConfig(claims *tokenauth.Claims, key string) error
+
+	// model.StreaksSimulation
+
+	GetStreaksSimulation(claims *tokenauth.Claims, key string, start *string, hours *int) (*model.StreaksSimulation, error)
+
+	// model.Achievement
+
+	GetAchievements(claims *tokenauth.Claims, courseKey *string) ([]model.Achievement, error)
+	CreateAchievement(claims *tokenauth.Claims, item model.Achievement) (*model.Achievement, error)
+	GetAchievement(claims *tokenauth.Claims, key string) (*model.Achievement, error)
+	UpdateAchievement(claims *tokenauth.Claims, key string, item model.Achievement) (*model.Achievement, error)
+	DeleteAchievement(claims *tokenauth.Claims, key string) error
+
+	// model.IntegrityReport
+
+	GetCustomIntegrityReport(claims *tokenauth.Claims) (*model.IntegrityReport, error)
+	RepairCustomIntegrity(claims *tokenauth.Claims) (*model.IntegrityReport, error)
+
+	// model.Cohort
+
+	GetCohorts(claims *tokenauth.Claims, courseKey *string) ([]model.Cohort, error)
+	CreateCohort(claims *tokenauth.Claims, item model.Cohort) (*model.Cohort, error)
+	GetCohort(claims *tokenauth.Claims, key string) (*model.Cohort, error)
+	UpdateCohort(claims *tokenauth.Claims, key string, item model.Cohort) (*model.Cohort, error)
+	DeleteCohort(claims *tokenauth.Claims, key string) error
+
+	// model.CohortEnrollment
+
+	EnrollCohort(claims *tokenauth.Claims, key string) (*model.CohortEnrollment, error)
+	GetCohortMembers(claims *tokenauth.Claims, key string) ([]model.UserCourse, error)
 }
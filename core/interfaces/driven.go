@@ -44,6 +44,7 @@ type Storage interface {
 
 	LoadAllNudges() ([]model.Nudge, error)
 	LoadActiveNudges() ([]model.Nudge, error)
+	FindNudge(ID string) (*model.Nudge, error)
 	InsertNudge(item model.Nudge) error
 	UpdateNudge(item model.Nudge) error
 	DeleteNudge(ID string) error
@@ -71,6 +72,11 @@ type Storage interface {
 	UpdateCustomCourse(key string, item model.Course) error
 	DeleteCustomCourse(appID string, orgID string, key string) error
 
+	FindCourseVersions(appID string, orgID string, courseKey string) ([]model.CourseVersion, error)
+	FindCourseVersion(appID string, orgID string, courseKey string, version *int) (*model.CourseVersion, error)
+	InsertCourseVersion(item model.CourseVersion) error
+	DeleteCourseVersions(appID string, orgID string, courseKey string) error
+
 	FindCustomModules(appID string, orgID string, id []string, name []string, key []string, unitKeys []string) ([]model.Module, error)
 	FindCustomModule(appID string, orgID string, key string) (*model.Module, error)
 	InsertCustomModule(item model.Module) error
@@ -103,9 +109,14 @@ type Storage interface {
 	InsertUserCourse(item model.UserCourse) error
 	UpdateUserCourses(key string, item model.Course) error
 	UpdateUserCourse(item model.UserCourse) error
+	MigrateUserCourse(item model.UserCourse) error
 	UpdateUserTimezone(appID string, orgID string, userID string, timezoneName string, timezoneOffset int) error
-	DecrementUserCoursePauses(appID string, orgID string, userIDs []string, key string) error
-	ResetUserCourseStreaks(appID string, orgID string, userIDs []string, key string) error
+	DecrementUserCoursePauses(appID string, orgID string, userIDs []string, key string, processTime time.Time) error
+	UseUserCourseStreakFreezes(appID string, orgID string, userIDs []string, key string, processTime time.Time) error
+	ResetUserCourseStreaks(appID string, orgID string, userIDs []string, key string, processTime time.Time) error
+	FindLeaderboardEntries(appID string, orgID string, courseKey string, metric string) ([]model.LeaderboardEntry, error)
+	FindLearnerProgress(appID string, orgID string, courseKey string, filter model.LearnerProgressFilter, limit int, offset int) ([]model.LearnerProgress, error)
+	FindCourseProgressStats(appID string, orgID string, courseKey string) (*model.CourseProgressStats, error)
 	DeleteUserCourse(appID string, orgID string, userID string, courseKey string) error
 	DeleteUserCourses(appID string, orgID string, courseKey string) error
 	DeleteUserCoursesByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
@@ -116,20 +127,79 @@ type Storage interface {
 	InsertUserUnit(item model.UserUnit) error
 	UpdateUserUnit(item model.UserUnit) error
 	UpdateUserUnits(key string, item model.Unit) error
+	MigrateUserUnit(item model.UserUnit) error
 	DeleteUserUnit(appID string, orgID string, key string) error
 	DeleteUserUnits(appID string, orgID string, userID string, courseKey string) error
+	DeleteUserUnitsByIDs(appID string, orgID string, ids []string) error
 	DeleteUserUnitsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
 
 	FindUserContents(id []string, appID string, orgID string, userID string) ([]model.UserContent, error)
 	InsertUserContent(item model.UserContent) error
 	UpdateUserContent(item model.UserContent, updateContent bool) error
 	DeleteUserContents(appID string, orgID string, userID string, courseKey *string) error
+	DeleteUserContentsByIDs(appID string, orgID string, ids []string) error
 	DeleteUserContentsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
 
 	DeleteContentKeyFromLinkedContents(appID string, orgID string, key string) error
 	DeleteContentKeyFromUnits(appID string, orgID string, key string) error
 	DeleteUnitKeyFromModules(appID string, orgID string, key string) error
 	DeleteModuleKeyFromCourses(appID string, orgID string, key string) error
+
+	FindCustomReferences(appID string, orgID string) (*model.CustomReferences, error)
+	UpdateCustomReferences(appID string, orgID string, refs model.CustomReferences) error
+
+	FindAchievements(appID string, orgID string, keys []string, courseKey *string) ([]model.Achievement, error)
+	FindAchievement(appID string, orgID string, key string) (*model.Achievement, error)
+	InsertAchievement(item model.Achievement) error
+	UpdateAchievement(item model.Achievement) error
+	DeleteAchievement(appID string, orgID string, key string) error
+	DeleteAchievements(appID string, orgID string, courseKey string) error
+
+	FindUserAchievements(appID string, orgID string, userID *string, courseKey *string) ([]model.UserAchievement, error)
+	InsertUserAchievements(items []model.UserAchievement) error
+	DeleteUserAchievements(appID string, orgID string, userID *string, courseKey string) error
+	DeleteUserAchievementsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
+
+	FindCohorts(appID string, orgID string, courseKey *string) ([]model.Cohort, error)
+	FindCohort(appID string, orgID string, key string) (*model.Cohort, error)
+	InsertCohort(item model.Cohort) error
+	UpdateCohort(item model.Cohort) error
+	DeleteCohort(appID string, orgID string, key string) error
+	DeleteCohorts(appID string, orgID string, courseKey string) error
+	FindCohortUserCourses(appID string, orgID string, cohortKey string) ([]model.UserCourse, error)
+	UpdateCohortUserCourses(appID string, orgID string, cohortKey string, start *time.Time) error
+
+	FindCertificate(appID string, orgID string, userID string, courseKey string) (*model.Certificate, error)
+	FindCertificateByID(id string) (*model.Certificate, error)
+	InsertCertificate(item model.Certificate) error
+	DeleteCertificatesByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
+
+	SearchCustomModules(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error)
+	SearchCustomUnits(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error)
+	SearchCustomContents(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error)
+
+	TrashCustomCourse(item model.TrashItem) error
+	TrashCustomModule(item model.TrashItem) error
+	TrashCustomUnit(item model.TrashItem) error
+	TrashCustomContent(item model.TrashItem) error
+	TrashCourseConfig(item model.TrashItem) error
+	FindTrashItems(appID string, orgID string, itemType *string) ([]model.TrashItem, error)
+	FindTrashItem(appID string, orgID string, id string) (*model.TrashItem, error)
+	RestoreTrashItem(appID string, orgID string, id string) error
+	DeleteTrashItem(appID string, orgID string, id string) error
+	DeleteTrashDocumentsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
+
+	FindProgressSyncReceipts(appID string, orgID string, userID string, idempotencyKeys []string) ([]model.ProgressSyncReceipt, error)
+	InsertProgressSyncReceipt(item model.ProgressSyncReceipt) error
+	DeleteProgressSyncReceiptsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
+
+	FindProgressArchives(appID string, orgID string, userID string, courseKey string) ([]model.ProgressArchive, error)
+	InsertProgressArchive(item model.ProgressArchive) error
+	DeleteProgressArchives(appID string, orgID string, userID string, courseKey string) error
+	DeleteProgressArchivesByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error
+
+	InsertAuditLog(item model.AuditLog) error
+	FindAuditLogs(appID string, orgID string, entityType *string, entityKey *string, actor *string, startDate *time.Time, endDate *time.Time, limit int, offset int) ([]model.AuditLog, error)
 }
 
 // Provider interface for LMS provider
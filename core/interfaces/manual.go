@@ -22,8 +22,15 @@ import (
 
 // Manual exposes manually defined APIs to the driver adapters
 type Manual interface {
+	//Default
+	GetCertificateHTML(id string) ([]byte, error)
+
 	//Client
 	GetUserData(claims *tokenauth.Claims) (*model.UserDataResponse, error)
+
+	//Admin
+	ExportCourseBundle(claims *tokenauth.Claims, key string) (*model.CourseBundle, error)
+	ImportCourseBundle(claims *tokenauth.Claims, key string, item model.CourseBundle) (*model.Course, error)
 }
 
 // Shared exposes shared APIs for other interface implementations
@@ -0,0 +1,125 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeAchievement achievement type
+	TypeAchievement logutils.MessageDataType = "achievement"
+	//TypeUserAchievement user achievement type
+	TypeUserAchievement logutils.MessageDataType = "user achievement"
+
+	//AchievementTypeStreak is awarded when the user course streak reaches the threshold
+	AchievementTypeStreak string = "streak"
+	//AchievementTypeModule is awarded when the module with the module key is completed, or when threshold modules are completed if no module key is set
+	AchievementTypeModule string = "module"
+	//AchievementTypePauses is awarded when the number of available pauses reaches the threshold
+	AchievementTypePauses string = "pauses"
+	//AchievementTypeCourse is awarded when the course is completed
+	AchievementTypeCourse string = "course"
+)
+
+// Achievement represents an admin-defined badge which may be awarded to users taking a course
+type Achievement struct {
+	ID        string `json:"id" bson:"_id"`
+	AppID     string `json:"app_id" bson:"app_id"`
+	OrgID     string `json:"org_id" bson:"org_id"`
+	Key       string `json:"key" bson:"key"`
+	CourseKey string `json:"course_key" bson:"course_key"`
+
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description" bson:"description"`
+	Type        string `json:"type" bson:"type"`                                 // streak, module, pauses, course
+	Threshold   int    `json:"threshold" bson:"threshold"`                       // streak length, number of completed modules, or number of pauses
+	ModuleKey   string `json:"module_key,omitempty" bson:"module_key,omitempty"` // module to complete (module type only)
+
+	Notification *AchievementNotification `json:"notification,omitempty" bson:"notification,omitempty"` // sent to the user when the achievement is awarded if set
+
+	Styles Styles `json:"styles" bson:"styles"`
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+}
+
+// Validate checks the achievement type and its requirements
+func (a *Achievement) Validate() error {
+	if a == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeAchievement, nil)
+	}
+
+	switch a.Type {
+	case AchievementTypeStreak, AchievementTypePauses:
+		if a.Threshold < 1 {
+			return errors.ErrorData(logutils.StatusInvalid, "achievement threshold", &logutils.FieldArgs{"type": a.Type, "threshold": a.Threshold})
+		}
+	case AchievementTypeModule:
+		if a.ModuleKey == "" && a.Threshold < 1 {
+			return errors.ErrorData(logutils.StatusInvalid, "achievement threshold", &logutils.FieldArgs{"type": a.Type, "threshold": a.Threshold})
+		}
+	case AchievementTypeCourse:
+	default:
+		return errors.ErrorData(logutils.StatusInvalid, "achievement type", &logutils.FieldArgs{"type": a.Type})
+	}
+	return nil
+}
+
+// IsEarned returns whether the user course meets the requirements of the achievement
+func (a *Achievement) IsEarned(userCourse UserCourse) bool {
+	if a == nil || a.CourseKey != userCourse.Course.Key {
+		return false
+	}
+
+	switch a.Type {
+	case AchievementTypeStreak:
+		return userCourse.Streak >= a.Threshold
+	case AchievementTypeModule:
+		if a.ModuleKey != "" {
+			_, completed := userCourse.CompletedModules[a.ModuleKey]
+			return completed
+		}
+		return len(userCourse.CompletedModules) >= a.Threshold
+	case AchievementTypePauses:
+		return userCourse.Pauses >= a.Threshold
+	case AchievementTypeCourse:
+		return userCourse.DateCompleted != nil
+	}
+	return false
+}
+
+// AchievementNotification represents the notification sent to a user when an achievement is awarded
+type AchievementNotification struct {
+	Subject string             `json:"subject" bson:"subject"`
+	Body    string             `json:"body" bson:"body"`
+	Params  NotificationParams `json:"params" bson:"params"`
+}
+
+// UserAchievement represents an achievement awarded to a user
+type UserAchievement struct {
+	ID        string `json:"id" bson:"_id"`
+	AppID     string `json:"app_id" bson:"app_id"`
+	OrgID     string `json:"org_id" bson:"org_id"`
+	UserID    string `json:"user_id" bson:"user_id"`
+	CourseKey string `json:"course_key" bson:"course_key"`
+
+	Achievement Achievement `json:"achievement" bson:"achievement"`
+
+	DateAwarded time.Time `json:"date_awarded" bson:"date_awarded"`
+}
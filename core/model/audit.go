@@ -0,0 +1,145 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeAuditLog audit log type
+	TypeAuditLog logutils.MessageDataType = "audit log"
+
+	//AuditActionCreate create audit action
+	AuditActionCreate string = "create"
+	//AuditActionUpdate update audit action
+	AuditActionUpdate string = "update"
+	//AuditActionDelete delete audit action
+	AuditActionDelete string = "delete"
+	//AuditActionRestore restore audit action
+	AuditActionRestore string = "restore"
+	//AuditActionPublish publish audit action
+	AuditActionPublish string = "publish"
+	//AuditActionMigrate migrate audit action
+	AuditActionMigrate string = "migrate"
+	//AuditActionEnroll enroll audit action
+	AuditActionEnroll string = "enroll"
+	//AuditActionRepair repair audit action
+	AuditActionRepair string = "repair"
+	//AuditActionRestart restart audit action
+	AuditActionRestart string = "restart"
+
+	// MaxAuditLogsLimit is the maximum number of audit log entries returned at once
+	MaxAuditLogsLimit int = 1000
+)
+
+// AuditLog represents a change made by an admin. Entries are never updated or deleted
+type AuditLog struct {
+	ID    string `json:"id" bson:"_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+
+	Actor      string `json:"actor" bson:"actor"` // account ID of the admin who made the change
+	Action     string `json:"action" bson:"action"`
+	EntityType string `json:"entity_type" bson:"entity_type"`
+	EntityKey  string `json:"entity_key" bson:"entity_key"` // key of the changed entity (ID for entities without a key)
+
+	Changes []AuditChange `json:"changes" bson:"changes"`
+
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+}
+
+// AuditChange represents the values of a changed field before and after a change
+type AuditChange struct {
+	Field  string      `json:"field" bson:"field"` // dot separated path of the field
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+// NewAuditLog creates an audit log entry with the differences between the entity before and after a change (nil if it did not exist)
+func NewAuditLog(appID string, orgID string, actor string, action string, entityType logutils.MessageDataType, entityKey string, before interface{}, after interface{}, now time.Time) (*AuditLog, error) {
+	beforeValue, err := auditValue(before)
+	if err != nil {
+		return nil, err
+	}
+	afterValue, err := auditValue(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]AuditChange, 0)
+	changes = diffAuditValues(changes, "", beforeValue, afterValue)
+	return &AuditLog{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Actor: actor, Action: action, EntityType: string(entityType), EntityKey: entityKey,
+		Changes: changes, DateCreated: now.UTC()}, nil
+}
+
+// auditValue converts an entity to its JSON representation so that it is compared the way it is seen in the API
+func auditValue(entity interface{}) (interface{}, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	if value := reflect.ValueOf(entity); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// diffAuditValues appends a change for every field which differs, descending into objects so that only the changed fields are recorded
+func diffAuditValues(changes []AuditChange, path string, before interface{}, after interface{}) []AuditChange {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+	if (beforeIsObject || before == nil) && (afterIsObject || after == nil) && (beforeIsObject || afterIsObject) {
+		fields := make([]string, 0, len(beforeObject)+len(afterObject))
+		for field := range beforeObject {
+			fields = append(fields, field)
+		}
+		for field := range afterObject {
+			if _, ok := beforeObject[field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			fieldPath := field
+			if path != "" {
+				fieldPath = path + "." + field
+			}
+			changes = diffAuditValues(changes, fieldPath, beforeObject[field], afterObject[field])
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes = append(changes, AuditChange{Field: path, Before: before, After: after})
+	}
+	return changes
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCertificate certificate type
+	TypeCertificate logutils.MessageDataType = "certificate"
+	//TypeCertificateToken certificate token type
+	TypeCertificateToken logutils.MessageDataType = "certificate token"
+	//TypeCertificateVerification certificate verification type
+	TypeCertificateVerification logutils.MessageDataType = "certificate verification"
+)
+
+// Certificate represents a signed credential issued to a user for completing a course
+type Certificate struct {
+	ID     string `json:"id" bson:"_id"`
+	AppID  string `json:"app_id" bson:"app_id"`
+	OrgID  string `json:"org_id" bson:"org_id"`
+	UserID string `json:"user_id" bson:"user_id"`
+
+	RecipientName string `json:"recipient_name" bson:"recipient_name"` // name of the user when the certificate was issued
+
+	CourseKey     string `json:"course_key" bson:"course_key"`
+	CourseName    string `json:"course_name" bson:"course_name"`
+	CourseVersion int    `json:"course_version" bson:"course_version"`
+
+	DateCompleted time.Time        `json:"date_completed" bson:"date_completed"`
+	Stats         CertificateStats `json:"stats" bson:"stats"`
+
+	KeyID string `json:"key_id" bson:"key_id"` // fingerprint of the public key which verifies the token
+	Token string `json:"token" bson:"token"`   // JWT containing the certificate details signed by the service
+
+	DateIssued time.Time `json:"date_issued" bson:"date_issued"`
+}
+
+// CertificateStats represents the course statistics recorded on a certificate
+type CertificateStats struct {
+	CompletedModules int `json:"completed_modules" bson:"completed_modules"`
+	Streak           int `json:"streak" bson:"streak"`
+	StreakResets     int `json:"streak_resets" bson:"streak_resets"`
+	Days             int `json:"days" bson:"days"` // days from enrollment to completion
+}
+
+// NewCertificate creates an unsigned certificate for a completed user course
+func NewCertificate(userCourse UserCourse, recipientName string, now time.Time) (*Certificate, error) {
+	if userCourse.DateCompleted == nil {
+		return nil, errors.ErrorData(logutils.StatusInvalid, TypeUserCourse, &logutils.FieldArgs{"user_id": userCourse.UserID, "course_key": userCourse.Course.Key, "date_completed": nil})
+	}
+
+	dateCompleted := userCourse.DateCompleted.UTC()
+	stats := CertificateStats{
+		CompletedModules: len(userCourse.CompletedModules),
+		Streak:           userCourse.Streak,
+		StreakResets:     len(userCourse.StreakResets),
+		Days:             int(dateCompleted.Sub(userCourse.DateCreated).Hours()/24) + 1,
+	}
+
+	return &Certificate{ID: uuid.NewString(), AppID: userCourse.AppID, OrgID: userCourse.OrgID, UserID: userCourse.UserID, RecipientName: recipientName,
+		CourseKey: userCourse.Course.Key, CourseName: userCourse.Course.Name, CourseVersion: userCourse.CourseVersion, DateCompleted: dateCompleted,
+		Stats: stats, DateIssued: now.UTC()}, nil
+}
+
+// CertificateVerification represents the result of verifying the signature of a certificate token
+type CertificateVerification struct {
+	Token string `json:"token"`
+
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"` // why the token could not be verified
+
+	Certificate *Certificate `json:"certificate,omitempty"` // details contained in the verified token
+}
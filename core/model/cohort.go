@@ -0,0 +1,84 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCohort cohort type
+	TypeCohort logutils.MessageDataType = "cohort"
+	//TypeCohortEnrollment cohort enrollment type
+	TypeCohortEnrollment logutils.MessageDataType = "cohort enrollment"
+)
+
+// Cohort represents a group of users enrolled in a course by an admin who start the course together
+type Cohort struct {
+	ID        string `json:"id" bson:"_id"`
+	AppID     string `json:"app_id" bson:"app_id"`
+	OrgID     string `json:"org_id" bson:"org_id"`
+	Key       string `json:"key" bson:"key"`
+	CourseKey string `json:"course_key" bson:"course_key"`
+
+	Name      string    `json:"name" bson:"name"`
+	StartDate time.Time `json:"start_date" bson:"start_date"` // members may not start any module before this date
+	Timezone  Timezone  `json:"timezone" bson:"timezone"`     // given to members until they report their own timezone
+
+	AccountIDs []string `json:"account_ids,omitempty" bson:"account_ids,omitempty"` // accounts enrolled in the cohort
+	GroupTitle string   `json:"group_title,omitempty" bson:"group_title,omitempty"` // Groups BB group whose members are enrolled in the cohort
+
+	DateCreated time.Time  `json:"date_created" bson:"date_created"`
+	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+}
+
+// Validate checks the cohort keys, start date, members and timezone (defaults to UTC if no timezone name is set)
+func (c *Cohort) Validate() error {
+	if c == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeCohort, nil)
+	}
+
+	if c.Key == "" || c.CourseKey == "" {
+		return errors.ErrorData(logutils.StatusMissing, "cohort key", &logutils.FieldArgs{"key": c.Key, "course_key": c.CourseKey})
+	}
+	if c.StartDate.IsZero() {
+		return errors.ErrorData(logutils.StatusMissing, "cohort start date", &logutils.FieldArgs{"key": c.Key})
+	}
+	if len(c.AccountIDs) == 0 && c.GroupTitle == "" {
+		return errors.ErrorData(logutils.StatusMissing, "cohort members", &logutils.FieldArgs{"key": c.Key})
+	}
+	if len(c.AccountIDs) > 0 && c.GroupTitle != "" {
+		return errors.ErrorData(logutils.StatusInvalid, "cohort members", &logutils.FieldArgs{"key": c.Key, "account_ids": len(c.AccountIDs), "group_title": c.GroupTitle})
+	}
+
+	if c.Timezone.Name == "" {
+		c.Timezone = Timezone{Name: "UTC", Offset: 0}
+	}
+	err := c.Timezone.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, TypeTimezone, &logutils.FieldArgs{"key": c.Key}, err)
+	}
+	return nil
+}
+
+// CohortEnrollment represents the result of enrolling the members of a cohort in its course
+type CohortEnrollment struct {
+	Enrolled []string `json:"enrolled"` // users enrolled by this request
+	Skipped  []string `json:"skipped"`  // users who were already taking the course
+	Failed   []string `json:"failed"`   // users who could not be enrolled (enrolling the cohort again retries them)
+}
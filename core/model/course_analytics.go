@@ -0,0 +1,224 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCourseAnalytics course analytics type
+	TypeCourseAnalytics logutils.MessageDataType = "course analytics"
+)
+
+// CourseAnalytics represents how far the users enrolled in a course have progressed through it
+type CourseAnalytics struct {
+	CourseKey string `json:"course_key"`
+	Enrolled  int    `json:"enrolled"`
+	Completed int    `json:"completed"`
+	Dropped   int    `json:"dropped"`
+
+	Modules      []ModuleAnalytics  `json:"modules"`       // in course order
+	DropOffs     []DropOff          `json:"drop_offs"`     // most users first
+	StreakResets []StreakResetCount `json:"streak_resets"` // by ascending number of resets
+}
+
+// ModuleAnalytics represents the number of users who started and completed a module
+type ModuleAnalytics struct {
+	ModuleKey string          `json:"module_key"`
+	Started   int             `json:"started"`
+	Completed int             `json:"completed"`
+	Units     []UnitAnalytics `json:"units"`
+}
+
+// UnitAnalytics represents the number of users who reached and completed a unit
+type UnitAnalytics struct {
+	UnitKey       string                  `json:"unit_key"`
+	Reached       int                     `json:"reached"`
+	Completed     int                     `json:"completed"`
+	ScheduleItems []ScheduleItemAnalytics `json:"schedule_items"`
+}
+
+// ScheduleItemAnalytics represents the number of users who started and completed a schedule item and how long it took them
+type ScheduleItemAnalytics struct {
+	Index         int    `json:"index"`
+	Name          string `json:"name"`
+	Started       int    `json:"started"`
+	Completed     int    `json:"completed"`
+	MedianSeconds *int64 `json:"median_seconds"` // median time from start to completion (nil if no user has completed it)
+}
+
+// DropOff represents the number of users who stopped progressing at a unit
+type DropOff struct {
+	ModuleKey *string `json:"module_key"` // nil if the users stopped before starting any unit
+	UnitKey   *string `json:"unit_key"`
+	Dropped   int     `json:"dropped"`  // users who dropped the course
+	Inactive  int     `json:"inactive"` // users who have not completed the course and have been inactive
+}
+
+// StreakResetCount represents the number of users whose streak has been reset a number of times
+type StreakResetCount struct {
+	Resets int `json:"resets" bson:"_id"`
+	Users  int `json:"users" bson:"users"`
+}
+
+// CourseProgressStats represents progress counts aggregated over the user courses and user units of a course
+type CourseProgressStats struct {
+	Enrolled  int
+	Completed int
+	Dropped   int
+
+	StreakResets     []StreakResetCount
+	ModulesStarted   map[string]int // users by module key
+	ModulesCompleted map[string]int // users by module key
+	Units            []UnitProgressStats
+	ScheduleItems    []ScheduleItemProgressStats
+}
+
+// UnitProgressStats represents the number of user units of a unit and how many of them are completed
+type UnitProgressStats struct {
+	ModuleKey string
+	UnitKey   string
+	Reached   int
+	Completed int
+}
+
+// ScheduleItemProgressStats represents the number of users who started and completed a schedule item
+type ScheduleItemProgressStats struct {
+	ModuleKey string
+	UnitKey   string
+	Index     int
+	Started   int
+	Completed int
+	Durations []int64 // milliseconds from start to completion
+}
+
+// NewCourseAnalytics arranges progress stats by the modules, units and schedule items of the course (stats of items no longer in the course are left out)
+func NewCourseAnalytics(course Course, stats CourseProgressStats) CourseAnalytics {
+	type unitID struct {
+		moduleKey string
+		unitKey   string
+	}
+	type itemID struct {
+		unitID
+		index int
+	}
+	units := make(map[unitID]UnitProgressStats)
+	for _, unit := range stats.Units {
+		units[unitID{unit.ModuleKey, unit.UnitKey}] = unit
+	}
+	items := make(map[itemID]ScheduleItemProgressStats)
+	for _, item := range stats.ScheduleItems {
+		items[itemID{unitID{item.ModuleKey, item.UnitKey}, item.Index}] = item
+	}
+
+	analytics := CourseAnalytics{CourseKey: course.Key, Enrolled: stats.Enrolled, Completed: stats.Completed, Dropped: stats.Dropped,
+		Modules: make([]ModuleAnalytics, len(course.Modules)), DropOffs: make([]DropOff, 0), StreakResets: stats.StreakResets}
+	if analytics.StreakResets == nil {
+		analytics.StreakResets = make([]StreakResetCount, 0)
+	}
+	for i, module := range course.Modules {
+		moduleAnalytics := ModuleAnalytics{ModuleKey: module.Key, Started: stats.ModulesStarted[module.Key], Completed: stats.ModulesCompleted[module.Key],
+			Units: make([]UnitAnalytics, len(module.Units))}
+		for j, unit := range module.Units {
+			id := unitID{module.Key, unit.Key}
+			unitAnalytics := UnitAnalytics{UnitKey: unit.Key, Reached: units[id].Reached, Completed: units[id].Completed,
+				ScheduleItems: make([]ScheduleItemAnalytics, len(unit.Schedule))}
+			for k, scheduleItem := range unit.Schedule {
+				item := items[itemID{id, k}]
+				unitAnalytics.ScheduleItems[k] = ScheduleItemAnalytics{Index: k, Name: scheduleItem.Name, Started: item.Started, Completed: item.Completed,
+					MedianSeconds: medianSeconds(item.Durations)}
+			}
+			moduleAnalytics.Units[j] = unitAnalytics
+		}
+		analytics.Modules[i] = moduleAnalytics
+	}
+	return analytics
+}
+
+// SetDropOffs counts the dropped and inactive users by the unit they are currently on
+func (a *CourseAnalytics) SetDropOffs(dropped []LearnerProgress, inactive []LearnerProgress) {
+	if a == nil {
+		return
+	}
+
+	type position struct {
+		moduleKey string
+		unitKey   string
+	}
+	dropOffs := make(map[position]*DropOff)
+	count := func(learners []LearnerProgress, isDropped bool) {
+		for _, learner := range learners {
+			var pos position
+			if learner.CurrentModuleKey != nil && learner.CurrentUnitKey != nil {
+				pos = position{*learner.CurrentModuleKey, *learner.CurrentUnitKey}
+			}
+			dropOff := dropOffs[pos]
+			if dropOff == nil {
+				dropOff = &DropOff{ModuleKey: learner.CurrentModuleKey, UnitKey: learner.CurrentUnitKey}
+				if learner.CurrentUnitKey == nil {
+					dropOff.ModuleKey = nil
+				}
+				dropOffs[pos] = dropOff
+			}
+			if isDropped {
+				dropOff.Dropped++
+			} else {
+				dropOff.Inactive++
+			}
+		}
+	}
+	count(dropped, true)
+	count(inactive, false)
+
+	a.DropOffs = make([]DropOff, 0, len(dropOffs))
+	for _, dropOff := range dropOffs {
+		a.DropOffs = append(a.DropOffs, *dropOff)
+	}
+	sort.Slice(a.DropOffs, func(i, j int) bool {
+		left, right := a.DropOffs[i], a.DropOffs[j]
+		if left.Dropped+left.Inactive != right.Dropped+right.Inactive {
+			return left.Dropped+left.Inactive > right.Dropped+right.Inactive
+		}
+		if (left.UnitKey == nil) != (right.UnitKey == nil) {
+			return left.UnitKey == nil
+		}
+		if left.UnitKey == nil {
+			return false
+		}
+		if *left.ModuleKey != *right.ModuleKey {
+			return *left.ModuleKey < *right.ModuleKey
+		}
+		return *left.UnitKey < *right.UnitKey
+	})
+}
+
+func medianSeconds(durations []int64) *int64 {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sorted := make([]int64, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	median /= 1000
+	return &median
+}
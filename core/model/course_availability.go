@@ -0,0 +1,84 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCourseAvailability course availability type
+	TypeCourseAvailability logutils.MessageDataType = "course availability"
+	//TypeCourseVisibility course visibility type
+	TypeCourseVisibility logutils.MessageDataType = "course visibility"
+)
+
+// CourseAvailability represents when users may enroll in and take a course. Unset times do not restrict the course
+type CourseAvailability struct {
+	EnrollmentStart *time.Time `json:"enrollment_start,omitempty" bson:"enrollment_start,omitempty"`
+	EnrollmentEnd   *time.Time `json:"enrollment_end,omitempty" bson:"enrollment_end,omitempty"`
+	CourseEnd       *time.Time `json:"course_end,omitempty" bson:"course_end,omitempty"` // the course is no longer listed or open for enrollment after this time
+}
+
+// Validate checks that the enrollment window opens before it closes and closes before the course ends
+func (a *CourseAvailability) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	if a.EnrollmentStart != nil && a.EnrollmentEnd != nil && !a.EnrollmentStart.Before(*a.EnrollmentEnd) {
+		return errors.ErrorData(logutils.StatusInvalid, "enrollment end", &logutils.FieldArgs{"enrollment_start": a.EnrollmentStart, "enrollment_end": a.EnrollmentEnd})
+	}
+	if a.EnrollmentStart != nil && a.CourseEnd != nil && !a.EnrollmentStart.Before(*a.CourseEnd) {
+		return errors.ErrorData(logutils.StatusInvalid, "course end", &logutils.FieldArgs{"enrollment_start": a.EnrollmentStart, "course_end": a.CourseEnd})
+	}
+	if a.EnrollmentEnd != nil && a.CourseEnd != nil && a.EnrollmentEnd.After(*a.CourseEnd) {
+		return errors.ErrorData(logutils.StatusInvalid, "enrollment end", &logutils.FieldArgs{"enrollment_end": a.EnrollmentEnd, "course_end": a.CourseEnd})
+	}
+	return nil
+}
+
+// HasEnded returns whether the course has ended at now
+func (a *CourseAvailability) HasEnded(now time.Time) bool {
+	return a != nil && a.CourseEnd != nil && !now.Before(*a.CourseEnd)
+}
+
+// IsEnrollmentOpen returns whether users may enroll in the course at now
+func (a *CourseAvailability) IsEnrollmentOpen(now time.Time) bool {
+	if a == nil {
+		return true
+	}
+	if a.EnrollmentStart != nil && now.Before(*a.EnrollmentStart) {
+		return false
+	}
+	if a.EnrollmentEnd != nil && !now.Before(*a.EnrollmentEnd) {
+		return false
+	}
+	return !a.HasEnded(now)
+}
+
+// CourseVisibility restricts a course to members of any of the listed groups or users with any of the listed roles
+type CourseVisibility struct {
+	Groups []string `json:"groups,omitempty" bson:"groups,omitempty"` // Groups BB group titles
+	Roles  []string `json:"roles,omitempty" bson:"roles,omitempty"`   // Core role names or IDs
+}
+
+// IsRestricted returns whether the course is visible only to some users
+func (v *CourseVisibility) IsRestricted() bool {
+	return v != nil && (len(v.Groups) > 0 || len(v.Roles) > 0)
+}
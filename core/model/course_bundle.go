@@ -0,0 +1,129 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCourseBundle course bundle type
+	TypeCourseBundle logutils.MessageDataType = "course bundle"
+
+	//CourseBundleFormatVersion is the current version of the course bundle format
+	CourseBundleFormatVersion int = 1
+)
+
+// CourseBundle represents a course with all of its modules, units, schedules and contents as a single document which can be moved between environments
+type CourseBundle struct {
+	FormatVersion int    `json:"format_version"`
+	Course        Course `json:"course"`
+}
+
+// NewCourseBundle creates a bundle from a course, removing all environment specific fields
+func NewCourseBundle(course Course) CourseBundle {
+	course.ID, course.AppID, course.OrgID = "", "", ""
+	modules := make([]Module, len(course.Modules))
+	for i, module := range course.Modules {
+		module.ID, module.AppID, module.OrgID = "", "", ""
+		units := make([]Unit, len(module.Units))
+		for j, unit := range module.Units {
+			unit.ID, unit.AppID, unit.OrgID = "", "", ""
+			contents := make([]Content, len(unit.Contents))
+			for k, content := range unit.Contents {
+				content.ID, content.AppID, content.OrgID = "", "", ""
+				contents[k] = content
+			}
+			unit.Contents = contents
+			units[j] = unit
+		}
+		module.Units = units
+		modules[i] = module
+	}
+	course.Modules = modules
+
+	return CourseBundle{FormatVersion: CourseBundleFormatVersion, Course: course}
+}
+
+// Validate checks the bundle as a whole and returns its distinct modules, units and contents in the order they first appear
+//
+//	Module and unit keys must be unique within the bundle. A content item may be shared by several units, but all copies must be identical.
+//	All schedule content keys and linked content keys must refer to content items in the bundle.
+func (b *CourseBundle) Validate() ([]Module, []Unit, []Content, error) {
+	if b == nil {
+		return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, TypeCourseBundle, nil)
+	}
+	if b.FormatVersion != CourseBundleFormatVersion {
+		return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, "course bundle format version", &logutils.FieldArgs{"format_version": b.FormatVersion})
+	}
+	if b.Course.Key == "" {
+		return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, "course key", nil)
+	}
+	err := b.Course.Validate()
+	if err != nil {
+		return nil, nil, nil, errors.WrapErrorAction(logutils.ActionValidate, TypeCourse, &logutils.FieldArgs{"key": b.Course.Key}, err)
+	}
+
+	modules := make([]Module, 0)
+	units := make([]Unit, 0)
+	contents := make([]Content, 0)
+	contentsByKey := make(map[string]Content)
+	for _, module := range b.Course.Modules {
+		for _, existing := range modules {
+			if existing.Key == module.Key {
+				return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, "duplicate module key", &logutils.FieldArgs{"key": module.Key})
+			}
+		}
+		modules = append(modules, module)
+
+		for _, unit := range module.Units {
+			for _, existing := range units {
+				if existing.Key == unit.Key {
+					return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, "duplicate unit key", &logutils.FieldArgs{"key": unit.Key, "module_key": module.Key})
+				}
+			}
+			units = append(units, unit)
+
+			for _, content := range unit.Contents {
+				if content.Key == "" {
+					return nil, nil, nil, errors.ErrorData(logutils.StatusMissing, "content key", &logutils.FieldArgs{"unit_key": unit.Key})
+				}
+				err := content.Validate()
+				if err != nil {
+					return nil, nil, nil, errors.WrapErrorAction(logutils.ActionValidate, TypeContent, &logutils.FieldArgs{"unit_key": unit.Key}, err)
+				}
+				content.ID, content.AppID, content.OrgID = "", "", ""
+				existing, exists := contentsByKey[content.Key]
+				if !exists {
+					contentsByKey[content.Key] = content
+					contents = append(contents, content)
+				} else if !existing.Equals(&content) {
+					return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, "conflicting content definitions", &logutils.FieldArgs{"key": content.Key, "unit_key": unit.Key})
+				}
+			}
+		}
+	}
+
+	for _, content := range contents {
+		for _, key := range content.LinkedContent {
+			if _, exists := contentsByKey[key]; !exists {
+				return nil, nil, nil, errors.ErrorData(logutils.StatusInvalid, "linked content key", &logutils.FieldArgs{"key": content.Key, "linked_content_key": key})
+			}
+		}
+	}
+
+	return modules, units, contents, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCourseClone course clone type
+	TypeCourseClone logutils.MessageDataType = "course clone"
+)
+
+// CourseClone represents the options for deep-copying a course with all of its modules, units and contents under new keys
+type CourseClone struct {
+	KeyPrefix string  `json:"key_prefix"` // added to the start of every cloned key
+	KeySuffix string  `json:"key_suffix"` // added to the end of every cloned key
+	Name      *string `json:"name"`       // name of the cloned course (defaults to the source course name)
+	AppID     *string `json:"app_id"`     // app to clone into (defaults to the source app)
+	OrgID     *string `json:"org_id"`     // org to clone into (defaults to the source org)
+}
+
+// NewKey returns the key an item with the given key is cloned under
+func (c CourseClone) NewKey(key string) string {
+	return c.KeyPrefix + key + c.KeySuffix
+}
+
+// CloneCourse deep-copies the source course into the given app/org under new keys and returns the cloned course with its distinct modules, units and contents
+//
+//	Schedule content keys, linked content keys, prerequisite module keys and style values which equal a cloned key are rewritten to the new key
+func (c CourseClone) CloneCourse(source Course, appID string, orgID string, now time.Time) (Course, []Module, []Unit, []Content) {
+	keys := map[string]string{source.Key: c.NewKey(source.Key)}
+	for _, module := range source.Modules {
+		keys[module.Key] = c.NewKey(module.Key)
+		for _, unit := range module.Units {
+			keys[unit.Key] = c.NewKey(unit.Key)
+			for _, content := range unit.Contents {
+				keys[content.Key] = c.NewKey(content.Key)
+			}
+		}
+	}
+	newKey := func(key string) string {
+		if cloned, exists := keys[key]; exists {
+			return cloned
+		}
+		return key
+	}
+
+	course := Course{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Key: newKey(source.Key), Name: source.Name,
+		Availability: source.Availability, Visibility: source.Visibility, Localizations: cloneLocalizations(source.Localizations, newKey), DateCreated: now}
+	if c.Name != nil {
+		course.Name = *c.Name
+	}
+
+	modules := make([]Module, 0)
+	units := make([]Unit, 0)
+	contents := make([]Content, 0)
+	clonedContents := make(map[string]Content)
+	for _, sourceModule := range source.Modules {
+		module := Module{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Key: newKey(sourceModule.Key), Name: sourceModule.Name,
+			Prerequisites: clonePrerequisites(sourceModule.Prerequisites, newKey), Styles: cloneStyles(sourceModule.Styles, newKey),
+			Localizations: cloneLocalizations(sourceModule.Localizations, newKey), DateCreated: now}
+		for _, sourceUnit := range sourceModule.Units {
+			unit := Unit{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Key: newKey(sourceUnit.Key), Name: sourceUnit.Name,
+				Required: sourceUnit.Required, Localizations: cloneLocalizations(sourceUnit.Localizations, newKey), DateCreated: now}
+
+			unit.Schedule = make([]ScheduleItem, len(sourceUnit.Schedule))
+			for i, item := range sourceUnit.Schedule {
+				unit.Schedule[i] = ScheduleItem{Name: item.Name, ContentKeys: cloneKeys(item.ContentKeys, newKey), Duration: item.Duration}
+			}
+
+			unit.Contents = make([]Content, len(sourceUnit.Contents))
+			for i, sourceContent := range sourceUnit.Contents {
+				content, exists := clonedContents[sourceContent.Key]
+				if !exists {
+					content = Content{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Key: newKey(sourceContent.Key), Type: sourceContent.Type,
+						Name: sourceContent.Name, Details: sourceContent.Details, Reference: sourceContent.Reference,
+						LinkedContent: cloneKeys(sourceContent.LinkedContent, newKey), Evaluation: sourceContent.Evaluation, ResponseSchema: sourceContent.ResponseSchema, Styles: cloneStyles(sourceContent.Styles, newKey),
+						Localizations: cloneLocalizations(sourceContent.Localizations, newKey), DateCreated: now}
+					clonedContents[sourceContent.Key] = content
+					contents = append(contents, content)
+				}
+				unit.Contents[i] = content
+			}
+
+			module.Units = append(module.Units, unit)
+			units = append(units, unit)
+		}
+		course.Modules = append(course.Modules, module)
+		modules = append(modules, module)
+	}
+
+	return course, modules, units, contents
+}
+
+func cloneKeys(keys []string, newKey func(string) string) []string {
+	if keys == nil {
+		return nil
+	}
+
+	cloned := make([]string, len(keys))
+	for i, key := range keys {
+		cloned[i] = newKey(key)
+	}
+	return cloned
+}
+
+func clonePrerequisites(prerequisites []ModulePrerequisite, newKey func(string) string) []ModulePrerequisite {
+	if prerequisites == nil {
+		return nil
+	}
+
+	cloned := make([]ModulePrerequisite, len(prerequisites))
+	for i, prerequisite := range prerequisites {
+		if prerequisite.ModuleKey != "" {
+			prerequisite.ModuleKey = newKey(prerequisite.ModuleKey)
+		}
+		cloned[i] = prerequisite
+	}
+	return cloned
+}
+
+func cloneStyles(styles Styles, newKey func(string) string) Styles {
+	return Styles{
+		Colors:  cloneStyleMap(styles.Colors, newKey),
+		Images:  cloneStyleMap(styles.Images, newKey),
+		Strings: cloneStyleMap(styles.Strings, newKey),
+	}
+}
+
+// cloneLocalizations rewrites the keys referenced by localized strings the same way as styles strings
+func cloneLocalizations(localizations Localizations, newKey func(string) string) Localizations {
+	if localizations == nil {
+		return nil
+	}
+
+	cloned := make(Localizations, len(localizations))
+	for locale, localization := range localizations {
+		localization.Strings = cloneStyleMap(localization.Strings, newKey)
+		cloned[locale] = localization
+	}
+	return cloned
+}
+
+func cloneStyleMap(values map[string]interface{}, newKey func(string) string) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	cloned := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		cloned[name] = cloneStyleValue(value, newKey)
+	}
+	return cloned
+}
+
+func cloneStyleValue(value interface{}, newKey func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return newKey(v)
+	case map[string]interface{}:
+		return cloneStyleMap(v, newKey)
+	case []interface{}:
+		cloned := make([]interface{}, len(v))
+		for i, item := range v {
+			cloned[i] = cloneStyleValue(item, newKey)
+		}
+		return cloned
+	default:
+		return v
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"lms/utils"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeCourseVersion course version type
+	TypeCourseVersion logutils.MessageDataType = "course version"
+	//TypeCourseVersionPreview course version preview type
+	TypeCourseVersionPreview logutils.MessageDataType = "course version preview"
+	//TypeCourseMigration course migration type
+	TypeCourseMigration logutils.MessageDataType = "course migration"
+
+	//CourseChangeAdded indicates an item exists in the draft but not the published version
+	CourseChangeAdded string = "added"
+	//CourseChangeRemoved indicates an item exists in the published version but not the draft
+	CourseChangeRemoved string = "removed"
+	//CourseChangeModified indicates an item exists in both but its data differs
+	CourseChangeModified string = "modified"
+
+	//UserUnitMigrationUnchanged indicates the unit schedule did not change
+	UserUnitMigrationUnchanged string = "unchanged"
+	//UserUnitMigrationUpdated indicates the unit changed without affecting the schedule items the user has completed
+	UserUnitMigrationUpdated string = "updated"
+	//UserUnitMigrationRegressed indicates the unit changed before the current schedule item, so some progress is discarded
+	UserUnitMigrationRegressed string = "regressed"
+	//UserUnitMigrationCompleted indicates the unit schedule was shortened so the user has completed it
+	UserUnitMigrationCompleted string = "completed"
+	//UserUnitMigrationRemoved indicates a unit the user has finished no longer exists (the user unit is kept as history)
+	UserUnitMigrationRemoved string = "removed"
+	//UserUnitMigrationBlocked indicates the unit the user is currently taking no longer exists
+	UserUnitMigrationBlocked string = "blocked"
+
+	//UserCourseMigrationReady indicates the user can be migrated
+	UserCourseMigrationReady string = "ready"
+	//UserCourseMigrationMigrated indicates the user has been migrated
+	UserCourseMigrationMigrated string = "migrated"
+	//UserCourseMigrationBlocked indicates the user cannot be migrated until the blocked units are resolved
+	UserCourseMigrationBlocked string = "blocked"
+	//UserCourseMigrationFailed indicates an error occurred while migrating the user, so their progress was left unchanged
+	UserCourseMigrationFailed string = "failed"
+)
+
+// CourseVersion represents a published snapshot of a course including all of its modules, units and contents
+type CourseVersion struct {
+	ID        string `json:"id" bson:"_id"`
+	AppID     string `json:"app_id" bson:"app_id"`
+	OrgID     string `json:"org_id" bson:"org_id"`
+	CourseKey string `json:"course_key" bson:"course_key"`
+
+	Version int    `json:"version" bson:"version"` // starts at 1 and increases with each publish
+	Notes   string `json:"notes" bson:"notes"`
+	Course  Course `json:"course" bson:"course"`
+
+	DatePublished time.Time `json:"date_published" bson:"date_published"`
+}
+
+// CourseVersionPreview represents the changes which would be published from the draft of a course
+type CourseVersionPreview struct {
+	CourseKey        string         `json:"course_key"`
+	PublishedVersion int            `json:"published_version"` // 0 if the course has never been published
+	Draft            Course         `json:"draft"`
+	Changes          []CourseChange `json:"changes"`
+}
+
+// CourseChange represents a difference between the draft and published version of a course
+type CourseChange struct {
+	Type      string `json:"type"`       // module, unit, content
+	Key       string `json:"key"`        // key of the changed item
+	ParentKey string `json:"parent_key"` // key of the module containing a unit or the unit containing a content item
+	Change    string `json:"change"`     // added, removed, modified
+}
+
+// DiffCourses lists the module, unit and content changes from the published course to the draft course (published may be nil)
+func DiffCourses(published *Course, draft Course) []CourseChange {
+	changes := make([]CourseChange, 0)
+	var publishedModules []Module
+	if published != nil {
+		publishedModules = published.Modules
+		if published.Name != draft.Name || !utils.DeepEqual(published.Localizations, draft.Localizations) ||
+			!utils.Equal(moduleKeys(published.Modules), moduleKeys(draft.Modules), true) {
+			changes = append(changes, CourseChange{Type: "course", Key: draft.Key, Change: CourseChangeModified})
+		}
+	}
+
+	for _, module := range draft.Modules {
+		var publishedModule *Module
+		for i := range publishedModules {
+			if publishedModules[i].Key == module.Key {
+				publishedModule = &publishedModules[i]
+				break
+			}
+		}
+		if publishedModule == nil {
+			changes = append(changes, CourseChange{Type: "module", Key: module.Key, ParentKey: draft.Key, Change: CourseChangeAdded})
+			continue
+		}
+		if publishedModule.Name != module.Name || !utils.DeepEqual(publishedModule.Styles, module.Styles) || !utils.DeepEqual(publishedModule.Localizations, module.Localizations) ||
+			!utils.DeepEqual(publishedModule.Prerequisites, module.Prerequisites) || !utils.Equal(unitKeys(publishedModule.Units), unitKeys(module.Units), true) {
+			changes = append(changes, CourseChange{Type: "module", Key: module.Key, ParentKey: draft.Key, Change: CourseChangeModified})
+		}
+		changes = append(changes, diffUnits(module.Key, publishedModule.Units, module.Units)...)
+	}
+	for _, module := range publishedModules {
+		if draft.GetModule(module.Key) == nil {
+			changes = append(changes, CourseChange{Type: "module", Key: module.Key, ParentKey: draft.Key, Change: CourseChangeRemoved})
+		}
+	}
+
+	return changes
+}
+
+func diffUnits(moduleKey string, published []Unit, draft []Unit) []CourseChange {
+	changes := make([]CourseChange, 0)
+	for _, unit := range draft {
+		var publishedUnit *Unit
+		for i := range published {
+			if published[i].Key == unit.Key {
+				publishedUnit = &published[i]
+				break
+			}
+		}
+		if publishedUnit == nil {
+			changes = append(changes, CourseChange{Type: "unit", Key: unit.Key, ParentKey: moduleKey, Change: CourseChangeAdded})
+			continue
+		}
+		if publishedUnit.Name != unit.Name || !utils.DeepEqual(publishedUnit.Schedule, unit.Schedule) || !utils.DeepEqual(publishedUnit.Localizations, unit.Localizations) {
+			changes = append(changes, CourseChange{Type: "unit", Key: unit.Key, ParentKey: moduleKey, Change: CourseChangeModified})
+		}
+
+		for _, content := range unit.Contents {
+			change := CourseChangeAdded
+			for j := range publishedUnit.Contents {
+				if publishedUnit.Contents[j].Key == content.Key {
+					change = ""
+					if !publishedUnit.Contents[j].Equals(&content) {
+						change = CourseChangeModified
+					}
+					break
+				}
+			}
+			if change != "" {
+				changes = append(changes, CourseChange{Type: "content", Key: content.Key, ParentKey: unit.Key, Change: change})
+			}
+		}
+		for _, content := range publishedUnit.Contents {
+			if !utils.Exist(contentKeys(unit.Contents), content.Key) {
+				changes = append(changes, CourseChange{Type: "content", Key: content.Key, ParentKey: unit.Key, Change: CourseChangeRemoved})
+			}
+		}
+	}
+	for _, unit := range published {
+		if !utils.Exist(unitKeys(draft), unit.Key) {
+			changes = append(changes, CourseChange{Type: "unit", Key: unit.Key, ParentKey: moduleKey, Change: CourseChangeRemoved})
+		}
+	}
+	return changes
+}
+
+func moduleKeys(modules []Module) []string {
+	keys := make([]string, len(modules))
+	for i, module := range modules {
+		keys[i] = module.Key
+	}
+	return keys
+}
+
+func unitKeys(units []Unit) []string {
+	keys := make([]string, len(units))
+	for i, unit := range units {
+		keys[i] = unit.Key
+	}
+	return keys
+}
+
+func contentKeys(contents []Content) []string {
+	keys := make([]string, len(contents))
+	for i, content := range contents {
+		keys[i] = content.Key
+	}
+	return keys
+}
+
+// CourseMigration represents the impact of moving users enrolled in a course to a published version
+type CourseMigration struct {
+	CourseKey string                `json:"course_key"`
+	Version   int                   `json:"version"` // target version
+	Applied   bool                  `json:"applied"` // whether the migration was performed or only reported
+	Total     int                   `json:"total"`   // number of users on an older version
+	Blocked   int                   `json:"blocked"` // number of users who cannot be migrated
+	Failed    int                   `json:"failed"`  // number of users whose migration failed
+	Users     []UserCourseMigration `json:"users"`
+}
+
+// UserCourseMigration represents the impact of moving a single user to a published course version
+type UserCourseMigration struct {
+	UserID      string              `json:"user_id"`
+	FromVersion int                 `json:"from_version"`
+	Status      string              `json:"status"` // ready, migrated, blocked, failed
+	Units       []UserUnitMigration `json:"units"`
+}
+
+// UserUnitMigration represents the impact of moving a user unit to a published course version
+type UserUnitMigration struct {
+	ModuleKey         string `json:"module_key"`
+	UnitKey           string `json:"unit_key"`
+	Status            string `json:"status"`             // unchanged, updated, regressed, completed, removed, blocked
+	Completed         int    `json:"completed"`          // completed schedule items before migration
+	MigratedCompleted int    `json:"migrated_completed"` // completed schedule items after migration
+}
+
+// MigrateTo moves the user unit to the target unit, keeping the progress the user made on schedule items which have not changed
+// Completed only counts the leading schedule items which are unchanged in the target, so it always indexes a valid schedule item afterwards
+// A unit the user has finished stays finished, since streak processing only reopens current units
+func (u *UserUnit) MigrateTo(target *Unit, version int, now time.Time) UserUnitMigration {
+	result := UserUnitMigration{ModuleKey: u.ModuleKey, UnitKey: u.Unit.Key, Completed: u.Completed, MigratedCompleted: u.Completed}
+	if target == nil {
+		result.Status = UserUnitMigrationRemoved
+		if u.Current {
+			result.Status = UserUnitMigrationBlocked
+		}
+		return result
+	}
+
+	sameItem := func(i int) bool {
+		return i < len(u.Unit.Schedule) && i < len(target.Schedule) && utils.Equal(u.Unit.Schedule[i].ContentKeys, target.Schedule[i].ContentKeys, true) &&
+			u.Unit.Schedule[i].IsRequired() == target.Schedule[i].IsRequired()
+	}
+
+	completed := 0
+	for completed < u.Completed && sameItem(completed) {
+		completed++
+	}
+	if !u.Current {
+		completed = target.Required
+	}
+
+	targetSchedule := target.CreateUserSchedule()
+	for i := range targetSchedule {
+		if i < len(u.UserSchedule) && sameItem(i) {
+			targetSchedule[i] = u.UserSchedule[i]
+		}
+	}
+
+	result.Status = UserUnitMigrationUnchanged
+	if !utils.DeepEqual(u.Unit.Schedule, target.Schedule) {
+		result.Status = UserUnitMigrationUpdated
+	}
+	if u.Current && completed < u.Completed {
+		result.Status = UserUnitMigrationRegressed
+	}
+	if u.Current && completed >= len(target.Schedule) {
+		result.Status = UserUnitMigrationCompleted
+		u.Current = false
+	}
+	if u.Current && completed < len(targetSchedule) && targetSchedule[completed].DateStarted == nil {
+		// the current schedule item changed, so give the user a full period to complete it
+		targetSchedule[completed].DateStarted = &now
+	}
+
+	u.Unit = *target
+	u.CourseVersion = version
+	u.Completed = completed
+	u.UserSchedule = targetSchedule
+	result.MigratedCompleted = completed
+	return result
+}
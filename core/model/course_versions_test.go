@@ -0,0 +1,145 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func testScheduleItem(required bool, contentKeys ...string) ScheduleItem {
+	item := ScheduleItem{Name: contentKeys[0], ContentKeys: contentKeys}
+	if required {
+		days := 1
+		item.Duration = &days
+	}
+	return item
+}
+
+func testUnit(schedule ...ScheduleItem) Unit {
+	return Unit{Key: "unit", Name: "Unit", Schedule: schedule, Required: len(schedule)}
+}
+
+// testUserUnit gives a user unit of unit which has completed the first schedule items, with the current item started at started
+func testUserUnit(unit Unit, completed int, current bool, started time.Time) UserUnit {
+	userSchedule := unit.CreateUserSchedule()
+	for i := range userSchedule {
+		if i < completed {
+			for j := range userSchedule[i].UserContent {
+				userSchedule[i].UserContent[j].Complete = true
+			}
+			userSchedule[i].DateStarted = &started
+			userSchedule[i].DateCompleted = &started
+		} else if i == completed && current {
+			userSchedule[i].DateStarted = &started
+		}
+	}
+	return UserUnit{ModuleKey: "module", Unit: unit, CourseVersion: 1, Completed: completed, Current: current, UserSchedule: userSchedule}
+}
+
+func TestUserUnitMigrateTo(t *testing.T) {
+	started := time.Date(2026, 3, 1, 5, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 4, 5, 0, 0, 0, time.UTC)
+
+	unit := testUnit(testScheduleItem(true, "a"), testScheduleItem(true, "b"), testScheduleItem(true, "c"))
+	unchanged := testUnit(testScheduleItem(true, "a"), testScheduleItem(true, "b"), testScheduleItem(true, "c"))
+	appended := testUnit(testScheduleItem(true, "a"), testScheduleItem(true, "b"), testScheduleItem(true, "c"), testScheduleItem(true, "d"))
+	changedFirst := testUnit(testScheduleItem(true, "x"), testScheduleItem(true, "b"), testScheduleItem(true, "c"))
+	changedCurrent := testUnit(testScheduleItem(true, "a"), testScheduleItem(true, "b"), testScheduleItem(true, "x"))
+	optionalFirst := testUnit(testScheduleItem(false, "a"), testScheduleItem(true, "b"), testScheduleItem(true, "c"))
+	shortened := testUnit(testScheduleItem(true, "a"), testScheduleItem(true, "b"))
+
+	tests := []struct {
+		name     string
+		userUnit UserUnit
+		target   *Unit
+
+		wantStatus    string
+		wantCompleted int
+		wantCurrent   bool
+		wantStarted   *time.Time // date started of the current schedule item after migration (checked only if current)
+	}{
+		{name: "removed finished unit", userUnit: testUserUnit(unit, 3, false, started), target: nil,
+			wantStatus: UserUnitMigrationRemoved, wantCompleted: 3, wantCurrent: false},
+		{name: "removed current unit", userUnit: testUserUnit(unit, 1, true, started), target: nil,
+			wantStatus: UserUnitMigrationBlocked, wantCompleted: 1, wantCurrent: true},
+		{name: "unchanged", userUnit: testUserUnit(unit, 1, true, started), target: &unchanged,
+			wantStatus: UserUnitMigrationUnchanged, wantCompleted: 1, wantCurrent: true, wantStarted: &started},
+		{name: "item appended after current", userUnit: testUserUnit(unit, 2, true, started), target: &appended,
+			wantStatus: UserUnitMigrationUpdated, wantCompleted: 2, wantCurrent: true, wantStarted: &started},
+		{name: "current item changed", userUnit: testUserUnit(unit, 2, true, started), target: &changedCurrent,
+			wantStatus: UserUnitMigrationUpdated, wantCompleted: 2, wantCurrent: true, wantStarted: &now},
+		{name: "completed item changed", userUnit: testUserUnit(unit, 2, true, started), target: &changedFirst,
+			wantStatus: UserUnitMigrationRegressed, wantCompleted: 0, wantCurrent: true, wantStarted: &now},
+		{name: "completed item made optional", userUnit: testUserUnit(unit, 2, true, started), target: &optionalFirst,
+			wantStatus: UserUnitMigrationRegressed, wantCompleted: 0, wantCurrent: true, wantStarted: &now},
+		{name: "schedule shortened to completed items", userUnit: testUserUnit(unit, 2, true, started), target: &shortened,
+			wantStatus: UserUnitMigrationCompleted, wantCompleted: 2, wantCurrent: false},
+		{name: "finished unit changed before the end", userUnit: testUserUnit(unit, 3, false, started), target: &changedFirst,
+			wantStatus: UserUnitMigrationUpdated, wantCompleted: 3, wantCurrent: false},
+		{name: "finished unit extended", userUnit: testUserUnit(unit, 3, false, started), target: &appended,
+			wantStatus: UserUnitMigrationUpdated, wantCompleted: 4, wantCurrent: false},
+		{name: "finished unit shortened", userUnit: testUserUnit(unit, 3, false, started), target: &shortened,
+			wantStatus: UserUnitMigrationUpdated, wantCompleted: 2, wantCurrent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userUnit := tt.userUnit
+			before := userUnit.Completed
+			result := userUnit.MigrateTo(tt.target, 2, now)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", result.Status, tt.wantStatus)
+			}
+			if result.Completed != before {
+				t.Errorf("result completed = %d, want %d", result.Completed, before)
+			}
+			if result.MigratedCompleted != tt.wantCompleted || userUnit.Completed != tt.wantCompleted {
+				t.Errorf("completed = %d (result %d), want %d", userUnit.Completed, result.MigratedCompleted, tt.wantCompleted)
+			}
+			if userUnit.Current != tt.wantCurrent {
+				t.Errorf("current = %t, want %t", userUnit.Current, tt.wantCurrent)
+			}
+			if tt.target == nil {
+				if userUnit.CourseVersion != 1 {
+					t.Errorf("course version = %d, want unchanged 1", userUnit.CourseVersion)
+				}
+				return
+			}
+
+			if userUnit.CourseVersion != 2 {
+				t.Errorf("course version = %d, want 2", userUnit.CourseVersion)
+			}
+			if len(userUnit.UserSchedule) != len(tt.target.Schedule) {
+				t.Fatalf("user schedule length = %d, want %d", len(userUnit.UserSchedule), len(tt.target.Schedule))
+			}
+			for i := 0; i < userUnit.Completed && i < len(userUnit.UserSchedule); i++ {
+				if userUnit.Current && !userUnit.UserSchedule[i].IsComplete() {
+					t.Errorf("user schedule item %d is not complete", i)
+				}
+			}
+			if userUnit.Current {
+				userScheduleItem, scheduleItem, _, _ := userUnit.GetScheduleItem("", true)
+				if userScheduleItem == nil || scheduleItem == nil {
+					t.Fatalf("current schedule item %d is out of range", userUnit.Completed)
+				}
+				if tt.wantStarted != nil && (userScheduleItem.DateStarted == nil || !userScheduleItem.DateStarted.Equal(*tt.wantStarted)) {
+					t.Errorf("current date started = %v, want %v", userScheduleItem.DateStarted, *tt.wantStarted)
+				}
+			}
+		})
+	}
+}
@@ -49,11 +49,33 @@ const (
 	TypeScheduleItem logutils.MessageDataType = "schedule item"
 	//TypeTimezone timezone type
 	TypeTimezone logutils.MessageDataType = "timezone"
+	//TypeStreakHistory streak history type
+	TypeStreakHistory logutils.MessageDataType = "streak history"
+	//TypeStreakFreeze streak freeze type
+	TypeStreakFreeze logutils.MessageDataType = "streak freeze"
 
 	//UserTimezone indicates the user's timezone should be used
 	UserTimezone string = "user"
 	//UserContentCompleteKey is the key into user data to check for task completion
 	UserContentCompleteKey string = "complete"
+
+	//StreakHistoryDateFormat is the layout of the local dates used in streak histories
+	StreakHistoryDateFormat string = "2006-01-02"
+	//StreakDayCompleted indicates a required task was completed during the streak day
+	StreakDayCompleted string = "completed"
+	//StreakDayPauseUsed indicates a pause was used to keep the streak for the streak day
+	StreakDayPauseUsed string = "pause_used"
+	//StreakDayReset indicates the streak was reset at the end of the streak day
+	StreakDayReset string = "reset"
+	//StreakDayNoRequiredTask indicates no required task had to be completed during the streak day
+	StreakDayNoRequiredTask string = "no_required_task"
+	//StreakDayPending indicates the streak day is in progress and no required task has been completed yet
+	StreakDayPending string = "pending"
+
+	//ErrorStatusConflict is the status of errors caused by a concurrent update to the same user progress, which the client must resolve by refreshing
+	ErrorStatusConflict string = "conflict"
+	//ErrorStatusNotFound is the status of errors caused by requesting an item which does not exist by its ID
+	ErrorStatusNotFound string = "not-found"
 )
 
 // UserCourse represents a copy of a course that the user modifies as progress is made
@@ -72,11 +94,23 @@ type UserCourse struct {
 	PauseProgress  int         `json:"pause_progress"`
 	PauseUses      []time.Time `json:"pause_uses"` // timestamps when a pause is used for this course
 
+	StreakBeforeReset *int `json:"-"` // streak lost by the most recent reset, which is restored if the reset day is credited later
+
+	StreakFreezes []StreakFreeze `json:"streak_freezes"` // periods frozen in advance by spending pauses
+
+	LeaderboardName *string `json:"leaderboard_name"` // name shown on the course leaderboard if the user has opted in
+
 	LastCompleted    *time.Time           `json:"last_completed"`
 	LastResponded    *time.Time           `json:"last_responded"`
 	CompletedModules map[string]time.Time `json:"completed_modules"`
 
-	Course Course `json:"course"`
+	Course        Course `json:"course"`
+	CourseVersion int    `json:"course_version"` // published course version the user is on (0 if the user follows the unversioned course)
+
+	CohortKey   *string    `json:"cohort_key"`   // cohort the user was enrolled in by an admin
+	CohortStart *time.Time `json:"cohort_start"` // no module may be started before the cohort starts
+
+	Version int `json:"version"` // incremented on every progress update so that concurrent updates are detected
 
 	DateCreated   time.Time  `json:"date_created"`
 	DateUpdated   *time.Time `json:"date_updated"`
@@ -94,16 +128,7 @@ func (u *UserCourse) MostRecentStreakProcessTime(now *time.Time, snConfig Streak
 		now = &newNow
 	}
 
-	var loc *time.Location
-	var err error
-	if snConfig.TimezoneName == UserTimezone {
-		loc = time.FixedZone(u.Timezone.Name, u.Timezone.Offset)
-	} else {
-		loc, err = time.LoadLocation(snConfig.TimezoneName)
-		if err != nil {
-			loc = time.FixedZone(snConfig.TimezoneName, snConfig.TimezoneOffset)
-		}
-	}
+	loc := u.StreaksLocation(snConfig)
 	nowLocal := now.In(loc)
 	nowLocalSeconds := utils.SecondsInHour * nowLocal.Hour()
 
@@ -115,6 +140,22 @@ func (u *UserCourse) MostRecentStreakProcessTime(now *time.Time, snConfig Streak
 	return &mostRecent
 }
 
+// StreaksLocation gives the location in which daily streaks are processed for a user
+func (u *UserCourse) StreaksLocation(snConfig StreaksNotificationsConfig) *time.Location {
+	if u == nil {
+		return time.UTC
+	}
+
+	if snConfig.TimezoneName == UserTimezone {
+		return time.FixedZone(u.Timezone.Name, u.Timezone.Offset)
+	}
+	loc, err := time.LoadLocation(snConfig.TimezoneName)
+	if err != nil {
+		loc = time.FixedZone(snConfig.TimezoneName, snConfig.TimezoneOffset)
+	}
+	return loc
+}
+
 // CanIncrementStreak returns whether the last required task was completed before the most recent streak process
 func (u *UserCourse) CanIncrementStreak(lastStreakProcess *time.Time, now *time.Time, snConfig StreaksNotificationsConfig) bool {
 	if u == nil {
@@ -147,6 +188,79 @@ func (u *UserCourse) CanMakePauseProgress(lastStreakProcess *time.Time, now *tim
 	return u.LastResponded == nil || u.LastResponded.Before(*lastStreakProcess)
 }
 
+// CreditProcessedStreakDay credits the completion of a required task at moment to its streak day if the streak process ending the day has already run and used a pause or freeze
+// or reset the streak because the task was incomplete. It must be called after the streak is incremented for the completion and returns whether a processed day was credited
+func (u *UserCourse) CreditProcessedStreakDay(moment time.Time, snConfig StreaksNotificationsConfig) bool {
+	if u == nil {
+		return false
+	}
+
+	dayStart := u.MostRecentStreakProcessTime(&moment, snConfig)
+	if dayStart == nil {
+		return false
+	}
+	// pauses and resets are recorded at the streak process time ending the day they apply to
+	endsDay := func(processTime time.Time) bool {
+		processedMoment := processTime.Add(-time.Second)
+		processedDayStart := u.MostRecentStreakProcessTime(&processedMoment, snConfig)
+		return processedDayStart != nil && processedDayStart.Equal(*dayStart)
+	}
+
+	for i, pauseUse := range u.PauseUses {
+		if endsDay(pauseUse) {
+			// the pause or freeze already counted the day toward the streak
+			u.PauseUses = append(u.PauseUses[:i:i], u.PauseUses[i+1:]...)
+			u.Streak--
+			if !u.IsFrozen(moment, snConfig) {
+				u.Pauses++ // pauses spent on freezes in advance are not refunded
+			}
+			return true
+		}
+	}
+	for i, streakReset := range u.StreakResets {
+		if endsDay(streakReset) {
+			mostRecent := i == len(u.StreakResets)-1
+			u.StreakResets = append(u.StreakResets[:i:i], u.StreakResets[i+1:]...)
+			if mostRecent && u.StreakBeforeReset != nil {
+				// the streak continues from before the reset, so any restart since the reset is undone
+				u.Streak += *u.StreakBeforeReset
+				u.StreakBeforeReset = nil
+				restarts := make([]time.Time, 0, len(u.StreakRestarts))
+				for _, restart := range u.StreakRestarts {
+					if restart.Before(streakReset) {
+						restarts = append(restarts, restart)
+					}
+				}
+				u.StreakRestarts = restarts
+			} else {
+				// the streak has been reset again since, so the day does not count toward the current streak
+				u.Streak--
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// IsFrozen returns whether the streak day containing moment has been frozen in advance
+func (u *UserCourse) IsFrozen(moment time.Time, snConfig StreaksNotificationsConfig) bool {
+	if u == nil {
+		return false
+	}
+
+	dayStart := u.MostRecentStreakProcessTime(&moment, snConfig)
+	if dayStart == nil {
+		return false
+	}
+	loc := u.StreaksLocation(snConfig)
+	for _, freeze := range u.StreakFreezes {
+		if !dayStart.Before(freeze.Start) && dayStart.Before(freeze.End(loc)) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsComplete returns whether all required schedule items in the course have been completed
 func (u *UserCourse) IsComplete() bool {
 	if u == nil {
@@ -156,18 +270,211 @@ func (u *UserCourse) IsComplete() bool {
 	return len(u.CompletedModules) == len(u.Course.Modules)
 }
 
+// BuildStreakHistory gives the daily activity timeline of the user course from its creation until now based on the schedule items completed in userUnits
+func (u *UserCourse) BuildStreakHistory(userUnits []UserUnit, now time.Time, snConfig StreaksNotificationsConfig) *StreakHistory {
+	if u == nil {
+		return nil
+	}
+
+	loc := u.StreaksLocation(snConfig)
+	dayOf := func(moment time.Time) string {
+		return u.MostRecentStreakProcessTime(&moment, snConfig).In(loc).Format(StreakHistoryDateFormat)
+	}
+	// pauses and resets are recorded at the streak process time ending the day they apply to
+	processedDayOf := func(moment time.Time) string {
+		return dayOf(moment.Add(-time.Second))
+	}
+
+	completed := make(map[string]int)
+	for _, userUnit := range userUnits {
+		for i, item := range userUnit.UserSchedule {
+			if item.DateCompleted == nil || i >= len(userUnit.Unit.Schedule) || !userUnit.Unit.Schedule[i].IsRequired() {
+				continue
+			}
+			completed[dayOf(*item.DateCompleted)]++
+		}
+	}
+	if u.LastCompleted != nil {
+		// a schedule item completion date may be overwritten by later responses, but the last completion is always recorded here
+		lastCompletedDay := dayOf(*u.LastCompleted)
+		if completed[lastCompletedDay] == 0 {
+			completed[lastCompletedDay] = 1
+		}
+	}
+	pauseUsed := make(map[string]bool)
+	for _, pauseUse := range u.PauseUses {
+		pauseUsed[processedDayOf(pauseUse)] = true
+	}
+	reset := make(map[string]bool)
+	for _, streakReset := range u.StreakResets {
+		reset[processedDayOf(streakReset)] = true
+	}
+	restarted := make(map[string]bool)
+	for _, streakRestart := range u.StreakRestarts {
+		restarted[dayOf(streakRestart)] = true
+	}
+
+	// the timeline ends when the course is completed or dropped
+	end := now
+	if u.DateCompleted != nil && u.DateCompleted.Before(end) {
+		end = *u.DateCompleted
+	}
+	if u.DateDropped != nil && u.DateDropped.Before(end) {
+		end = *u.DateDropped
+	}
+	lastDay := dayOf(end)
+	today := dayOf(now)
+
+	history := StreakHistory{CourseKey: u.Course.Key, CurrentStreak: u.Streak, Days: make([]StreakHistoryDay, 0)}
+	firstStart := u.MostRecentStreakProcessTime(&u.DateCreated, snConfig).In(loc)
+	lastStart := u.MostRecentStreakProcessTime(&end, snConfig)
+	streak := 0
+	for start := firstStart; !start.After(*lastStart); start = start.AddDate(0, 0, 1) {
+		date := start.Format(StreakHistoryDateFormat)
+		day := StreakHistoryDay{Date: date, Start: start.UTC(), Completed: completed[date], Restarted: restarted[date]}
+		switch {
+		case day.Completed > 0:
+			day.Status = StreakDayCompleted
+			streak++
+		case pauseUsed[date]:
+			day.Status = StreakDayPauseUsed
+			streak++ // using a pause counts toward the streak
+		case reset[date]:
+			day.Status = StreakDayReset
+			streak = 0
+		case date == today:
+			day.Status = StreakDayPending
+		default:
+			day.Status = StreakDayNoRequiredTask
+		}
+		day.Streak = streak
+		if streak > history.LongestStreak {
+			history.LongestStreak = streak
+		}
+
+		history.Days = append(history.Days, day)
+		if date == lastDay {
+			break
+		}
+	}
+	if history.CurrentStreak > history.LongestStreak {
+		history.LongestStreak = history.CurrentStreak
+	}
+
+	return &history
+}
+
+// StreakFreeze represents a set of consecutive streak days frozen in advance by spending one pause per day
+type StreakFreeze struct {
+	Start time.Time `json:"start" bson:"start"` // streak process time starting the first frozen day
+	Days  int       `json:"days" bson:"days"`   // number of frozen days
+
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+}
+
+// End gives the streak process time ending the last frozen day
+func (f StreakFreeze) End(loc *time.Location) time.Time {
+	return f.Start.In(loc).AddDate(0, 0, f.Days).UTC()
+}
+
+// StreakHistory represents the daily activity timeline of a user in a course
+type StreakHistory struct {
+	CourseKey     string             `json:"course_key"`
+	CurrentStreak int                `json:"current_streak"`
+	LongestStreak int                `json:"longest_streak"`
+	Days          []StreakHistoryDay `json:"days"`
+}
+
+// StreakHistoryDay represents the activity of a user in a course during a single streak day (from one streak process to the next)
+type StreakHistoryDay struct {
+	Date      string    `json:"date"`      // local date on which the streak day starts
+	Start     time.Time `json:"start"`     // streak process time starting the streak day
+	Status    string    `json:"status"`    // completed, pause_used, reset, no_required_task, pending
+	Completed int       `json:"completed"` // number of required schedule items completed during the streak day
+	Restarted bool      `json:"restarted"` // whether the streak was restarted during the streak day
+	Streak    int       `json:"streak"`    // streak at the end of the streak day
+}
+
 // Course represents a custom-defined course (e.g. Essential Skills Coaching)
 type Course struct {
-	ID    string `json:"id"`
-	AppID string `json:"app_id"`
-	OrgID string `json:"org_id"`
+	ID    string `json:"id" bson:"_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+
+	Key     string   `json:"key" bson:"key"`
+	Name    string   `json:"name" bson:"name"`
+	Modules []Module `json:"modules" bson:"modules"`
+
+	Availability *CourseAvailability `json:"availability,omitempty" bson:"availability,omitempty"` // enrollment window and end of the course (always available if nil)
+	Visibility   *CourseVisibility   `json:"visibility,omitempty" bson:"visibility,omitempty"`     // groups and roles the course is restricted to (visible to all users if nil)
+
+	Localizations Localizations `json:"localizations,omitempty" bson:"localizations,omitempty"` // localized text by locale
+
+	DateCreated time.Time  `json:"-" bson:"date_created"`
+	DateUpdated *time.Time `json:"-" bson:"date_updated"`
+}
+
+// Validate checks that all modules and units of the course exist and that all unit schedules and module prerequisites are valid
+func (c *Course) Validate() error {
+	if c == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeCourse, nil)
+	}
+	if len(c.Modules) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, TypeModule, &logutils.FieldArgs{"course_key": c.Key})
+	}
+	err := c.Localizations.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, TypeLocalization, &logutils.FieldArgs{"key": c.Key}, err)
+	}
+
+	for i, module := range c.Modules {
+		if module.Key == "" {
+			return errors.ErrorData(logutils.StatusMissing, TypeModule, &logutils.FieldArgs{"course_key": c.Key, "index": i})
+		}
+		err = module.Localizations.Validate()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, TypeLocalization, &logutils.FieldArgs{"module_key": module.Key}, err)
+		}
+		for j, unit := range module.Units {
+			if unit.Key == "" {
+				return errors.ErrorData(logutils.StatusMissing, TypeUnit, &logutils.FieldArgs{"module_key": module.Key, "index": j})
+			}
+			err = c.Modules[i].Units[j].Validate(nil)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionValidate, TypeUnit, &logutils.FieldArgs{"module_key": module.Key, "key": unit.Key}, err)
+			}
+		}
+	}
+	return c.validatePrerequisites()
+}
 
-	Key     string   `json:"key"`
-	Name    string   `json:"name"`
-	Modules []Module `json:"modules"`
+// GetModule returns the module with the given key
+func (c *Course) GetModule(moduleKey string) *Module {
+	if c == nil {
+		return nil
+	}
 
-	DateCreated time.Time  `json:"-"`
-	DateUpdated *time.Time `json:"-"`
+	for i, module := range c.Modules {
+		if module.Key == moduleKey {
+			return &c.Modules[i]
+		}
+	}
+	return nil
+}
+
+// GetUnit returns the unit with unitKey in the module with moduleKey
+func (c *Course) GetUnit(moduleKey string, unitKey string) *Unit {
+	module := c.GetModule(moduleKey)
+	if module == nil {
+		return nil
+	}
+
+	for i, unit := range module.Units {
+		if unit.Key == unitKey {
+			return &module.Units[i]
+		}
+	}
+	return nil
 }
 
 // GetNextUnit returns the unit after the one specified by unitKey in the same module as the one specified by moduleKey
@@ -225,6 +532,11 @@ type CourseConfig struct {
 	InitialPauses       int `json:"initial_pauses" bson:"initial_pauses"`
 	MaxPauses           int `json:"max_pauses" bson:"max_pauses"`
 	PauseProgressReward int `json:"pause_progress_reward" bson:"pause_progress_reward"`
+	MaxFreezeLength     int `json:"max_freeze_length" bson:"max_freeze_length"` // maximum number of days in a single streak freeze (0 disables streak freezes)
+
+	LeaderboardActive bool `json:"leaderboard_active" bson:"leaderboard_active"` // whether users can see the course leaderboard
+
+	ResetStreaksOnRestart bool `json:"reset_streaks_on_restart" bson:"reset_streaks_on_restart"` // whether restarting the course also resets the streak, pauses and streak history
 
 	StreaksNotificationsConfig StreaksNotificationsConfig `json:"streaks_notifications_config" bson:"streaks_notifications_config"`
 
@@ -296,18 +608,23 @@ type NotificationParams map[string]string
 
 // Module represents an individual module of a Course (e.g. Conversational Skills)
 type Module struct {
-	ID    string `json:"id"`
-	AppID string `json:"app_id"`
-	OrgID string `json:"org_id"`
+	ID    string `json:"id" bson:"_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
 
-	Key   string `json:"key"`
-	Name  string `json:"name"`
-	Units []Unit `json:"units"`
+	Key   string `json:"key" bson:"key"`
+	Name  string `json:"name" bson:"name"`
+	Units []Unit `json:"units" bson:"units"`
 
-	Styles Styles `json:"styles"`
+	Prerequisites []ModulePrerequisite `json:"prerequisites" bson:"prerequisites,omitempty"` // all must be met before a user may start the module
+	Lock          *ModuleLock          `json:"lock,omitempty" bson:"-"`                      // set only when the module is returned as part of a user course
+
+	Localizations Localizations `json:"localizations,omitempty" bson:"localizations,omitempty"` // localized text by locale
+
+	Styles Styles `json:"styles" bson:"styles"`
 
-	DateCreated time.Time  `json:"-"`
-	DateUpdated *time.Time `json:"-"`
+	DateCreated time.Time  `json:"-" bson:"date_created"`
+	DateUpdated *time.Time `json:"-" bson:"date_updated"`
 }
 
 // UserUnit represents a copy of a unit that the user modifies as progress is made
@@ -320,10 +637,14 @@ type UserUnit struct {
 	ModuleKey string `json:"module_key"`
 	Unit      Unit   `json:"unit"`
 
+	CourseVersion int `json:"course_version"` // published course version the unit was taken from (0 if unversioned)
+
 	Completed    int                `json:"completed"` // number of schedule items the user has completed
 	Current      bool               `json:"current"`
 	UserSchedule []UserScheduleItem `json:"user_schedule"`
 
+	Version int `json:"version"` // incremented on every progress update so that concurrent updates are detected
+
 	DateCreated time.Time  `json:"date_created"`
 	DateUpdated *time.Time `json:"date_updated"`
 }
@@ -390,19 +711,21 @@ func (u *UserUnit) GetUserContentReferenceForKey(contentKey string) (*UserConten
 
 // Unit represents an individual unit of a Module (e.g. The Physical Side of Communication)
 type Unit struct {
-	ID    string `json:"id"`
-	AppID string `json:"app_id"`
-	OrgID string `json:"org_id"`
+	ID    string `json:"id" bson:"_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+
+	Key      string         `json:"key" bson:"key"`
+	Name     string         `json:"name" bson:"name"`
+	Contents []Content      `json:"content" bson:"contents"`
+	Schedule []ScheduleItem `json:"schedule" bson:"schedule"`
 
-	Key      string         `json:"key"`
-	Name     string         `json:"name"`
-	Contents []Content      `json:"content"`
-	Schedule []ScheduleItem `json:"schedule"`
+	Required int `json:"required" bson:"required"` // number of schedule items to complete = length of Schedule (may add required flags to each schedule item in future)
 
-	Required int `json:"required"` // number of schedule items to complete = length of Schedule (may add required flags to each schedule item in future)
+	Localizations Localizations `json:"localizations,omitempty" bson:"localizations,omitempty"` // localized text by locale
 
-	DateCreated time.Time  `json:"-"`
-	DateUpdated *time.Time `json:"-"`
+	DateCreated time.Time  `json:"-" bson:"date_created"`
+	DateUpdated *time.Time `json:"-" bson:"date_updated"`
 }
 
 // Validate checks the unit schedule to make sure it is valid
@@ -413,6 +736,10 @@ func (u *Unit) Validate(contentKeys []string) error {
 	if len(u.Schedule) == 0 {
 		return errors.ErrorData(logutils.StatusMissing, "unit schedule", &logutils.FieldArgs{"key": u.Key})
 	}
+	err := u.Localizations.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, TypeLocalization, &logutils.FieldArgs{"key": u.Key}, err)
+	}
 
 	if len(contentKeys) == 0 {
 		contentKeys = make([]string, 0)
@@ -499,8 +826,9 @@ type UserContent struct {
 	ModuleKey string `json:"module_key" bson:"module_key"`
 	UnitKey   string `json:"unit_key" bson:"unit_key"`
 
-	Content  Content                `json:"content" bson:"content"`
-	Response map[string]interface{} `json:"response" bson:"response"`
+	Content    Content                `json:"content" bson:"content"`
+	Response   map[string]interface{} `json:"response" bson:"response"`
+	Evaluation *UserEvaluation        `json:"evaluation,omitempty" bson:"evaluation,omitempty"` // scoring of the submitted answers (evaluation content only)
 
 	DateCreated time.Time  `json:"date_created" bson:"date_created"`
 	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
@@ -549,6 +877,11 @@ type Content struct {
 	Reference     Reference `json:"reference" bson:"reference"`
 	LinkedContent []string  `json:"linked_content" bson:"linked_content"`
 
+	Evaluation     *Evaluation            `json:"evaluation,omitempty" bson:"evaluation,omitempty"`           // questions and answer key (evaluation type only)
+	ResponseSchema map[string]interface{} `json:"response_schema,omitempty" bson:"response_schema,omitempty"` // JSON Schema user responses must conform to (any response is accepted if nil)
+
+	Localizations Localizations `json:"localizations,omitempty" bson:"localizations,omitempty"` // localized text by locale
+
 	Styles Styles `json:"styles" bson:"styles"`
 
 	DateCreated time.Time  `json:"-" bson:"date_created"`
@@ -576,9 +909,51 @@ func (c *Content) Equals(other *Content) bool {
 	if !utils.DeepEqual(c.Styles, other.Styles) {
 		return false
 	}
+	if !utils.DeepEqual(c.Evaluation, other.Evaluation) {
+		return false
+	}
+	if !utils.DeepEqual(c.ResponseSchema, other.ResponseSchema) {
+		return false
+	}
+	if !utils.DeepEqual(c.Localizations, other.Localizations) {
+		return false
+	}
 	return true
 }
 
+// Validate checks the data required by the content type
+func (c *Content) Validate() error {
+	if c == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeContent, nil)
+	}
+
+	err := c.Localizations.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, TypeLocalization, &logutils.FieldArgs{"key": c.Key}, err)
+	}
+	if c.Type == ContentTypeEvaluation && c.Evaluation != nil {
+		err = c.Evaluation.Validate()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, TypeEvaluation, &logutils.FieldArgs{"key": c.Key}, err)
+		}
+	}
+	if c.ResponseSchema != nil {
+		err = utils.ValidateJSONSchema(c.ResponseSchema)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, "response schema", &logutils.FieldArgs{"key": c.Key}, err)
+		}
+	}
+	return nil
+}
+
+// ValidateResponse checks that a user response conforms to the response schema if there is one
+func (c *Content) ValidateResponse(response map[string]interface{}) error {
+	if c == nil || c.ResponseSchema == nil {
+		return nil
+	}
+	return utils.ValidateJSON(c.ResponseSchema, response)
+}
+
 // UserResponse includes a user response to a task with timezone info
 type UserResponse struct {
 	Timezone                          // include user timezone info
@@ -0,0 +1,260 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeEvaluation evaluation type
+	TypeEvaluation logutils.MessageDataType = "evaluation"
+	//TypeUserEvaluation user evaluation type
+	TypeUserEvaluation logutils.MessageDataType = "user evaluation"
+
+	//ContentTypeEvaluation is the content type of evaluations which are scored by the server
+	ContentTypeEvaluation string = "evaluation"
+	//UserContentAnswersKey is the key into user data holding the answers submitted for an evaluation, mapped by question key
+	UserContentAnswersKey string = "answers"
+)
+
+// Evaluation represents the questions, answer key and pass threshold of an evaluation content item
+type Evaluation struct {
+	Questions     []EvaluationQuestion `json:"questions" bson:"questions"`
+	PassThreshold float64              `json:"pass_threshold" bson:"pass_threshold"`                 // fraction of the total points required to pass (0-1)
+	MaxAttempts   *int                 `json:"max_attempts,omitempty" bson:"max_attempts,omitempty"` // unlimited if nil
+}
+
+// EvaluationQuestion represents a single question of an evaluation
+type EvaluationQuestion struct {
+	Key     string   `json:"key" bson:"key"`
+	Text    string   `json:"text" bson:"text"`
+	Options []string `json:"options,omitempty" bson:"options,omitempty"`
+	Answers []string `json:"answers,omitempty" bson:"answers"` // accepted answers, all of which must be given if there are several (hidden from clients)
+	Points  int      `json:"points" bson:"points"`
+}
+
+// Validate checks that the evaluation can be scored
+func (e *Evaluation) Validate() error {
+	if e == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeEvaluation, nil)
+	}
+	if len(e.Questions) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, "evaluation questions", nil)
+	}
+	if e.PassThreshold < 0 || e.PassThreshold > 1 {
+		return errors.ErrorData(logutils.StatusInvalid, "evaluation pass threshold", &logutils.FieldArgs{"pass_threshold": e.PassThreshold})
+	}
+	if e.MaxAttempts != nil && *e.MaxAttempts < 1 {
+		return errors.ErrorData(logutils.StatusInvalid, "evaluation max attempts", &logutils.FieldArgs{"max_attempts": *e.MaxAttempts})
+	}
+
+	keys := make(map[string]bool)
+	for i, question := range e.Questions {
+		if question.Key == "" || keys[question.Key] {
+			return errors.ErrorData(logutils.StatusInvalid, "evaluation question key", &logutils.FieldArgs{"index": i, "key": question.Key})
+		}
+		keys[question.Key] = true
+		if len(question.Answers) == 0 {
+			return errors.ErrorData(logutils.StatusMissing, "evaluation question answers", &logutils.FieldArgs{"key": question.Key})
+		}
+		if question.Points < 1 {
+			return errors.ErrorData(logutils.StatusInvalid, "evaluation question points", &logutils.FieldArgs{"key": question.Key, "points": question.Points})
+		}
+	}
+	return nil
+}
+
+// HideAnswers removes the answer key so the evaluation can be sent to clients
+func (e *Evaluation) HideAnswers() {
+	if e == nil {
+		return
+	}
+
+	questions := make([]EvaluationQuestion, len(e.Questions))
+	for i, question := range e.Questions {
+		question.Answers = nil
+		questions[i] = question
+	}
+	e.Questions = questions
+}
+
+// Score scores the submitted answers, which map question keys to a single answer or a list of answers
+func (e *Evaluation) Score(answers interface{}) (int, int, bool) {
+	if e == nil {
+		return 0, 0, false
+	}
+
+	answersMap, _ := answers.(map[string]interface{})
+	score := 0
+	maxScore := 0
+	for _, question := range e.Questions {
+		maxScore += question.Points
+		if question.IsCorrect(answersMap[question.Key]) {
+			score += question.Points
+		}
+	}
+
+	passed := maxScore > 0 && float64(score) >= e.PassThreshold*float64(maxScore)
+	return score, maxScore, passed
+}
+
+// IsCorrect returns whether the given answer matches the accepted answers, ignoring case, surrounding whitespace and order
+func (q *EvaluationQuestion) IsCorrect(answer interface{}) bool {
+	if q == nil {
+		return false
+	}
+
+	given := make([]string, 0)
+	switch a := answer.(type) {
+	case string:
+		given = append(given, a)
+	case []interface{}:
+		for _, item := range a {
+			itemString, ok := item.(string)
+			if !ok {
+				return false
+			}
+			given = append(given, itemString)
+		}
+	default:
+		return false
+	}
+
+	return equalAnswers(q.Answers, given)
+}
+
+func equalAnswers(accepted []string, given []string) bool {
+	if len(accepted) != len(given) {
+		return false
+	}
+
+	normalize := func(values []string) []string {
+		normalized := make([]string, len(values))
+		for i, value := range values {
+			normalized[i] = strings.ToLower(strings.TrimSpace(value))
+		}
+		sort.Strings(normalized)
+		return normalized
+	}
+	acceptedNormalized := normalize(accepted)
+	givenNormalized := normalize(given)
+	for i := range acceptedNormalized {
+		if acceptedNormalized[i] != givenNormalized[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UserEvaluation represents the scoring of a user's submissions to an evaluation
+type UserEvaluation struct {
+	Attempts      int        `json:"attempts" bson:"attempts"`
+	Score         int        `json:"score" bson:"score"` // score of the latest attempt
+	MaxScore      int        `json:"max_score" bson:"max_score"`
+	BestScore     int        `json:"best_score" bson:"best_score"`
+	Passed        bool       `json:"passed" bson:"passed"` // whether any attempt passed
+	DateSubmitted *time.Time `json:"date_submitted" bson:"date_submitted"`
+}
+
+// SubmitEvaluation scores the answers in a response to an evaluation content item and sets the completion of the response to whether the user has passed
+//
+//	The user cannot complete an evaluation by sending {UserContentCompleteKey: true}. A response without answers is saved without counting as an attempt.
+//	previous is the user evaluation carried over from earlier responses to the same content item (may be nil)
+func SubmitEvaluation(content Content, response map[string]interface{}, previous *UserEvaluation, now time.Time) (*UserEvaluation, error) {
+	if content.Type != ContentTypeEvaluation || content.Evaluation == nil {
+		return previous, nil
+	}
+
+	var result UserEvaluation
+	if previous != nil {
+		result = *previous
+	}
+
+	answers, submitted := response[UserContentAnswersKey]
+	if submitted {
+		if content.Evaluation.MaxAttempts != nil && result.Attempts >= *content.Evaluation.MaxAttempts {
+			return nil, errors.ErrorData(logutils.StatusInvalid, "evaluation attempts", &logutils.FieldArgs{"content_key": content.Key, "attempts": result.Attempts,
+				"max_attempts": *content.Evaluation.MaxAttempts})
+		}
+
+		score, maxScore, passed := content.Evaluation.Score(answers)
+		result.Attempts++
+		result.Score = score
+		result.MaxScore = maxScore
+		if score > result.BestScore {
+			result.BestScore = score
+		}
+		result.Passed = result.Passed || passed
+		result.DateSubmitted = &now
+	}
+
+	response[UserContentCompleteKey] = result.Passed
+	if result.Attempts == 0 {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+// HideAnswers removes the evaluation answer key from the content so it can be sent to clients
+func (c *Content) HideAnswers() {
+	if c == nil || c.Evaluation == nil {
+		return
+	}
+
+	evaluation := *c.Evaluation
+	evaluation.HideAnswers()
+	c.Evaluation = &evaluation
+}
+
+// HideAnswers removes the evaluation answer keys from all unit contents so the unit can be sent to clients
+func (u *Unit) HideAnswers() {
+	if u == nil || u.Contents == nil {
+		return
+	}
+
+	contents := make([]Content, len(u.Contents))
+	for i, content := range u.Contents {
+		content.HideAnswers()
+		contents[i] = content
+	}
+	u.Contents = contents
+}
+
+// HideAnswers removes the evaluation answer keys from all contents in the course so the course can be sent to clients
+func (c *Course) HideAnswers() {
+	if c == nil || c.Modules == nil {
+		return
+	}
+
+	modules := make([]Module, len(c.Modules))
+	for i, module := range c.Modules {
+		if module.Units != nil {
+			units := make([]Unit, len(module.Units))
+			for j, unit := range module.Units {
+				unit.HideAnswers()
+				units[j] = unit
+			}
+			module.Units = units
+		}
+		modules[i] = module
+	}
+	c.Modules = modules
+}
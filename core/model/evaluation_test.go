@@ -0,0 +1,157 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// testEvaluation gives an evaluation worth 3 points with a single answer question and a question needing two answers
+func testEvaluation(passThreshold float64, maxAttempts *int) *Evaluation {
+	return &Evaluation{PassThreshold: passThreshold, MaxAttempts: maxAttempts, Questions: []EvaluationQuestion{
+		{Key: "capital", Text: "Capital of Illinois", Answers: []string{"Springfield"}, Points: 1},
+		{Key: "lakes", Text: "Two Great Lakes", Answers: []string{"Erie", "Huron"}, Points: 2},
+	}}
+}
+
+func TestEvaluationScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		evaluation   *Evaluation
+		answers      interface{}
+		wantScore    int
+		wantMaxScore int
+		wantPassed   bool
+	}{
+		{name: "nil evaluation", evaluation: nil, answers: map[string]interface{}{}, wantScore: 0, wantMaxScore: 0, wantPassed: false},
+		{name: "all correct", evaluation: testEvaluation(1, nil),
+			answers: map[string]interface{}{"capital": "Springfield", "lakes": []interface{}{"Erie", "Huron"}}, wantScore: 3, wantMaxScore: 3, wantPassed: true},
+		{name: "case, whitespace and order ignored", evaluation: testEvaluation(1, nil),
+			answers: map[string]interface{}{"capital": "  springfield ", "lakes": []interface{}{"HURON", "erie"}}, wantScore: 3, wantMaxScore: 3, wantPassed: true},
+		{name: "partial answer list", evaluation: testEvaluation(0.5, nil),
+			answers: map[string]interface{}{"capital": "Springfield", "lakes": []interface{}{"Erie"}}, wantScore: 1, wantMaxScore: 3, wantPassed: false},
+		{name: "extra answer in list", evaluation: testEvaluation(0.5, nil),
+			answers: map[string]interface{}{"lakes": []interface{}{"Erie", "Huron", "Ontario"}}, wantScore: 0, wantMaxScore: 3, wantPassed: false},
+		{name: "non-string answer in list", evaluation: testEvaluation(0.5, nil),
+			answers: map[string]interface{}{"lakes": []interface{}{"Erie", 2}}, wantScore: 0, wantMaxScore: 3, wantPassed: false},
+		{name: "wrong answer type", evaluation: testEvaluation(0.5, nil),
+			answers: map[string]interface{}{"capital": 1, "lakes": []interface{}{"Erie", "Huron"}}, wantScore: 2, wantMaxScore: 3, wantPassed: true},
+		{name: "exactly at the threshold", evaluation: testEvaluation(2.0/3.0, nil),
+			answers: map[string]interface{}{"lakes": []interface{}{"Erie", "Huron"}}, wantScore: 2, wantMaxScore: 3, wantPassed: true},
+		{name: "below the threshold", evaluation: testEvaluation(0.7, nil),
+			answers: map[string]interface{}{"lakes": []interface{}{"Erie", "Huron"}}, wantScore: 2, wantMaxScore: 3, wantPassed: false},
+		{name: "zero threshold passes without correct answers", evaluation: testEvaluation(0, nil),
+			answers: map[string]interface{}{}, wantScore: 0, wantMaxScore: 3, wantPassed: true},
+		{name: "answers not a map", evaluation: testEvaluation(0.5, nil), answers: []interface{}{"Springfield"}, wantScore: 0, wantMaxScore: 3, wantPassed: false},
+		{name: "no answers", evaluation: testEvaluation(0.5, nil), answers: nil, wantScore: 0, wantMaxScore: 3, wantPassed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, maxScore, passed := tt.evaluation.Score(tt.answers)
+			if score != tt.wantScore || maxScore != tt.wantMaxScore || passed != tt.wantPassed {
+				t.Errorf("Score() = (%d, %d, %t), want (%d, %d, %t)", score, maxScore, passed, tt.wantScore, tt.wantMaxScore, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestSubmitEvaluation(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	two := 2
+	correct := map[string]interface{}{"capital": "Springfield", "lakes": []interface{}{"Erie", "Huron"}}
+	wrong := map[string]interface{}{"capital": "Chicago"}
+
+	tests := []struct {
+		name     string
+		content  Content
+		response map[string]interface{}
+		previous *UserEvaluation
+
+		want         *UserEvaluation
+		wantErr      bool
+		wantComplete *bool // value of the complete key in the response (not checked if nil)
+	}{
+		{name: "not an evaluation", content: Content{Key: "c", Type: "assignment", Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentCompleteKey: true}, previous: nil, want: nil},
+		{name: "evaluation type without evaluation", content: Content{Key: "c", Type: ContentTypeEvaluation},
+			response: map[string]interface{}{}, previous: &UserEvaluation{Attempts: 1}, want: &UserEvaluation{Attempts: 1}},
+		{name: "first attempt passes", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentAnswersKey: correct},
+			want:     &UserEvaluation{Attempts: 1, Score: 3, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &now}, wantComplete: boolPointer(true)},
+		{name: "first attempt fails", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentAnswersKey: wrong},
+			want:     &UserEvaluation{Attempts: 1, Score: 0, MaxScore: 3, BestScore: 0, Passed: false, DateSubmitted: &now}, wantComplete: boolPointer(false)},
+		{name: "complete key cannot be set by the user", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentAnswersKey: wrong, UserContentCompleteKey: true},
+			want:     &UserEvaluation{Attempts: 1, Score: 0, MaxScore: 3, BestScore: 0, Passed: false, DateSubmitted: &now}, wantComplete: boolPointer(false)},
+		{name: "no answers without previous attempts", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentCompleteKey: true}, want: nil, wantComplete: boolPointer(false)},
+		{name: "no answers keeps previous attempts", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, &two)},
+			response: map[string]interface{}{}, previous: &UserEvaluation{Attempts: 2, Score: 3, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &earlier},
+			want: &UserEvaluation{Attempts: 2, Score: 3, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &earlier}, wantComplete: boolPointer(true)},
+		{name: "failed retry keeps pass and best score", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, nil)},
+			response: map[string]interface{}{UserContentAnswersKey: wrong}, previous: &UserEvaluation{Attempts: 1, Score: 3, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &earlier},
+			want: &UserEvaluation{Attempts: 2, Score: 0, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &now}, wantComplete: boolPointer(true)},
+		{name: "last allowed attempt", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, &two)},
+			response: map[string]interface{}{UserContentAnswersKey: correct}, previous: &UserEvaluation{Attempts: 1, MaxScore: 3, DateSubmitted: &earlier},
+			want: &UserEvaluation{Attempts: 2, Score: 3, MaxScore: 3, BestScore: 3, Passed: true, DateSubmitted: &now}, wantComplete: boolPointer(true)},
+		{name: "attempts exhausted", content: Content{Key: "c", Type: ContentTypeEvaluation, Evaluation: testEvaluation(1, &two)},
+			response: map[string]interface{}{UserContentAnswersKey: correct}, previous: &UserEvaluation{Attempts: 2, MaxScore: 3, DateSubmitted: &earlier},
+			wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var previous *UserEvaluation
+			if tt.previous != nil {
+				copied := *tt.previous
+				previous = &copied
+			}
+			got, err := SubmitEvaluation(tt.content, tt.response, previous, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !equalUserEvaluations(got, tt.want) {
+				t.Errorf("SubmitEvaluation() = %+v, want %+v", got, tt.want)
+			}
+			if previous != nil && tt.previous != nil && *previous != *tt.previous {
+				t.Errorf("previous user evaluation changed to %+v", *previous)
+			}
+			if tt.wantComplete != nil && tt.response[UserContentCompleteKey] != *tt.wantComplete {
+				t.Errorf("response complete = %v, want %t", tt.response[UserContentCompleteKey], *tt.wantComplete)
+			}
+		})
+	}
+}
+
+func equalUserEvaluations(a *UserEvaluation, b *UserEvaluation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if (a.DateSubmitted == nil) != (b.DateSubmitted == nil) || (a.DateSubmitted != nil && !a.DateSubmitted.Equal(*b.DateSubmitted)) {
+		return false
+	}
+	return a.Attempts == b.Attempts && a.Score == b.Score && a.MaxScore == b.MaxScore && a.BestScore == b.BestScore && a.Passed == b.Passed
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
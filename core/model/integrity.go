@@ -0,0 +1,240 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"lms/utils"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeIntegrityReport integrity report type
+	TypeIntegrityReport logutils.MessageDataType = "integrity report"
+	//TypeItemReferences item references type
+	TypeItemReferences logutils.MessageDataType = "item references"
+
+	//IntegrityMissingReference indicates an item refers to a key which does not exist
+	IntegrityMissingReference string = "missing_reference"
+	//IntegrityScheduleMissingContent indicates a unit schedule item refers to a content key which is not in the unit
+	IntegrityScheduleMissingContent string = "schedule_missing_content"
+	//IntegrityEmptyScheduleItem indicates a unit schedule item has no content
+	IntegrityEmptyScheduleItem string = "empty_schedule_item"
+	//IntegrityEmptySchedule indicates a unit has no schedule items
+	IntegrityEmptySchedule string = "empty_schedule"
+	//IntegrityRequiredMismatch indicates the number of required schedule items of a unit does not match its schedule
+	IntegrityRequiredMismatch string = "required_mismatch"
+	//IntegrityMissingUnit indicates a user unit refers to a unit which does not exist
+	IntegrityMissingUnit string = "missing_unit"
+	//IntegritySnapshotMismatch indicates the unit copied into a user unit differs from the unit definition
+	IntegritySnapshotMismatch string = "snapshot_mismatch"
+)
+
+// ItemReferences represents the keys a stored course, module, unit or content item refers to
+type ItemReferences struct {
+	Key      string         `bson:"key"`
+	Keys     []string       `bson:"keys"`     // module keys of a course, unit keys of a module, content keys of a unit, or linked content keys of a content item
+	Schedule []ScheduleItem `bson:"schedule"` // units only
+	Required int            `bson:"required"` // units only
+}
+
+// CustomReferences represents the references between all courses, modules, units and contents of an app/org
+type CustomReferences struct {
+	Courses  []ItemReferences
+	Modules  []ItemReferences
+	Units    []ItemReferences
+	Contents []ItemReferences
+}
+
+// IntegrityReport represents the consistency problems found in the custom courses of an app/org
+type IntegrityReport struct {
+	Checked  map[string]int   `json:"checked"`  // number of items checked by type
+	Issues   []IntegrityIssue `json:"issues"`   // problems found before any repair
+	Repaired int              `json:"repaired"` // number of issues repaired
+}
+
+// IntegrityIssue represents a single consistency problem
+type IntegrityIssue struct {
+	Type       string `json:"type"`                // course, module, unit, content, user_unit
+	Key        string `json:"key"`                 // item key, or user unit ID
+	Problem    string `json:"problem"`             // missing_reference, schedule_missing_content, empty_schedule_item, empty_schedule, required_mismatch, missing_unit, snapshot_mismatch
+	Reference  string `json:"reference,omitempty"` // dangling key
+	Repairable bool   `json:"repairable"`
+}
+
+// Check finds dangling keys, invalid schedules and mismatched required counts and returns the issues with the repaired references of the items which have issues
+//
+//	Repairs remove dangling keys, remove schedule items left without content and reset the required count. Units without a schedule cannot be repaired.
+func (r CustomReferences) Check() ([]IntegrityIssue, CustomReferences) {
+	issues := make([]IntegrityIssue, 0)
+	var repaired CustomReferences
+
+	checkKeys := func(itemType string, items []ItemReferences, existing []ItemReferences) []ItemReferences {
+		exists := referenceKeys(existing)
+		repairedItems := make([]ItemReferences, 0)
+		for _, item := range items {
+			keys := make([]string, 0, len(item.Keys))
+			for _, key := range item.Keys {
+				if exists[key] {
+					keys = append(keys, key)
+					continue
+				}
+				issues = append(issues, IntegrityIssue{Type: itemType, Key: item.Key, Problem: IntegrityMissingReference, Reference: key, Repairable: true})
+			}
+			if len(keys) != len(item.Keys) {
+				item.Keys = keys
+				repairedItems = append(repairedItems, item)
+			}
+		}
+		return repairedItems
+	}
+	repaired.Courses = checkKeys("course", r.Courses, r.Modules)
+	repaired.Modules = checkKeys("module", r.Modules, r.Units)
+	repaired.Contents = checkKeys("content", r.Contents, r.Contents)
+
+	contents := referenceKeys(r.Contents)
+	repaired.Units = make([]ItemReferences, 0)
+	for _, unit := range r.Units {
+		changed := false
+		keys := make([]string, 0, len(unit.Keys))
+		for _, key := range unit.Keys {
+			if contents[key] {
+				keys = append(keys, key)
+				continue
+			}
+			issues = append(issues, IntegrityIssue{Type: "unit", Key: unit.Key, Problem: IntegrityMissingReference, Reference: key, Repairable: true})
+			changed = true
+		}
+		unitContents := make(map[string]bool)
+		for _, key := range keys {
+			unitContents[key] = true
+		}
+
+		if len(unit.Schedule) == 0 {
+			issues = append(issues, IntegrityIssue{Type: "unit", Key: unit.Key, Problem: IntegrityEmptySchedule, Repairable: false})
+		}
+		schedule := make([]ScheduleItem, 0, len(unit.Schedule))
+		for _, item := range unit.Schedule {
+			itemKeys := make([]string, 0, len(item.ContentKeys))
+			for _, key := range item.ContentKeys {
+				if unitContents[key] {
+					itemKeys = append(itemKeys, key)
+					continue
+				}
+				issues = append(issues, IntegrityIssue{Type: "unit", Key: unit.Key, Problem: IntegrityScheduleMissingContent, Reference: key, Repairable: true})
+				changed = true
+			}
+			if len(itemKeys) == 0 {
+				issues = append(issues, IntegrityIssue{Type: "unit", Key: unit.Key, Problem: IntegrityEmptyScheduleItem, Reference: item.Name, Repairable: len(unit.Schedule) > 1})
+				if len(unit.Schedule) > 1 {
+					changed = true
+				} else {
+					schedule = append(schedule, item)
+				}
+				continue
+			}
+			item.ContentKeys = itemKeys
+			schedule = append(schedule, item)
+		}
+
+		if unit.Required != len(unit.Schedule) {
+			issues = append(issues, IntegrityIssue{Type: "unit", Key: unit.Key, Problem: IntegrityRequiredMismatch, Repairable: true})
+		}
+		if unit.Required != len(schedule) {
+			changed = true
+		}
+
+		if changed {
+			unit.Keys = keys
+			unit.Schedule = schedule
+			unit.Required = len(schedule)
+			repaired.Units = append(repaired.Units, unit)
+		}
+	}
+
+	return issues, repaired
+}
+
+func referenceKeys(items []ItemReferences) map[string]bool {
+	keys := make(map[string]bool, len(items))
+	for _, item := range items {
+		keys[item.Key] = true
+	}
+	return keys
+}
+
+// MatchesUnit returns whether the unit copied into the user unit and the user schedule match the unit definition
+func (u *UserUnit) MatchesUnit(unit Unit) bool {
+	if u == nil {
+		return false
+	}
+
+	if !utils.DeepEqual(u.Unit.Schedule, unit.Schedule) || u.Unit.Required != unit.Required || !utils.Equal(contentKeys(u.Unit.Contents), contentKeys(unit.Contents), false) {
+		return false
+	}
+	if len(u.UserSchedule) != len(unit.Schedule) {
+		return false
+	}
+	for i, item := range u.UserSchedule {
+		if !userScheduleItemMatches(item, unit.Schedule[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncToUnit replaces the unit copied into the user unit with the unit definition, keeping the user schedule items which still match
+//
+//	Returns false without changing the user unit if the user would complete their current unit or reopen a finished unit, which requires a course migration instead
+func (u *UserUnit) SyncToUnit(unit Unit) bool {
+	if u == nil {
+		return false
+	}
+
+	userSchedule := unit.CreateUserSchedule()
+	kept := make([]bool, len(userSchedule))
+	for i := range userSchedule {
+		if i < len(u.UserSchedule) && userScheduleItemMatches(u.UserSchedule[i], unit.Schedule[i]) {
+			userSchedule[i] = u.UserSchedule[i]
+			kept[i] = true
+		}
+	}
+
+	completed := 0
+	for completed < u.Completed && completed < len(kept) && kept[completed] {
+		completed++
+	}
+	finished := !u.Current && u.Completed >= u.Unit.Required
+	if (u.Current && completed >= len(unit.Schedule)) || (finished && completed < len(unit.Schedule)) {
+		return false
+	}
+
+	u.Unit = unit
+	u.Completed = completed
+	u.UserSchedule = userSchedule
+	return true
+}
+
+func userScheduleItemMatches(userItem UserScheduleItem, item ScheduleItem) bool {
+	if len(userItem.UserContent) != len(item.ContentKeys) {
+		return false
+	}
+	for i, reference := range userItem.UserContent {
+		if reference.ContentKey != item.ContentKeys[i] {
+			return false
+		}
+	}
+	return true
+}
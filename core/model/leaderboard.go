@@ -0,0 +1,79 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeLeaderboard leaderboard type
+	TypeLeaderboard logutils.MessageDataType = "leaderboard"
+
+	//LeaderboardMetricStreak ranks users by current streak
+	LeaderboardMetricStreak string = "streak"
+	//LeaderboardMetricScheduleItems ranks users by number of completed schedule items
+	LeaderboardMetricScheduleItems string = "schedule_items"
+	//LeaderboardMetricModules ranks users by number of completed modules
+	LeaderboardMetricModules string = "modules"
+)
+
+// Leaderboard represents the ranking of users enrolled in a course by a metric
+type Leaderboard struct {
+	CourseKey string             `json:"course_key"`
+	Metric    string             `json:"metric"` // streak, schedule_items, modules
+	Total     int                `json:"total"`  // number of ranked users
+	Entries   []LeaderboardEntry `json:"entries"`
+	Self      *LeaderboardEntry  `json:"self"` // entry of the requesting user if enrolled
+}
+
+// LeaderboardEntry represents a single ranked user in a leaderboard
+type LeaderboardEntry struct {
+	UserID string  `json:"-" bson:"user_id"`
+	Name   *string `json:"-" bson:"leaderboard_name"` // set only if the user has opted in to show their name
+
+	Rank        int    `json:"rank" bson:"-"`
+	DisplayName string `json:"display_name" bson:"-"`
+	Value       int    `json:"value" bson:"value"`
+	IsSelf      bool   `json:"is_self" bson:"-"`
+}
+
+// SetDisplayName sets the display name to the user name if they have opted in, otherwise to a stable pseudonym for the course
+//
+//	The pseudonym is keyed by a service secret, so it cannot be traced back to a user ID without the secret
+func (e *LeaderboardEntry) SetDisplayName(courseKey string, key []byte) {
+	if e == nil {
+		return
+	}
+
+	if e.Name != nil && *e.Name != "" {
+		e.DisplayName = *e.Name
+		return
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(courseKey))
+	mac.Write([]byte{0}) // separates the course key from the user ID
+	mac.Write([]byte(e.UserID))
+	e.DisplayName = fmt.Sprintf("Learner %X", mac.Sum(nil)[:5])
+}
+
+// IsValidLeaderboardMetric returns whether metric is a supported leaderboard metric
+func IsValidLeaderboardMetric(metric string) bool {
+	return metric == LeaderboardMetricStreak || metric == LeaderboardMetricScheduleItems || metric == LeaderboardMetricModules
+}
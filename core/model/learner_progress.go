@@ -0,0 +1,66 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeLearnerProgress learner progress type
+	TypeLearnerProgress logutils.MessageDataType = "learner progress"
+	//TypeLearnerDetails learner details type
+	TypeLearnerDetails logutils.MessageDataType = "learner details"
+)
+
+// LearnerProgressFilter represents the conditions the users in a course progress list must meet
+type LearnerProgressFilter struct {
+	CohortKey        *string
+	InactiveSince    *time.Time // users who have not responded since (users who never responded are compared by enrollment date)
+	StreakResetSince *time.Time // users whose streak was reset since
+	Completed        *bool
+	Dropped          *bool
+}
+
+// LearnerProgress represents the state of a user enrolled in a course as seen by coaches and admins
+type LearnerProgress struct {
+	UserID    string  `json:"user_id" bson:"user_id"`
+	CohortKey *string `json:"cohort_key" bson:"cohort_key"`
+
+	Streak           int         `json:"streak" bson:"streak"`
+	StreakResets     []time.Time `json:"streak_resets" bson:"streak_resets"`
+	Pauses           int         `json:"pauses" bson:"pauses"`
+	CompletedModules int         `json:"completed_modules" bson:"completed_modules"` // number of completed modules
+
+	CurrentModuleKey *string `json:"current_module_key" bson:"current_module_key"` // module of the most recently updated current unit
+	CurrentUnitKey   *string `json:"current_unit_key" bson:"current_unit_key"`
+
+	LastCompleted *time.Time `json:"last_completed" bson:"last_completed"`
+	LastResponded *time.Time `json:"last_responded" bson:"last_responded"`
+	DateCreated   time.Time  `json:"date_created" bson:"date_created"`
+	DateCompleted *time.Time `json:"date_completed" bson:"date_completed"`
+	DateDropped   *time.Time `json:"date_dropped" bson:"date_dropped"`
+}
+
+// LearnerDetails represents the full progress of a single user in a course with their units and responses
+type LearnerDetails struct {
+	UserCourse   UserCourse    `json:"user_course"`
+	UserUnits    []UserUnit    `json:"user_units"`
+	UserContents []UserContent `json:"user_contents"` // most recent first
+
+	ProgressArchives []ProgressArchive `json:"progress_archives"` // previous attempts kept when progress was restarted, most recent first
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"golang.org/x/text/language"
+)
+
+const (
+	//TypeLocalization localization type
+	TypeLocalization logutils.MessageDataType = "localization"
+)
+
+// Localization represents the text of a course, module, unit or content item in a locale other than the default
+type Localization struct {
+	Name    string                 `json:"name,omitempty" bson:"name,omitempty"`
+	Details string                 `json:"details,omitempty" bson:"details,omitempty"` // content only
+	Strings map[string]interface{} `json:"strings,omitempty" bson:"strings,omitempty"` // replace the matching Styles.Strings entries (modules and content only)
+}
+
+// Localizations maps locales (BCP 47 language tags such as "es" or "zh-Hant") to localized text
+type Localizations map[string]Localization
+
+// Validate checks that all locales are valid language tags
+func (l Localizations) Validate() error {
+	for locale := range l {
+		if _, err := language.Parse(locale); err != nil {
+			return errors.WrapErrorData(logutils.StatusInvalid, TypeLocalization, &logutils.FieldArgs{"locale": locale}, err)
+		}
+	}
+	return nil
+}
+
+// SearchText returns the localized names, details and strings in all locales so they can be indexed for text search
+func (l Localizations) SearchText() []string {
+	if len(l) == 0 {
+		return nil
+	}
+
+	text := make([]string, 0)
+	for _, localization := range l {
+		for _, value := range []string{localization.Name, localization.Details} {
+			if value != "" {
+				text = append(text, value)
+			}
+		}
+		text = appendStrings(text, localization.Strings)
+	}
+	return text
+}
+
+// appendStrings appends all non-empty strings nested in value to text
+func appendStrings(text []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			text = append(text, v)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			text = appendStrings(text, item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			text = appendStrings(text, item)
+		}
+	}
+	return text
+}
+
+// resolve returns the localization best matching the preferred locales, or nil if the default text should be used
+func (l Localizations) resolve(preferred LocalePreferences) *Localization {
+	if len(l) == 0 || len(preferred.preferred) == 0 {
+		return nil
+	}
+
+	// the default text is listed first so that it is used when it matches as well as any localization, or when no locale matches
+	locales := []string{""}
+	supported := []language.Tag{preferred.defaultLocale}
+	for locale := range l {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		locales = append(locales, locale)
+		supported = append(supported, tag)
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred.preferred...)
+	if index == 0 || confidence == language.No {
+		return nil
+	}
+	localization := l[locales[index]]
+	return &localization
+}
+
+// localizeStyles returns a copy of styles with the localized strings applied
+func (l *Localization) localizeStyles(styles Styles) Styles {
+	if l == nil || len(l.Strings) == 0 {
+		return styles
+	}
+
+	localizedStrings := make(map[string]interface{}, len(styles.Strings)+len(l.Strings))
+	for key, value := range styles.Strings {
+		localizedStrings[key] = value
+	}
+	for key, value := range l.Strings {
+		localizedStrings[key] = value
+	}
+	styles.Strings = localizedStrings
+	return styles
+}
+
+// LocalePreferences represents the locales a user prefers and the locale of the default text
+type LocalePreferences struct {
+	defaultLocale language.Tag
+	preferred     []language.Tag // most preferred first
+}
+
+// NewLocalePreferences creates locale preferences from a locale chosen by the user, followed by the locales in an Accept-Language header
+//
+//	defaultLocale is the locale the default (unlocalized) text is written in
+func NewLocalePreferences(defaultLocale string, locale *string, acceptLanguage *string) LocalePreferences {
+	defaultTag, err := language.Parse(defaultLocale)
+	if err != nil {
+		defaultTag = language.Und
+	}
+
+	preferences := LocalePreferences{defaultLocale: defaultTag, preferred: make([]language.Tag, 0)}
+	if locale != nil && strings.TrimSpace(*locale) != "" {
+		if tag, err := language.Parse(strings.TrimSpace(*locale)); err == nil {
+			preferences.preferred = append(preferences.preferred, tag)
+		}
+	}
+	if acceptLanguage != nil {
+		// invalid entries are skipped rather than rejecting the request
+		if tags, _, err := language.ParseAcceptLanguage(*acceptLanguage); err == nil {
+			preferences.preferred = append(preferences.preferred, tags...)
+		}
+	}
+	return preferences
+}
+
+// Localize replaces the course text and the text of all its modules, units and content with the variants best matching the preferred locales
+//
+//	Localizations are removed so that only the resolved text is returned
+func (c *Course) Localize(preferred LocalePreferences) {
+	if c == nil {
+		return
+	}
+
+	if localization := c.Localizations.resolve(preferred); localization != nil && localization.Name != "" {
+		c.Name = localization.Name
+	}
+	c.Localizations = nil
+	for i := range c.Modules {
+		c.Modules[i].Localize(preferred)
+	}
+}
+
+// Localize replaces the module text and the text of all its units and content with the variants best matching the preferred locales
+func (m *Module) Localize(preferred LocalePreferences) {
+	if m == nil {
+		return
+	}
+
+	localization := m.Localizations.resolve(preferred)
+	if localization != nil && localization.Name != "" {
+		m.Name = localization.Name
+	}
+	m.Styles = localization.localizeStyles(m.Styles)
+	m.Localizations = nil
+	for i := range m.Units {
+		m.Units[i].Localize(preferred)
+	}
+}
+
+// Localize replaces the unit text and the text of all its content with the variants best matching the preferred locales
+func (u *Unit) Localize(preferred LocalePreferences) {
+	if u == nil {
+		return
+	}
+
+	if localization := u.Localizations.resolve(preferred); localization != nil && localization.Name != "" {
+		u.Name = localization.Name
+	}
+	u.Localizations = nil
+	for i := range u.Contents {
+		u.Contents[i].Localize(preferred)
+	}
+}
+
+// Localize replaces the content text with the variant best matching the preferred locales
+func (c *Content) Localize(preferred LocalePreferences) {
+	if c == nil {
+		return
+	}
+
+	localization := c.Localizations.resolve(preferred)
+	if localization != nil {
+		if localization.Name != "" {
+			c.Name = localization.Name
+		}
+		if localization.Details != "" {
+			c.Details = localization.Details
+		}
+	}
+	c.Styles = localization.localizeStyles(c.Styles)
+	c.Localizations = nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeModulePrerequisite module prerequisite type
+	TypeModulePrerequisite logutils.MessageDataType = "module prerequisite"
+
+	//ModulePrerequisiteModule is met when the module with the module key is completed
+	ModulePrerequisiteModule string = "module"
+	//ModulePrerequisiteDate is met once the date has passed
+	ModulePrerequisiteDate string = "date"
+	//ModulePrerequisiteEnrollmentDays is met once the number of days have passed since the user enrolled in the course
+	ModulePrerequisiteEnrollmentDays string = "enrollment_days"
+)
+
+// ModulePrerequisite represents a condition which must be met before a user may start a module
+type ModulePrerequisite struct {
+	Type      string     `json:"type" bson:"type"`                                 // module, date, enrollment_days
+	ModuleKey string     `json:"module_key,omitempty" bson:"module_key,omitempty"` // module to complete (module type only)
+	Date      *time.Time `json:"date,omitempty" bson:"date,omitempty"`             // date the module opens (date type only)
+	Days      int        `json:"days,omitempty" bson:"days,omitempty"`             // days after enrollment the module opens (enrollment_days type only)
+}
+
+// Validate checks the prerequisite type and its requirements
+func (p *ModulePrerequisite) Validate() error {
+	if p == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeModulePrerequisite, nil)
+	}
+
+	switch p.Type {
+	case ModulePrerequisiteModule:
+		if p.ModuleKey == "" {
+			return errors.ErrorData(logutils.StatusMissing, "prerequisite module key", nil)
+		}
+	case ModulePrerequisiteDate:
+		if p.Date == nil {
+			return errors.ErrorData(logutils.StatusMissing, "prerequisite date", nil)
+		}
+	case ModulePrerequisiteEnrollmentDays:
+		if p.Days < 1 {
+			return errors.ErrorData(logutils.StatusInvalid, "prerequisite days", &logutils.FieldArgs{"days": p.Days})
+		}
+	default:
+		return errors.ErrorData(logutils.StatusInvalid, "prerequisite type", &logutils.FieldArgs{"type": p.Type})
+	}
+	return nil
+}
+
+// ModuleLock represents whether a user may start a module and why not
+type ModuleLock struct {
+	Locked     bool                 `json:"locked"`
+	Reason     string               `json:"reason,omitempty"`      // description of the first unmet prerequisite
+	Unmet      []ModulePrerequisite `json:"unmet"`                 // prerequisites the user has not met yet
+	UnlockDate *time.Time           `json:"unlock_date,omitempty"` // when the module unlocks if only date based prerequisites are unmet
+}
+
+// GetModuleLock evaluates the prerequisites of a module in the course for the user at the given time
+func (u *UserCourse) GetModuleLock(module Module, now time.Time) ModuleLock {
+	lock := ModuleLock{Unmet: make([]ModulePrerequisite, 0)}
+	if u == nil {
+		return lock
+	}
+
+	// members of a cohort may not start any module before the cohort starts
+	prerequisites := module.Prerequisites
+	if u.CohortStart != nil {
+		prerequisites = append([]ModulePrerequisite{{Type: ModulePrerequisiteDate, Date: u.CohortStart}}, module.Prerequisites...)
+	}
+
+	dateOnly := true
+	for _, prerequisite := range prerequisites {
+		var unlockDate *time.Time
+		reason := ""
+		switch prerequisite.Type {
+		case ModulePrerequisiteModule:
+			if _, completed := u.CompletedModules[prerequisite.ModuleKey]; !completed {
+				name := prerequisite.ModuleKey
+				if required := u.Course.GetModule(prerequisite.ModuleKey); required != nil && required.Name != "" {
+					name = required.Name
+				}
+				reason = fmt.Sprintf("complete %s", name)
+			}
+		case ModulePrerequisiteDate:
+			if prerequisite.Date != nil && now.Before(*prerequisite.Date) {
+				unlockDate = prerequisite.Date
+				reason = fmt.Sprintf("available on %s", prerequisite.Date.UTC().Format(time.RFC3339))
+			}
+		case ModulePrerequisiteEnrollmentDays:
+			// cohort members are counted from the cohort start rather than the date they were enrolled
+			enrolled := u.DateCreated
+			if u.CohortStart != nil {
+				enrolled = *u.CohortStart
+			}
+			date := enrolled.AddDate(0, 0, prerequisite.Days)
+			if now.Before(date) {
+				unlockDate = &date
+				reason = fmt.Sprintf("available %d days after enrollment", prerequisite.Days)
+			}
+		}
+		if reason == "" {
+			continue
+		}
+
+		if !lock.Locked {
+			lock.Locked = true
+			lock.Reason = reason
+		}
+		lock.Unmet = append(lock.Unmet, prerequisite)
+		if unlockDate == nil {
+			dateOnly = false
+		} else if lock.UnlockDate == nil || unlockDate.After(*lock.UnlockDate) {
+			lock.UnlockDate = unlockDate
+		}
+	}
+
+	if !dateOnly {
+		lock.UnlockDate = nil
+	}
+	return lock
+}
+
+// SetModuleLocks sets the lock state of every module in the course for the user at the given time
+func (u *UserCourse) SetModuleLocks(now time.Time) {
+	if u == nil {
+		return
+	}
+
+	modules := make([]Module, len(u.Course.Modules))
+	for i, module := range u.Course.Modules {
+		lock := u.GetModuleLock(module, now)
+		module.Lock = &lock
+		modules[i] = module
+	}
+	u.Course.Modules = modules
+}
+
+// Validate checks the module prerequisites (references to other modules are checked when the course is validated)
+func (m *Module) Validate() error {
+	if m == nil {
+		return errors.ErrorData(logutils.StatusMissing, TypeModule, nil)
+	}
+
+	err := m.Localizations.Validate()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, TypeLocalization, &logutils.FieldArgs{"key": m.Key}, err)
+	}
+	for _, prerequisite := range m.Prerequisites {
+		err = prerequisite.Validate()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionValidate, TypeModulePrerequisite, &logutils.FieldArgs{"module_key": m.Key}, err)
+		}
+		if prerequisite.Type == ModulePrerequisiteModule && prerequisite.ModuleKey == m.Key {
+			return errors.ErrorData(logutils.StatusInvalid, "prerequisite module key", &logutils.FieldArgs{"module_key": m.Key, "prerequisite": prerequisite.ModuleKey})
+		}
+	}
+	return nil
+}
+
+// validatePrerequisites checks that module prerequisites refer to other modules in the course and do not form a cycle
+func (c *Course) validatePrerequisites() error {
+	requires := make(map[string][]string)
+	for _, module := range c.Modules {
+		for _, prerequisite := range module.Prerequisites {
+			err := prerequisite.Validate()
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionValidate, TypeModulePrerequisite, &logutils.FieldArgs{"module_key": module.Key}, err)
+			}
+			if prerequisite.Type != ModulePrerequisiteModule {
+				continue
+			}
+			if prerequisite.ModuleKey == module.Key || c.GetModule(prerequisite.ModuleKey) == nil {
+				return errors.ErrorData(logutils.StatusInvalid, "prerequisite module key", &logutils.FieldArgs{"module_key": module.Key, "prerequisite": prerequisite.ModuleKey})
+			}
+			requires[module.Key] = append(requires[module.Key], prerequisite.ModuleKey)
+		}
+	}
+
+	// modules in a prerequisite cycle could never be started
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(key string) bool
+	visit = func(key string) bool {
+		switch state[key] {
+		case visiting:
+			return false
+		case visited:
+			return true
+		}
+		state[key] = visiting
+		for _, required := range requires[key] {
+			if !visit(required) {
+				return false
+			}
+		}
+		state[key] = visited
+		return true
+	}
+	for _, module := range c.Modules {
+		if state[module.Key] == unvisited && !visit(module.Key) {
+			return errors.ErrorData(logutils.StatusInvalid, "module prerequisites", &logutils.FieldArgs{"module_key": module.Key, "cycle": true})
+		}
+	}
+	return nil
+}
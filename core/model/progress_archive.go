@@ -0,0 +1,110 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeProgressArchive progress archive type
+	TypeProgressArchive logutils.MessageDataType = "progress archive"
+
+	//ProgressScopeCourse the whole course was restarted
+	ProgressScopeCourse string = "course"
+	//ProgressScopeModule a single module was restarted
+	ProgressScopeModule string = "module"
+	//ProgressScopeUnit a unit and the units following it in its module were restarted
+	ProgressScopeUnit string = "unit"
+)
+
+// ProgressArchive represents a previous attempt at a course, module or unit which was kept when a user's progress was restarted
+type ProgressArchive struct {
+	ID        string  `json:"id"`
+	AppID     string  `json:"app_id"`
+	OrgID     string  `json:"org_id"`
+	UserID    string  `json:"user_id"`
+	CourseKey string  `json:"course_key"`
+	Scope     string  `json:"scope"` // course, module, unit
+	ModuleKey *string `json:"module_key"`
+	UnitKey   *string `json:"unit_key"`
+
+	RestartedBy string `json:"restarted_by"` // account ID of the user or admin who restarted the progress
+
+	UserCourse   *UserCourse   `json:"user_course"` // user course before the restart (course scope only)
+	UserUnits    []UserUnit    `json:"user_units"`
+	UserContents []UserContent `json:"user_contents"`
+
+	DateCreated time.Time `json:"date_created"`
+}
+
+// ProgressRestartScope gives the scope of a progress restart for the given module and unit keys
+func ProgressRestartScope(moduleKey *string, unitKey *string) (string, error) {
+	if unitKey != nil {
+		if moduleKey == nil {
+			return "", errors.ErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("module_key"))
+		}
+		return ProgressScopeUnit, nil
+	}
+	if moduleKey != nil {
+		return ProgressScopeModule, nil
+	}
+	return ProgressScopeCourse, nil
+}
+
+// Restart clears the completion of the course or of the module being restarted, and when restarting the course with resetStreaks set,
+// also returns the streak, pauses and streak history to their initial state
+func (u *UserCourse) Restart(moduleKey *string, resetStreaks bool, initialPauses int) {
+	if u == nil {
+		return
+	}
+
+	// a restarted module is no longer complete, so neither is the course
+	u.DateCompleted = nil
+	if moduleKey != nil {
+		delete(u.CompletedModules, *moduleKey)
+		return
+	}
+
+	u.CompletedModules = nil
+	if resetStreaks {
+		u.Streak = 0
+		u.StreakResets = nil
+		u.StreakRestarts = nil
+		u.StreakBeforeReset = nil
+		u.Pauses = initialPauses
+		u.PauseProgress = 0
+		u.PauseUses = nil
+		u.StreakFreezes = nil
+		u.LastCompleted = nil
+		u.LastResponded = nil
+	}
+}
+
+// HideAnswers hides the evaluation answers of the archived course, units and content
+func (p *ProgressArchive) HideAnswers() {
+	if p.UserCourse != nil {
+		p.UserCourse.Course.HideAnswers()
+	}
+	for i := range p.UserUnits {
+		p.UserUnits[i].Unit.HideAnswers()
+	}
+	for i := range p.UserContents {
+		p.UserContents[i].Content.HideAnswers()
+	}
+}
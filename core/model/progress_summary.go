@@ -0,0 +1,234 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeProgressSummary progress summary type
+	TypeProgressSummary logutils.MessageDataType = "progress summary"
+)
+
+// ProgressSummary represents the progress of a user in a course with what to do next
+type ProgressSummary struct {
+	CourseKey       string           `json:"course_key"`
+	Completed       int              `json:"completed"`        // number of required schedule items completed
+	Required        int              `json:"required"`         // number of required schedule items in the course
+	PercentComplete float64          `json:"percent_complete"` // 0-100
+	Modules         []ModuleProgress `json:"modules"`          // in course order
+
+	Next         *NextScheduleItem `json:"next"`          // nil if there is nothing left to do
+	NextRequired *NextScheduleItem `json:"next_required"` // the required schedule item the streak process checks, which is next if it is required (nil if none is left in the module)
+
+	Streak            int       `json:"streak"`
+	Pauses            int       `json:"pauses"`
+	TaskCompleted     bool      `json:"task_completed"`      // whether the next required schedule item has been completed, so the streak process counts the current streak day
+	Frozen            bool      `json:"frozen"`              // whether the current streak day has been frozen in advance
+	NextStreakProcess time.Time `json:"next_streak_process"` // when the current streak day ends
+	SecondsRemaining  int64     `json:"seconds_remaining"`   // until the next streak process
+
+	DateCompleted *time.Time `json:"date_completed"`
+}
+
+// ModuleProgress represents the progress of a user in a module
+type ModuleProgress struct {
+	ModuleKey       string      `json:"module_key"`
+	Name            string      `json:"name"`
+	Completed       int         `json:"completed"` // number of required schedule items completed
+	Required        int         `json:"required"`  // number of required schedule items in the module
+	PercentComplete float64     `json:"percent_complete"`
+	Started         bool        `json:"started"`
+	Lock            *ModuleLock `json:"lock"`
+	DateCompleted   *time.Time  `json:"date_completed"`
+}
+
+// NextScheduleItem represents the schedule item a user should work on next with its content
+type NextScheduleItem struct {
+	ModuleKey     string                 `json:"module_key"`
+	UnitKey       string                 `json:"unit_key"`
+	UnitName      string                 `json:"unit_name"`
+	ScheduleIndex int                    `json:"schedule_index"`
+	Name          string                 `json:"name"`
+	Required      bool                   `json:"required"`
+	Contents      []Content              `json:"content"`
+	UserContent   []UserContentReference `json:"user_content"` // completion of each content item (empty if the module has not been started)
+	DateStarted   *time.Time             `json:"date_started"`
+	Deadline      *time.Time             `json:"deadline"` // when the streak process checks the item for completion (required items which have been started only)
+}
+
+// BuildProgressSummary gives the progress of the user course at now based on the user units taken in it
+//
+//	The next schedule item is the current one in the first module in progress, or the first one in the first unlocked module which has not been started
+//	The next required schedule item is the one the streak process checks for it, which the deadline and task completion refer to
+func (u *UserCourse) BuildProgressSummary(userUnits []UserUnit, now time.Time, snConfig StreaksNotificationsConfig) *ProgressSummary {
+	if u == nil {
+		return nil
+	}
+
+	summary := ProgressSummary{CourseKey: u.Course.Key, Modules: make([]ModuleProgress, 0), Streak: u.Streak, Pauses: u.Pauses, DateCompleted: u.DateCompleted}
+	var firstUnstarted *NextScheduleItem
+	var nextUserUnit, firstUnstartedUserUnit *UserUnit
+	for _, module := range u.Course.Modules {
+		lock := u.GetModuleLock(module, now)
+		progress := ModuleProgress{ModuleKey: module.Key, Name: module.Name, Lock: &lock}
+		if dateCompleted, completed := u.CompletedModules[module.Key]; completed {
+			progress.DateCompleted = &dateCompleted
+		}
+
+		for _, unit := range module.Units {
+			var userUnit *UserUnit
+			for i := range userUnits {
+				if userUnits[i].ModuleKey == module.Key && userUnits[i].Unit.Key == unit.Key {
+					userUnit = &userUnits[i]
+					break
+				}
+			}
+			if userUnit != nil {
+				progress.Started = true
+			}
+
+			for i, item := range unit.Schedule {
+				if !item.IsRequired() {
+					continue
+				}
+				progress.Required++
+				if progress.DateCompleted != nil || (userUnit != nil && i < userUnit.Completed) {
+					progress.Completed++
+				}
+			}
+
+			if userUnit != nil && userUnit.Current && summary.Next == nil && progress.DateCompleted == nil {
+				summary.Next = userUnit.scheduleItem(userUnit.Completed)
+				nextUserUnit = userUnit
+			}
+		}
+		if !progress.Started && !lock.Locked && progress.DateCompleted == nil && firstUnstarted == nil && len(module.Units) > 0 && len(module.Units[0].Schedule) > 0 {
+			unit := module.Units[0]
+			firstUnstartedUserUnit = &UserUnit{ModuleKey: module.Key, Unit: unit, UserSchedule: make([]UserScheduleItem, len(unit.Schedule))}
+			firstUnstarted = firstUnstartedUserUnit.scheduleItem(0)
+		}
+
+		progress.PercentComplete = percentComplete(progress.Completed, progress.Required, progress.DateCompleted != nil)
+		summary.Completed += progress.Completed
+		summary.Required += progress.Required
+		summary.Modules = append(summary.Modules, progress)
+	}
+	if summary.Next == nil {
+		summary.Next = firstUnstarted
+		nextUserUnit = firstUnstartedUserUnit
+	}
+	summary.PercentComplete = percentComplete(summary.Completed, summary.Required, u.DateCompleted != nil)
+
+	if summary.Next != nil && nextUserUnit != nil {
+		summary.NextRequired, summary.TaskCompleted = u.nextRequiredScheduleItem(*nextUserUnit, userUnits)
+	}
+	summary.Frozen = u.IsFrozen(now, snConfig)
+	if lastStreakProcess := u.MostRecentStreakProcessTime(&now, snConfig); lastStreakProcess != nil {
+		summary.NextStreakProcess = lastStreakProcess.In(u.StreaksLocation(snConfig)).AddDate(0, 0, 1).UTC()
+		summary.SecondsRemaining = int64(summary.NextStreakProcess.Sub(now) / time.Second)
+	}
+
+	return &summary
+}
+
+// nextRequiredScheduleItem gives the required schedule item the streak process checks for the user unit, the same way it is found when processing streaks,
+// and whether it has been completed
+//
+//	This is the current schedule item if it is required, otherwise the first required one after it in the module, which belongs to a later unit if none is left in this one
+func (u *UserCourse) nextRequiredScheduleItem(userUnit UserUnit, userUnits []UserUnit) (*NextScheduleItem, bool) {
+	required := u.Course.GetNextRequiredScheduleItem(userUnit.ModuleKey, userUnit.Unit.Key, userUnit.Completed, true)
+	if required == nil {
+		return nil, false
+	}
+
+	for _, module := range u.Course.Modules {
+		if module.Key != userUnit.ModuleKey {
+			continue
+		}
+		for _, unit := range module.Units {
+			for i := range unit.Schedule {
+				// the course schedule item found above is the one at this position
+				if &unit.Schedule[i] != required {
+					continue
+				}
+
+				// nothing has been done in a unit which has not been started
+				requiredUserUnit := UserUnit{ModuleKey: module.Key, Unit: unit, UserSchedule: make([]UserScheduleItem, len(unit.Schedule))}
+				started := false
+				if unit.Key == userUnit.Unit.Key {
+					requiredUserUnit, started = userUnit, userUnit.ID != ""
+				} else {
+					for j := range userUnits {
+						if userUnits[j].ModuleKey == module.Key && userUnits[j].Unit.Key == unit.Key {
+							requiredUserUnit, started = userUnits[j], true
+							break
+						}
+					}
+				}
+
+				next := requiredUserUnit.scheduleItem(i)
+				if next == nil {
+					return nil, false
+				}
+				return next, started && requiredUserUnit.UserSchedule[i].IsComplete()
+			}
+		}
+	}
+	return nil, false
+}
+
+// scheduleItem gives the schedule item of the user unit at index with its content
+func (u *UserUnit) scheduleItem(index int) *NextScheduleItem {
+	if index < 0 || index >= len(u.Unit.Schedule) || index >= len(u.UserSchedule) {
+		return nil
+	}
+
+	item := u.Unit.Schedule[index]
+	userItem := u.UserSchedule[index]
+	next := NextScheduleItem{ModuleKey: u.ModuleKey, UnitKey: u.Unit.Key, UnitName: u.Unit.Name, ScheduleIndex: index, Name: item.Name, Required: item.IsRequired(),
+		Contents: make([]Content, 0), UserContent: userItem.UserContent, DateStarted: userItem.DateStarted}
+	if next.UserContent == nil {
+		next.UserContent = make([]UserContentReference, 0)
+	}
+	for _, key := range item.ContentKeys {
+		for _, content := range u.Unit.Contents {
+			if content.Key == key {
+				next.Contents = append(next.Contents, content)
+				break
+			}
+		}
+	}
+	// the streak process checks a required item once it has been started for its duration in days
+	if item.IsRequired() && item.Duration != nil && userItem.DateStarted != nil {
+		deadline := userItem.DateStarted.Add(24 * time.Duration(*item.Duration) * time.Hour)
+		next.Deadline = &deadline
+	}
+	return &next
+}
+
+// percentComplete gives completed as a percentage of required, where nothing required counts as complete only if done is set
+func percentComplete(completed int, required int, done bool) float64 {
+	if required == 0 {
+		if done {
+			return 100
+		}
+		return 0
+	}
+	return float64(completed) * 100 / float64(required)
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeProgressSync progress sync type
+	TypeProgressSync logutils.MessageDataType = "progress sync"
+	//TypeProgressSyncItem progress sync item type
+	TypeProgressSyncItem logutils.MessageDataType = "progress sync item"
+	//TypeProgressSyncResult progress sync result type
+	TypeProgressSyncResult logutils.MessageDataType = "progress sync result"
+	//TypeProgressSyncReceipt progress sync receipt type
+	TypeProgressSyncReceipt logutils.MessageDataType = "progress sync receipt"
+
+	//ProgressSyncStatusApplied the response was applied
+	ProgressSyncStatusApplied string = "applied"
+	//ProgressSyncStatusDuplicate the response was applied by an earlier request with the same idempotency key and was ignored
+	ProgressSyncStatusDuplicate string = "duplicate"
+	//ProgressSyncStatusRejected the response was not applied and may be sent again with the same idempotency key
+	ProgressSyncStatusRejected string = "rejected"
+
+	// MaxProgressSyncItems is the maximum number of responses synced at once
+	MaxProgressSyncItems int = 100
+	// MaxProgressSyncAge is how long after it was made a response may be synced
+	MaxProgressSyncAge time.Duration = 7 * 24 * time.Hour
+	// MaxProgressSyncClockSkew is how far in the future a response timestamp may be because of client clock differences
+	MaxProgressSyncClockSkew time.Duration = 5 * time.Minute
+)
+
+// ProgressSync represents responses made by a user in a course while offline, in the order they were made
+type ProgressSync struct {
+	Items []ProgressSyncItem `json:"items"`
+}
+
+// ProgressSyncItem represents a response made while offline
+type ProgressSyncItem struct {
+	UserResponse
+	ModuleKey      string    `json:"module_key"`
+	IdempotencyKey string    `json:"idempotency_key"` // unique per response so that it is applied at most once
+	Timestamp      time.Time `json:"timestamp"`       // client time when the response was made
+}
+
+// ProgressSyncResult represents the outcome of syncing each item of a progress sync
+type ProgressSyncResult struct {
+	CourseKey string                   `json:"course_key"`
+	Items     []ProgressSyncItemResult `json:"items"`
+}
+
+// ProgressSyncItemResult represents the outcome of syncing a single response
+type ProgressSyncItemResult struct {
+	IdempotencyKey string     `json:"idempotency_key"`
+	Status         string     `json:"status"`           // applied, duplicate, rejected
+	Reason         *string    `json:"reason,omitempty"` // why the response was rejected
+	Timestamp      *time.Time `json:"timestamp"`        // time the response was applied at (the client time unless it was slightly in the future)
+}
+
+// ProgressSyncReceipt records that a synced response was applied so that replays are ignored
+type ProgressSyncReceipt struct {
+	ID             string    `json:"id" bson:"_id"`
+	AppID          string    `json:"app_id" bson:"app_id"`
+	OrgID          string    `json:"org_id" bson:"org_id"`
+	UserID         string    `json:"user_id" bson:"user_id"`
+	CourseKey      string    `json:"course_key" bson:"course_key"`
+	IdempotencyKey string    `json:"idempotency_key" bson:"idempotency_key"`
+	Timestamp      time.Time `json:"timestamp" bson:"timestamp"`
+	DateCreated    time.Time `json:"date_created" bson:"date_created"`
+}
+
+// Validate checks the number of items and that every item has an idempotency key
+func (p *ProgressSync) Validate() error {
+	if len(p.Items) == 0 || len(p.Items) > MaxProgressSyncItems {
+		return errors.ErrorData(logutils.StatusInvalid, TypeProgressSync, &logutils.FieldArgs{"items.length": len(p.Items), "max": MaxProgressSyncItems})
+	}
+	for i, item := range p.Items {
+		if item.IdempotencyKey == "" {
+			return errors.ErrorData(logutils.StatusMissing, "idempotency key", &logutils.FieldArgs{"index": i})
+		}
+	}
+	return nil
+}
+
+// SyncTime gives the time the response should be applied at if it was made at least after earliest and no later than the clock skew after now
+func (p ProgressSyncItem) SyncTime(earliest *time.Time, now time.Time) (time.Time, error) {
+	if p.Timestamp.IsZero() {
+		return time.Time{}, errors.ErrorData(logutils.StatusMissing, "timestamp", &logutils.FieldArgs{"idempotency_key": p.IdempotencyKey})
+	}
+	timestamp := p.Timestamp.UTC()
+	if timestamp.After(now.Add(MaxProgressSyncClockSkew)) {
+		return time.Time{}, errors.ErrorData(logutils.StatusInvalid, "timestamp", &logutils.FieldArgs{"timestamp": timestamp, "future": true})
+	}
+	if timestamp.Before(now.Add(-MaxProgressSyncAge)) {
+		return time.Time{}, errors.ErrorData(logutils.StatusInvalid, "timestamp", &logutils.FieldArgs{"timestamp": timestamp, "max_age": MaxProgressSyncAge.String()})
+	}
+	// responses must be applied in the order they were made
+	if earliest != nil && timestamp.Before(*earliest) {
+		return time.Time{}, errors.ErrorData(logutils.StatusInvalid, "timestamp", &logutils.FieldArgs{"timestamp": timestamp, "earliest": *earliest})
+	}
+	if timestamp.After(now) {
+		timestamp = now
+	}
+	return timestamp, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressSyncItemSyncTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	earliest := now.Add(-time.Hour)
+	central := time.FixedZone("CST", -6*60*60)
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		earliest  *time.Time
+
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "missing timestamp", timestamp: time.Time{}, wantErr: true},
+		{name: "in the past", timestamp: now.Add(-2 * time.Hour), want: now.Add(-2 * time.Hour)},
+		{name: "at now", timestamp: now, want: now},
+		{name: "converted to UTC", timestamp: now.Add(-time.Hour).In(central), want: now.Add(-time.Hour)},
+		{name: "slightly in the future is applied at now", timestamp: now.Add(time.Minute), want: now},
+		{name: "at the clock skew limit", timestamp: now.Add(MaxProgressSyncClockSkew), want: now},
+		{name: "beyond the clock skew limit", timestamp: now.Add(MaxProgressSyncClockSkew + time.Second), wantErr: true},
+		{name: "at the maximum age", timestamp: now.Add(-MaxProgressSyncAge), want: now.Add(-MaxProgressSyncAge)},
+		{name: "older than the maximum age", timestamp: now.Add(-MaxProgressSyncAge - time.Second), wantErr: true},
+		{name: "at the earliest time", timestamp: earliest, earliest: &earliest, want: earliest},
+		{name: "after the earliest time", timestamp: earliest.Add(time.Second), earliest: &earliest, want: earliest.Add(time.Second)},
+		{name: "before the earliest time", timestamp: earliest.Add(-time.Second), earliest: &earliest, wantErr: true},
+		{name: "future after the earliest time", timestamp: now.Add(time.Minute), earliest: &earliest, want: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := ProgressSyncItem{IdempotencyKey: "key", Timestamp: tt.timestamp}
+			got, err := item.SyncTime(tt.earliest, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("sync time = %v, want %v in UTC", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressSyncValidate(t *testing.T) {
+	items := func(count int) []ProgressSyncItem {
+		result := make([]ProgressSyncItem, count)
+		for i := range result {
+			result[i].IdempotencyKey = "key"
+		}
+		return result
+	}
+	missingKey := items(3)
+	missingKey[1].IdempotencyKey = ""
+
+	tests := []struct {
+		name    string
+		items   []ProgressSyncItem
+		wantErr bool
+	}{
+		{name: "no items", items: nil, wantErr: true},
+		{name: "single item", items: items(1)},
+		{name: "maximum items", items: items(MaxProgressSyncItems)},
+		{name: "too many items", items: items(MaxProgressSyncItems + 1), wantErr: true},
+		{name: "missing idempotency key", items: missingKey, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sync := ProgressSync{Items: tt.items}
+			err := sync.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
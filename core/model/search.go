@@ -0,0 +1,202 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeSearchResult search result type
+	TypeSearchResult logutils.MessageDataType = "search result"
+
+	//SearchResultTypeModule module search result type
+	SearchResultTypeModule string = "module"
+	//SearchResultTypeUnit unit search result type
+	SearchResultTypeUnit string = "unit"
+	//SearchResultTypeContent content search result type
+	SearchResultTypeContent string = "content"
+
+	maxSearchTextLength int = 200
+)
+
+// SearchResult represents a module, unit or content item matching a text search
+type SearchResult struct {
+	Type  string  `json:"type" bson:"-"`
+	Key   string  `json:"key" bson:"key"`
+	Name  string  `json:"name" bson:"name"`
+	Score float64 `json:"score" bson:"score"` // text search relevance (higher is more relevant)
+
+	Paths []SearchPath `json:"paths" bson:"-"` // locations of the item in the courses searched
+}
+
+// SearchPath represents the location of a module, unit or content item in a course
+type SearchPath struct {
+	CourseKey  string  `json:"course_key"`
+	CourseName string  `json:"course_name"`
+	ModuleKey  string  `json:"module_key"`
+	ModuleName string  `json:"module_name"`
+	UnitKey    *string `json:"unit_key,omitempty"` // nil for modules
+	UnitName   *string `json:"unit_name,omitempty"`
+}
+
+// ValidateSearchText checks that the search text is not empty or too long and returns it trimmed
+func ValidateSearchText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.ErrorData(logutils.StatusMissing, "search text", nil)
+	}
+	if len(text) > maxSearchTextLength {
+		return "", errors.ErrorData(logutils.StatusInvalid, "search text", &logutils.FieldArgs{"length": len(text), "max": maxSearchTextLength})
+	}
+	return text, nil
+}
+
+// SearchKeys returns the keys of the modules, units and content items in the courses
+func SearchKeys(courses []Course) (moduleKeys []string, unitKeys []string, contentKeys []string) {
+	moduleKeys, unitKeys, contentKeys = make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, course := range courses {
+		for _, module := range course.Modules {
+			moduleKeys = append(moduleKeys, module.Key)
+			for _, unit := range module.Units {
+				unitKeys = append(unitKeys, unit.Key)
+				for _, content := range unit.Contents {
+					contentKeys = append(contentKeys, content.Key)
+				}
+			}
+		}
+	}
+	return moduleKeys, unitKeys, contentKeys
+}
+
+// SearchCourses runs a text search for the modules, units and content items in the courses themselves, so users only find text from the courses they are taking
+//
+//	Fields are weighted as in the text indexes of the custom collections. Terms match whole words regardless of case, and an item scores the weight of each
+//	field containing each term
+func SearchCourses(courses []Course, text string) []SearchResult {
+	terms := searchWords(text)
+	type itemKey struct {
+		itemType string
+		key      string
+	}
+	scores := make(map[itemKey]float64)
+	results := make([]SearchResult, 0)
+	add := func(itemType string, key string, name string, score float64) {
+		if score == 0 {
+			return
+		}
+		item := itemKey{itemType: itemType, key: key}
+		if _, found := scores[item]; !found {
+			results = append(results, SearchResult{Type: itemType, Key: key, Name: name})
+		}
+		scores[item] = max(scores[item], score)
+	}
+
+	for _, course := range courses {
+		for _, module := range course.Modules {
+			add(SearchResultTypeModule, module.Key, module.Name, searchScore(terms, 10, module.Name)+searchScore(terms, 5, module.Localizations.SearchText()...))
+			for _, unit := range module.Units {
+				add(SearchResultTypeUnit, unit.Key, unit.Name, searchScore(terms, 10, unit.Name)+searchScore(terms, 5, unit.Localizations.SearchText()...))
+				for _, content := range unit.Contents {
+					score := searchScore(terms, 10, content.Name) + searchScore(terms, 5, content.Reference.Name) + searchScore(terms, 3, content.Localizations.SearchText()...) +
+						searchScore(terms, 1, content.Details)
+					add(SearchResultTypeContent, content.Key, content.Name, score)
+				}
+			}
+		}
+	}
+
+	for i := range results {
+		results[i].Score = scores[itemKey{itemType: results[i].Type, key: results[i].Key}]
+	}
+	return results
+}
+
+// searchScore gives weight for each term found in the fields
+func searchScore(terms []string, weight float64, fields ...string) float64 {
+	score := 0.0
+	for _, field := range fields {
+		words := searchWords(field)
+		for _, term := range terms {
+			for _, word := range words {
+				if word == term {
+					score += weight
+					break
+				}
+			}
+		}
+	}
+	return score
+}
+
+// searchWords splits text into lower case words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// RankSearchResults sorts the results by relevance, keeping at most limit results, and sets their paths in the courses
+//
+//	Result names are replaced by the names in the courses so that localized courses give localized results
+func RankSearchResults(results []SearchResult, courses []Course, limit int) []SearchResult {
+	type itemKey struct {
+		itemType string
+		key      string
+	}
+	paths := make(map[itemKey][]SearchPath)
+	names := make(map[itemKey]string)
+	for _, course := range courses {
+		for _, module := range course.Modules {
+			moduleKey := itemKey{itemType: SearchResultTypeModule, key: module.Key}
+			paths[moduleKey] = append(paths[moduleKey], SearchPath{CourseKey: course.Key, CourseName: course.Name, ModuleKey: module.Key, ModuleName: module.Name})
+			names[moduleKey] = module.Name
+			for _, unit := range module.Units {
+				unitPath := SearchPath{CourseKey: course.Key, CourseName: course.Name, ModuleKey: module.Key, ModuleName: module.Name, UnitKey: &unit.Key, UnitName: &unit.Name}
+				unitKey := itemKey{itemType: SearchResultTypeUnit, key: unit.Key}
+				paths[unitKey] = append(paths[unitKey], unitPath)
+				names[unitKey] = unit.Name
+				for _, content := range unit.Contents {
+					contentKey := itemKey{itemType: SearchResultTypeContent, key: content.Key}
+					paths[contentKey] = append(paths[contentKey], unitPath)
+					names[contentKey] = content.Name
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for i, result := range results {
+		key := itemKey{itemType: result.Type, key: result.Key}
+		results[i].Paths = paths[key]
+		if results[i].Paths == nil {
+			results[i].Paths = make([]SearchPath, 0)
+		}
+		if name, ok := names[key]; ok {
+			results[i].Name = name
+		}
+	}
+	return results
+}
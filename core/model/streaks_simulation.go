@@ -0,0 +1,75 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeStreaksSimulation streaks simulation type
+	TypeStreaksSimulation logutils.MessageDataType = "streaks simulation"
+
+	//MaxStreaksSimulationHours is the maximum number of hours which may be simulated at once
+	MaxStreaksSimulationHours int = 168
+)
+
+// StreaksSimulation represents the changes streaks and notifications processing would make for a course over a range of hours
+type StreaksSimulation struct {
+	CourseKey string                  `json:"course_key"`
+	Start     time.Time               `json:"start"`
+	Hours     []StreaksSimulationHour `json:"hours"`
+}
+
+// StreaksSimulationHour represents the changes streaks and notifications processing would make at a single hour
+type StreaksSimulationHour struct {
+	Time time.Time `json:"time"`
+
+	UsedPause   []string `json:"used_pause"`   // users who would use a pause
+	UsedFreeze  []string `json:"used_freeze"`  // users who would use a day frozen in advance
+	ResetStreak []string `json:"reset_streak"` // users who would have their streak reset
+
+	AdvancedUnits []StreaksSimulationUnit         `json:"advanced_units"`
+	Notifications []StreaksSimulationNotification `json:"notifications"`
+	Achievements  []StreaksSimulationAchievement  `json:"achievements"`
+}
+
+// NewStreaksSimulationHour creates an empty streaks simulation hour
+func NewStreaksSimulationHour(moment time.Time) StreaksSimulationHour {
+	return StreaksSimulationHour{Time: moment, UsedPause: make([]string, 0), UsedFreeze: make([]string, 0), ResetStreak: make([]string, 0),
+		AdvancedUnits: make([]StreaksSimulationUnit, 0), Notifications: make([]StreaksSimulationNotification, 0), Achievements: make([]StreaksSimulationAchievement, 0)}
+}
+
+// StreaksSimulationUnit represents a user unit which would be completed
+type StreaksSimulationUnit struct {
+	UserID      string  `json:"user_id"`
+	ModuleKey   string  `json:"module_key"`
+	UnitKey     string  `json:"unit_key"`
+	NextUnitKey *string `json:"next_unit_key"` // nil if the completed unit is the last in its module
+}
+
+// StreaksSimulationNotification represents a notification which would be sent
+type StreaksSimulationNotification struct {
+	Subject string   `json:"subject"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// StreaksSimulationAchievement represents an achievement which would be awarded
+type StreaksSimulationAchievement struct {
+	UserID         string `json:"user_id"`
+	AchievementKey string `json:"achievement_key"`
+}
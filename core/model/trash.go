@@ -0,0 +1,70 @@
+// Copyright 2026 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 the "License";
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+)
+
+const (
+	//TypeTrashItem trash item type
+	TypeTrashItem logutils.MessageDataType = "trash item"
+
+	//TrashTypeCourse course trash item type
+	TrashTypeCourse string = "course"
+	//TrashTypeModule module trash item type
+	TrashTypeModule string = "module"
+	//TrashTypeUnit unit trash item type
+	TrashTypeUnit string = "unit"
+	//TrashTypeContent content trash item type
+	TrashTypeContent string = "content"
+	//TrashTypeCourseConfig course config trash item type
+	TrashTypeCourseConfig string = "course_config"
+)
+
+// TrashItem represents a deleted course, module, unit, content or course config which may be restored until it expires
+type TrashItem struct {
+	ID    string `json:"id" bson:"_id"`
+	AppID string `json:"app_id" bson:"app_id"`
+	OrgID string `json:"org_id" bson:"org_id"`
+
+	Type string `json:"type" bson:"type"`
+	Key  string `json:"key" bson:"key"` // key of the deleted item (course key for course configs)
+	Name string `json:"name" bson:"name"`
+
+	Counts map[string]int `json:"counts" bson:"counts"` // number of documents and references removed with the item by collection
+
+	DeletedBy   string    `json:"deleted_by" bson:"deleted_by"` // account ID of the admin who deleted the item
+	DateDeleted time.Time `json:"date_deleted" bson:"date_deleted"`
+	DateExpires time.Time `json:"date_expires" bson:"date_expires"` // the item is permanently deleted after this date
+}
+
+// NewTrashItem creates a trash item for an item deleted by an admin at now which is kept for the retention period
+func NewTrashItem(appID string, orgID string, itemType string, key string, deletedBy string, now time.Time, retention time.Duration) TrashItem {
+	return TrashItem{ID: uuid.NewString(), AppID: appID, OrgID: orgID, Type: itemType, Key: key, Counts: make(map[string]int),
+		DeletedBy: deletedBy, DateDeleted: now.UTC(), DateExpires: now.Add(retention).UTC()}
+}
+
+// IsValidTrashType returns whether the item type may be deleted to the trash
+func IsValidTrashType(itemType string) bool {
+	switch itemType {
+	case TrashTypeCourse, TrashTypeModule, TrashTypeUnit, TrashTypeContent, TrashTypeCourseConfig:
+		return true
+	}
+	return false
+}
@@ -43,3 +43,22 @@ func NewCacheAdapter(defaultCacheExpirationSeconds string) *CacheAdapter {
 		cache: cache,
 	}
 }
+
+// GetGroupMembers returns the cached user IDs of the members of a Groups BB group
+func (c *CacheAdapter) GetGroupMembers(groupTitle string) (map[string]bool, bool) {
+	value, found := c.cache.Get(groupMembersCacheKey(groupTitle))
+	if !found {
+		return nil, false
+	}
+	members, ok := value.(map[string]bool)
+	return members, ok
+}
+
+// SetGroupMembers caches the user IDs of the members of a Groups BB group
+func (c *CacheAdapter) SetGroupMembers(groupTitle string, members map[string]bool) {
+	c.cache.SetDefault(groupMembersCacheKey(groupTitle), members)
+}
+
+func groupMembersCacheKey(groupTitle string) string {
+	return "group_members:" + groupTitle
+}
@@ -129,6 +129,21 @@ func (a *Adapter) GetAccountsByNetIDs(netIDs []string) ([]model.CoreAccount, err
 	return a.GetAccounts(searchParams)
 }
 
+// GetAccountByID retrieves an account by ID
+func (a *Adapter) GetAccountByID(id string) (*model.CoreAccount, error) {
+	searchParams := map[string]interface{}{
+		"id": id,
+	}
+	accounts, err := a.GetAccounts(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	return &accounts[0], nil
+}
+
 // GetAccounts retrieves account for provided params
 func (a *Adapter) GetAccounts(searchParams map[string]interface{}) ([]model.CoreAccount, error) {
 	if a.serviceAccountManager == nil {
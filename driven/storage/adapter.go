@@ -37,6 +37,14 @@ type configEntity struct {
 	Config interface{} `bson:"config"`
 }
 
+const (
+	maxTransactionAttempts int           = 3                     // attempts made to perform a transaction that conflicts with concurrent updates
+	transactionRetryDelay  time.Duration = 50 * time.Millisecond // delay before performing a conflicting transaction again, doubled after each attempt
+
+	transientTransactionErrorLabel string = "TransientTransactionError"
+	writeConflictErrorCode         int    = 112
+)
+
 // Adapter implements the Storage interface
 type Adapter struct {
 	db *database
@@ -55,9 +63,32 @@ func (sa *Adapter) SetListener(listener interfaces.CollectionListener) {
 	sa.db.listener = listener
 }
 
-// PerformTransaction performs a transaction
+// PerformTransaction performs a transaction (or joins the current one if the adapter is already performing a transaction)
+//
+//	A transaction that conflicts with a concurrent update is performed again a limited number of times, after which a conflict error is returned
 func (sa *Adapter) PerformTransaction(transaction func(storage interfaces.Storage) error) error {
-	// transaction
+	if sa.context != nil {
+		return transaction(sa)
+	}
+
+	var err error
+	delay := transactionRetryDelay
+	for attempt := 1; attempt <= maxTransactionAttempts; attempt++ {
+		err = sa.performTransaction(transaction)
+		if err == nil || !isTransactionConflict(err) {
+			return err
+		}
+		if attempt < maxTransactionAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	// the documents kept changing while the transaction was performed, so the client must refresh them before trying again
+	return errors.SetStatus(err, model.ErrorStatusConflict)
+}
+
+func (sa *Adapter) performTransaction(transaction func(storage interfaces.Storage) error) error {
 	callback := func(sessionContext mongo.SessionContext) (interface{}, error) {
 		adapter := sa.withContext(sessionContext)
 
@@ -88,6 +119,26 @@ func (sa *Adapter) PerformTransaction(transaction func(storage interfaces.Storag
 	return nil
 }
 
+// isTransactionConflict returns whether a transaction failed because of a concurrent update, in which case performing it again may succeed
+func isTransactionConflict(err error) bool {
+	if errors.Status(err) == model.ErrorStatusConflict {
+		return true
+	}
+	for err != nil {
+		if serverErr, ok := err.(mongo.ServerError); ok {
+			return serverErr.HasErrorLabel(transientTransactionErrorLabel) || serverErr.HasErrorCode(writeConflictErrorCode)
+		}
+		wrappedErr, ok := err.(interface {
+			Internal() error
+		})
+		if !ok {
+			return false
+		}
+		err = wrappedErr.Internal()
+	}
+	return false
+}
+
 // UserExist returns whether a user exists with the given netID
 func (sa *Adapter) UserExist(netID string) (*bool, error) {
 	filter := bson.D{primitive.E{Key: "net_id", Value: netID}}
@@ -251,6 +302,20 @@ func (sa *Adapter) LoadAllNudges() ([]model.Nudge, error) {
 	return result, nil
 }
 
+// FindNudge finds a nudge by ID (nil if it does not exist)
+func (sa *Adapter) FindNudge(ID string) (*model.Nudge, error) {
+	filter := bson.D{primitive.E{Key: "_id", Value: ID}}
+	var result []model.Nudge
+	err := sa.db.nudges.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeNudge, &logutils.FieldArgs{"_id": ID}, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
 // LoadActiveNudges loads all active nudges
 func (sa *Adapter) LoadActiveNudges() ([]model.Nudge, error) {
 	filter := bson.D{primitive.E{Key: "active", Value: true}}
@@ -589,6 +654,17 @@ func (sa *Adapter) DeleteUserUnitsByAccountsIDs(log *logs.Log, appID string, org
 	return err
 }
 
+// DeleteUserAchievementsByAccountsIDs deletes user achievements by accountsIDs
+func (sa *Adapter) DeleteUserAchievementsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error {
+	filter := bson.D{
+		{Key: "app_id", Value: appID},
+		{Key: "org_id", Value: orgID},
+		primitive.E{Key: "user_id", Value: primitive.M{"$in": accountsIDs}},
+	}
+	_, err := sa.db.userAchievements.DeleteMany(nil, filter, nil)
+	return err
+}
+
 // NewStorageAdapter creates a new storage adapter instance
 func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout string, logger *logs.Logger) *Adapter {
 	timeout, err := strconv.Atoi(mongoTimeout)
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"lms/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindAchievements finds achievements by a set of parameters
+func (sa *Adapter) FindAchievements(appID string, orgID string, keys []string, courseKey *string) ([]model.Achievement, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	if len(keys) != 0 {
+		filter["key"] = bson.M{"$in": keys}
+	}
+	if courseKey != nil {
+		filter["course_key"] = *courseKey
+	}
+
+	var result []model.Achievement
+	err := sa.db.achievements.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAchievement, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// FindAchievement finds an achievement by key
+func (sa *Adapter) FindAchievement(appID string, orgID string, key string) (*model.Achievement, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
+	var result model.Achievement
+	err := sa.db.achievements.FindOne(sa.context, filter, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAchievement, &errArgs, err)
+	}
+
+	return &result, nil
+}
+
+// InsertAchievement inserts an achievement
+func (sa *Adapter) InsertAchievement(item model.Achievement) error {
+	_, err := sa.db.achievements.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeAchievement, &logutils.FieldArgs{"key": item.Key, "course_key": item.CourseKey}, err)
+	}
+	return nil
+}
+
+// UpdateAchievement updates an achievement
+func (sa *Adapter) UpdateAchievement(item model.Achievement) error {
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}
+	errArgs := logutils.FieldArgs(filter)
+	update := bson.M{
+		"$set": bson.M{
+			"course_key":   item.CourseKey,
+			"name":         item.Name,
+			"description":  item.Description,
+			"type":         item.Type,
+			"threshold":    item.Threshold,
+			"module_key":   item.ModuleKey,
+			"notification": item.Notification,
+			"styles":       item.Styles,
+			"date_updated": time.Now().UTC(),
+		},
+	}
+
+	result, err := sa.db.achievements.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeAchievement, &errArgs, err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeAchievement, &errArgs)
+	}
+	return nil
+}
+
+// DeleteAchievement deletes an achievement by key
+func (sa *Adapter) DeleteAchievement(appID string, orgID string, key string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
+	errArgs := logutils.FieldArgs(filter)
+
+	result, err := sa.db.achievements.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeAchievement, &errArgs, err)
+	}
+	if result == nil {
+		return errors.WrapErrorData(logutils.StatusInvalid, "delete achievement result", &errArgs, err)
+	}
+	if result.DeletedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeAchievement, &errArgs)
+	}
+	return nil
+}
+
+// DeleteAchievements deletes all achievements for a course key
+func (sa *Adapter) DeleteAchievements(appID string, orgID string, courseKey string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+	_, err := sa.db.achievements.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeAchievement, &errArgs, err)
+	}
+	return nil
+}
+
+// FindUserAchievements finds user achievements by a set of parameters sorted by date awarded
+func (sa *Adapter) FindUserAchievements(appID string, orgID string, userID *string, courseKey *string) ([]model.UserAchievement, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	if userID != nil {
+		filter["user_id"] = *userID
+	}
+	if courseKey != nil {
+		filter["course_key"] = *courseKey
+	}
+
+	var result []model.UserAchievement
+	opts := options.Find().SetSort(bson.D{{Key: "date_awarded", Value: 1}})
+	err := sa.db.userAchievements.Find(sa.context, filter, &result, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserAchievement, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// InsertUserAchievements inserts user achievements
+func (sa *Adapter) InsertUserAchievements(items []model.UserAchievement) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	storeItems := make([]interface{}, len(items))
+	for i, item := range items {
+		storeItems[i] = item
+	}
+
+	_, err := sa.db.userAchievements.InsertMany(sa.context, storeItems, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserAchievement, nil, err)
+	}
+	return nil
+}
+
+// DeleteUserAchievements deletes the user achievements for a course key (for all users if userID is nil)
+func (sa *Adapter) DeleteUserAchievements(appID string, orgID string, userID *string, courseKey string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+	if userID != nil {
+		filter["user_id"] = *userID
+	}
+
+	_, err := sa.db.userAchievements.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserAchievement, &errArgs, err)
+	}
+	return nil
+}
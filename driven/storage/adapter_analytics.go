@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type userCourseStats struct {
+	Totals []struct {
+		Enrolled  int `bson:"enrolled"`
+		Completed int `bson:"completed"`
+		Dropped   int `bson:"dropped"`
+	} `bson:"totals"`
+	StreakResets     []model.StreakResetCount `bson:"streak_resets"`
+	CompletedModules []struct {
+		ModuleKey string `bson:"_id"`
+		Users     int    `bson:"users"`
+	} `bson:"completed_modules"`
+}
+
+type userUnitStats struct {
+	Modules []struct {
+		ModuleKey string `bson:"_id"`
+		Users     int    `bson:"users"`
+	} `bson:"modules"`
+	Units []struct {
+		ID struct {
+			ModuleKey string `bson:"module_key"`
+			UnitKey   string `bson:"unit_key"`
+		} `bson:"_id"`
+		Reached   int `bson:"reached"`
+		Completed int `bson:"completed"`
+	} `bson:"units"`
+	ScheduleItems []struct {
+		ID struct {
+			ModuleKey string `bson:"module_key"`
+			UnitKey   string `bson:"unit_key"`
+			Index     int    `bson:"index"`
+		} `bson:"_id"`
+		Started   int     `bson:"started"`
+		Completed int     `bson:"completed"`
+		Durations []int64 `bson:"durations"`
+	} `bson:"schedule_items"`
+}
+
+// FindCourseProgressStats aggregates the user courses and user units of a course into progress counts
+func (sa *Adapter) FindCourseProgressStats(appID string, orgID string, courseKey string) (*model.CourseProgressStats, error) {
+	errArgs := &logutils.FieldArgs{"app_id": appID, "org_id": orgID, "course_key": courseKey}
+	isSet := func(field string) bson.M {
+		return bson.M{"$cond": bson.A{bson.M{"$ifNull": bson.A{field, false}}, 1, 0}}
+	}
+
+	userCoursesPipeline := bson.A{
+		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "course.key": courseKey}},
+		bson.M{"$facet": bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{"_id": nil, "enrolled": bson.M{"$sum": 1}, "completed": bson.M{"$sum": isSet("$date_completed")}, "dropped": bson.M{"$sum": isSet("$date_dropped")}}},
+			},
+			"streak_resets": bson.A{
+				bson.M{"$group": bson.M{"_id": bson.M{"$size": bson.M{"$ifNull": bson.A{"$streak_resets", bson.A{}}}}, "users": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"completed_modules": bson.A{
+				bson.M{"$project": bson.M{"modules": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$completed_modules", bson.M{}}}}}},
+				bson.M{"$unwind": "$modules"},
+				bson.M{"$group": bson.M{"_id": "$modules.k", "users": bson.M{"$sum": 1}}},
+			},
+		}},
+	}
+	var courseStats []userCourseStats
+	err := sa.db.userCourses.Aggregate(sa.context, userCoursesPipeline, &courseStats, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, errArgs, err)
+	}
+
+	userUnitsPipeline := bson.A{
+		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "course_key": courseKey}},
+		bson.M{"$facet": bson.M{
+			"modules": bson.A{
+				bson.M{"$group": bson.M{"_id": bson.M{"module_key": "$module_key", "user_id": "$user_id"}}},
+				bson.M{"$group": bson.M{"_id": "$_id.module_key", "users": bson.M{"$sum": 1}}},
+			},
+			"units": bson.A{
+				bson.M{"$group": bson.M{"_id": bson.M{"module_key": "$module_key", "unit_key": "$unit.key"}, "reached": bson.M{"$sum": 1},
+					"completed": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$and": bson.A{bson.M{"$not": bson.A{"$current"}}, bson.M{"$gte": bson.A{"$completed", "$unit.required"}}}}, 1, 0}}}}},
+			},
+			"schedule_items": bson.A{
+				bson.M{"$unwind": bson.M{"path": "$user_schedule", "includeArrayIndex": "index"}},
+				bson.M{"$group": bson.M{"_id": bson.M{"module_key": "$module_key", "unit_key": "$unit.key", "index": "$index"},
+					"started":   bson.M{"$sum": isSet("$user_schedule.date_started")},
+					"completed": bson.M{"$sum": isSet("$user_schedule.date_completed")},
+					"durations": bson.M{"$push": bson.M{"$cond": bson.A{
+						bson.M{"$and": bson.A{bson.M{"$ifNull": bson.A{"$user_schedule.date_started", false}}, bson.M{"$ifNull": bson.A{"$user_schedule.date_completed", false}}}},
+						bson.M{"$subtract": bson.A{"$user_schedule.date_completed", "$user_schedule.date_started"}},
+						"$$REMOVE",
+					}}},
+				}},
+			},
+		}},
+	}
+	var unitStats []userUnitStats
+	err = sa.db.userUnits.Aggregate(sa.context, userUnitsPipeline, &unitStats, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, errArgs, err)
+	}
+
+	stats := model.CourseProgressStats{StreakResets: make([]model.StreakResetCount, 0), ModulesStarted: make(map[string]int), ModulesCompleted: make(map[string]int),
+		Units: make([]model.UnitProgressStats, 0), ScheduleItems: make([]model.ScheduleItemProgressStats, 0)}
+	if len(courseStats) > 0 {
+		if len(courseStats[0].Totals) > 0 {
+			stats.Enrolled = courseStats[0].Totals[0].Enrolled
+			stats.Completed = courseStats[0].Totals[0].Completed
+			stats.Dropped = courseStats[0].Totals[0].Dropped
+		}
+		stats.StreakResets = append(stats.StreakResets, courseStats[0].StreakResets...)
+		for _, module := range courseStats[0].CompletedModules {
+			stats.ModulesCompleted[module.ModuleKey] = module.Users
+		}
+	}
+	if len(unitStats) > 0 {
+		for _, module := range unitStats[0].Modules {
+			stats.ModulesStarted[module.ModuleKey] = module.Users
+		}
+		for _, unit := range unitStats[0].Units {
+			stats.Units = append(stats.Units, model.UnitProgressStats{ModuleKey: unit.ID.ModuleKey, UnitKey: unit.ID.UnitKey, Reached: unit.Reached, Completed: unit.Completed})
+		}
+		for _, item := range unitStats[0].ScheduleItems {
+			stats.ScheduleItems = append(stats.ScheduleItems, model.ScheduleItemProgressStats{ModuleKey: item.ID.ModuleKey, UnitKey: item.ID.UnitKey, Index: item.ID.Index,
+				Started: item.Started, Completed: item.Completed, Durations: item.Durations})
+		}
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"lms/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertAuditLog inserts an audit log entry
+func (sa *Adapter) InsertAuditLog(item model.AuditLog) error {
+	_, err := sa.db.auditLogs.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeAuditLog, &logutils.FieldArgs{"action": item.Action, "entity_type": item.EntityType, "entity_key": item.EntityKey}, err)
+	}
+	return nil
+}
+
+// FindAuditLogs finds a page of audit log entries sorted by most recent, optionally for a single entity type, entity key or actor within a date range
+func (sa *Adapter) FindAuditLogs(appID string, orgID string, entityType *string, entityKey *string, actor *string, startDate *time.Time, endDate *time.Time, limit int, offset int) ([]model.AuditLog, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	if entityType != nil {
+		filter["entity_type"] = *entityType
+	}
+	if entityKey != nil {
+		filter["entity_key"] = *entityKey
+	}
+	if actor != nil {
+		filter["actor"] = *actor
+	}
+	if startDate != nil || endDate != nil {
+		dateFilter := bson.M{}
+		if startDate != nil {
+			dateFilter["$gte"] = *startDate
+		}
+		if endDate != nil {
+			dateFilter["$lt"] = *endDate
+		}
+		filter["date_created"] = dateFilter
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date_created", Value: -1}}).SetLimit(int64(limit)).SetSkip(int64(offset))
+	var result []model.AuditLog
+	err := sa.db.auditLogs.Find(sa.context, filter, &result, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeAuditLog, &errArgs, err)
+	}
+
+	for i := range result {
+		for j := range result[i].Changes {
+			result[i].Changes[j].Before = auditChangeValue(result[i].Changes[j].Before)
+			result[i].Changes[j].After = auditChangeValue(result[i].Changes[j].After)
+		}
+	}
+	return result, nil
+}
+
+// auditChangeValue converts the documents and arrays decoded from a change value back to the JSON representation it was recorded from
+func auditChangeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.D:
+		object := make(map[string]interface{}, len(v))
+		for _, element := range v {
+			object[element.Key] = auditChangeValue(element.Value)
+		}
+		return object
+	case primitive.A:
+		array := make([]interface{}, len(v))
+		for i, element := range v {
+			array[i] = auditChangeValue(element)
+		}
+		return array
+	}
+	return value
+}
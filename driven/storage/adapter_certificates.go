@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindCertificate finds the certificate issued to a user for a course (nil if it does not exist)
+func (sa *Adapter) FindCertificate(appID string, orgID string, userID string, courseKey string) (*model.Certificate, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": userID, "course_key": courseKey}
+	return sa.findCertificate(filter)
+}
+
+// FindCertificateByID finds a certificate by ID (nil if it does not exist)
+func (sa *Adapter) FindCertificateByID(id string) (*model.Certificate, error) {
+	return sa.findCertificate(bson.M{"_id": id})
+}
+
+func (sa *Adapter) findCertificate(filter bson.M) (*model.Certificate, error) {
+	var result []model.Certificate
+	err := sa.db.certificates.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCertificate, &errArgs, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// InsertCertificate inserts a certificate
+func (sa *Adapter) InsertCertificate(item model.Certificate) error {
+	_, err := sa.db.certificates.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeCertificate, &logutils.FieldArgs{"user_id": item.UserID, "course_key": item.CourseKey}, err)
+	}
+	return nil
+}
+
+// DeleteCertificatesByAccountsIDs deletes the certificates issued to the given accounts
+func (sa *Adapter) DeleteCertificatesByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.certificates.DeleteMany(nil, filter, nil)
+	return err
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"lms/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindCohorts finds cohorts sorted by start date, optionally for a single course
+func (sa *Adapter) FindCohorts(appID string, orgID string, courseKey *string) ([]model.Cohort, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	if courseKey != nil {
+		filter["course_key"] = *courseKey
+	}
+
+	var result []model.Cohort
+	err := sa.db.cohorts.Find(sa.context, filter, &result, options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}}))
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// FindCohort finds a cohort by key (nil if it does not exist)
+func (sa *Adapter) FindCohort(appID string, orgID string, key string) (*model.Cohort, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
+	var result []model.Cohort
+	err := sa.db.cohorts.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCohort, &errArgs, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// InsertCohort inserts a cohort
+func (sa *Adapter) InsertCohort(item model.Cohort) error {
+	_, err := sa.db.cohorts.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeCohort, &logutils.FieldArgs{"key": item.Key, "course_key": item.CourseKey}, err)
+	}
+	return nil
+}
+
+// UpdateCohort updates a cohort (the course of a cohort may not change)
+func (sa *Adapter) UpdateCohort(item model.Cohort) error {
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}
+	errArgs := logutils.FieldArgs(filter)
+	update := bson.M{
+		"$set": bson.M{
+			"name":         item.Name,
+			"start_date":   item.StartDate,
+			"timezone":     item.Timezone,
+			"account_ids":  item.AccountIDs,
+			"group_title":  item.GroupTitle,
+			"date_updated": time.Now().UTC(),
+		},
+	}
+
+	result, err := sa.db.cohorts.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeCohort, &errArgs, err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeCohort, &errArgs)
+	}
+	return nil
+}
+
+// DeleteCohort deletes a cohort by key
+func (sa *Adapter) DeleteCohort(appID string, orgID string, key string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
+	errArgs := logutils.FieldArgs(filter)
+
+	result, err := sa.db.cohorts.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeCohort, &errArgs, err)
+	}
+	if result == nil {
+		return errors.WrapErrorData(logutils.StatusInvalid, "delete cohort result", &errArgs, err)
+	}
+	if result.DeletedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeCohort, &errArgs)
+	}
+	return nil
+}
+
+// DeleteCohorts deletes all cohorts for a course key
+func (sa *Adapter) DeleteCohorts(appID string, orgID string, courseKey string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+	_, err := sa.db.cohorts.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeCohort, &errArgs, err)
+	}
+	return nil
+}
+
+// FindCohortUserCourses finds the user courses of the members of a cohort
+func (sa *Adapter) FindCohortUserCourses(appID string, orgID string, cohortKey string) ([]model.UserCourse, error) {
+	filter := bson.M{"app_id": appID, "org_id": orgID, "cohort_key": cohortKey}
+	var result []userCourse
+	err := sa.db.userCourses.Find(sa.context, filter, &result, options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}}))
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserCourse, &errArgs, err)
+	}
+
+	refs := make([]courseVersionRef, len(result))
+	for i, item := range result {
+		refs[i] = userCourseVersionRef(item)
+	}
+	snapshots, err := sa.findCourseVersionSnapshots(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedResult := make([]model.UserCourse, len(result))
+	for i, item := range result {
+		convertedResult[i], err = sa.userCourseFromStorage(item, snapshots)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return convertedResult, nil
+}
+
+// UpdateCohortUserCourses sets the cohort start date on the user courses of the members of a cohort, or removes them from the cohort if start is nil
+func (sa *Adapter) UpdateCohortUserCourses(appID string, orgID string, cohortKey string, start *time.Time) error {
+	filter := bson.M{"app_id": appID, "org_id": orgID, "cohort_key": cohortKey}
+	update := bson.M{
+		"$set": bson.M{
+			"cohort_start": start,
+			"date_updated": time.Now().UTC(),
+		},
+	}
+	if start == nil {
+		update = bson.M{
+			"$unset": bson.M{"cohort_key": "", "cohort_start": ""},
+			"$set":   bson.M{"date_updated": time.Now().UTC()},
+		}
+	}
+
+	_, err := sa.db.userCourses.UpdateMany(sa.context, filter, update, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &errArgs, err)
+	}
+	return nil
+}
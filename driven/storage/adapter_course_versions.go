@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"lms/core/model"
+	"time"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindCourseVersions finds the published versions of a course sorted from newest to oldest
+func (sa *Adapter) FindCourseVersions(appID string, orgID string, courseKey string) ([]model.CourseVersion, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+
+	var result []model.CourseVersion
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	err := sa.db.courseVersions.Find(sa.context, filter, &result, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// FindCourseVersion finds a published version of a course (the latest version if version is nil)
+func (sa *Adapter) FindCourseVersion(appID string, orgID string, courseKey string, version *int) (*model.CourseVersion, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+	if version != nil {
+		filter["version"] = *version
+	}
+
+	var result []model.CourseVersion
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}}).SetLimit(1)
+	err := sa.db.courseVersions.Find(sa.context, filter, &result, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, &errArgs, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// courseVersionRef identifies the published course version a user course or user unit is on
+type courseVersionRef struct {
+	AppID     string `bson:"app_id"`
+	OrgID     string `bson:"org_id"`
+	CourseKey string `bson:"course_key"`
+	Version   int    `bson:"version"`
+}
+
+// courseVersionSnapshots holds the published course versions referenced by a batch of user courses or user units, so that each is only found once per batch
+//
+//	The versions are kept encoded and decoded for each item so that items on the same version do not share any data
+type courseVersionSnapshots map[courseVersionRef]bson.Raw
+
+// findCourseVersionSnapshots finds the published course versions referenced by a batch of user courses or user units in a single query
+func (sa *Adapter) findCourseVersionSnapshots(refs []courseVersionRef) (courseVersionSnapshots, error) {
+	snapshots := make(courseVersionSnapshots)
+	versionFilters := make(bson.A, 0)
+	for _, ref := range refs {
+		// version 0 is the unversioned course
+		if ref.Version <= 0 {
+			continue
+		}
+		if _, exists := snapshots[ref]; exists {
+			continue
+		}
+		snapshots[ref] = nil
+		versionFilters = append(versionFilters, bson.M{"app_id": ref.AppID, "org_id": ref.OrgID, "course_key": ref.CourseKey, "version": ref.Version})
+	}
+	if len(versionFilters) == 0 {
+		return snapshots, nil
+	}
+
+	var result []bson.Raw
+	err := sa.db.courseVersions.Find(sa.context, bson.M{"$or": versionFilters}, &result, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeCourseVersion, &logutils.FieldArgs{"count": len(versionFilters)}, err)
+	}
+	for _, raw := range result {
+		var ref courseVersionRef
+		err = bson.Unmarshal(raw, &ref)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDecode, model.TypeCourseVersion, nil, err)
+		}
+		snapshots[ref] = raw
+	}
+	return snapshots, nil
+}
+
+// findSnapshotCourseVersion gives a published course version from the snapshots of a batch, or finds it directly if the item is not part of a batch
+func (sa *Adapter) findSnapshotCourseVersion(snapshots courseVersionSnapshots, ref courseVersionRef) (*model.CourseVersion, error) {
+	if snapshots == nil {
+		return sa.FindCourseVersion(ref.AppID, ref.OrgID, ref.CourseKey, &ref.Version)
+	}
+
+	raw := snapshots[ref]
+	if raw == nil {
+		return nil, nil
+	}
+	var result model.CourseVersion
+	err := bson.Unmarshal(raw, &result)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionDecode, model.TypeCourseVersion, &logutils.FieldArgs{"course_key": ref.CourseKey, "version": ref.Version}, err)
+	}
+	return &result, nil
+}
+
+// InsertCourseVersion inserts a published course version
+func (sa *Adapter) InsertCourseVersion(item model.CourseVersion) error {
+	_, err := sa.db.courseVersions.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeCourseVersion, &logutils.FieldArgs{"course_key": item.CourseKey, "version": item.Version}, err)
+	}
+	return nil
+}
+
+// DeleteCourseVersions deletes all published versions of a course
+func (sa *Adapter) DeleteCourseVersions(appID string, orgID string, courseKey string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "course_key": courseKey}
+	_, err := sa.db.courseVersions.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeCourseVersion, &errArgs, err)
+	}
+	return nil
+}
+
+// MigrateUserCourse moves a user course to the published course version it has been migrated to
+func (sa *Adapter) MigrateUserCourse(item model.UserCourse) error {
+	filter := bson.M{"app_id": item.AppID, "org_id": item.OrgID, "course.key": item.Course.Key, "user_id": item.UserID}
+	errArgs := logutils.FieldArgs(filter)
+	update := bson.M{
+		"$set": bson.M{
+			"course":            sa.customCourseToStorage(item.Course),
+			"course_version":    item.CourseVersion,
+			"completed_modules": item.CompletedModules,
+			"date_completed":    item.DateCompleted,
+			"date_updated":      time.Now().UTC(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := sa.db.userCourses.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &errArgs, err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserCourse, &errArgs)
+	}
+	return nil
+}
+
+// MigrateUserUnit moves a user unit to the unit and schedule of the published course version it has been migrated to
+func (sa *Adapter) MigrateUserUnit(item model.UserUnit) error {
+	filter := bson.M{"_id": item.ID, "org_id": item.OrgID, "app_id": item.AppID}
+	errArgs := logutils.FieldArgs(filter)
+	update := bson.M{
+		"$set": bson.M{
+			"unit":           sa.customUnitToStorage(item.Unit),
+			"course_version": item.CourseVersion,
+			"user_schedule":  item.UserSchedule,
+			"completed":      item.Completed,
+			"current":        item.Current,
+			"date_updated":   time.Now().UTC(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := sa.db.userUnits.UpdateOne(sa.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, &errArgs, err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &errArgs)
+	}
+	return nil
+}
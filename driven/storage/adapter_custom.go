@@ -93,9 +93,12 @@ func (sa *Adapter) UpdateCustomCourse(key string, item model.Course) error {
 	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": key}
 	update := bson.M{
 		"$set": bson.M{
-			"date_updated": time.Now(),
-			"name":         item.Name,
-			"module_keys":  moduleKeys,
+			"date_updated":  time.Now(),
+			"name":          item.Name,
+			"module_keys":   moduleKeys,
+			"availability":  item.Availability,
+			"visibility":    item.Visibility,
+			"localizations": item.Localizations,
 		},
 	}
 	result, err := sa.db.customCourses.UpdateOne(sa.context, filter, update, nil)
@@ -108,7 +111,7 @@ func (sa *Adapter) UpdateCustomCourse(key string, item model.Course) error {
 	return nil
 }
 
-// UpdateUserCourses updates all user_course that matches given courseKey
+// UpdateUserCourses updates all unversioned user_course that matches given courseKey
 func (sa *Adapter) UpdateUserCourses(key string, item model.Course) error {
 	//parse into the storage format and pass parameters
 	var moduleKeys []string
@@ -116,12 +119,16 @@ func (sa *Adapter) UpdateUserCourses(key string, item model.Course) error {
 		moduleKeys = append(moduleKeys, val.Key)
 	}
 
-	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "course.key": key}
+	// users on a published version only receive changes when they are migrated to a newer version
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "course.key": key, "course_version": bson.M{"$in": bson.A{nil, 0}}}
 	update := bson.M{
 		"$set": bson.M{
-			"course.date_updated": time.Now(),
-			"course.name":         item.Name,
-			"course.module_keys":  moduleKeys,
+			"course.date_updated":  time.Now(),
+			"course.name":          item.Name,
+			"course.module_keys":   moduleKeys,
+			"course.availability":  item.Availability,
+			"course.visibility":    item.Visibility,
+			"course.localizations": item.Localizations,
 		},
 	}
 	_, err := sa.db.userCourses.UpdateMany(sa.context, filter, update, nil)
@@ -133,7 +140,7 @@ func (sa *Adapter) UpdateUserCourses(key string, item model.Course) error {
 }
 
 // DecrementUserCoursePauses decrements all matching user course pauses by 1
-func (sa *Adapter) DecrementUserCoursePauses(appID string, orgID string, userIDs []string, key string) error {
+func (sa *Adapter) DecrementUserCoursePauses(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
 	// should never decrement pauses for all user courses matching the first three filter fields at once
 	if len(userIDs) == 0 {
 		return errors.ErrorData(logutils.StatusMissing, "user ids", nil)
@@ -144,11 +151,12 @@ func (sa *Adapter) DecrementUserCoursePauses(appID string, orgID string, userIDs
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
 		"$inc": bson.M{
-			"streak": 1, // using a pause counts toward the streak
-			"pauses": -1,
+			"streak":  1, // using a pause counts toward the streak
+			"pauses":  -1,
+			"version": 1,
 		},
 		"$push": bson.M{
-			"pause_uses": now.Truncate(time.Hour),
+			"pause_uses": processTime.Truncate(time.Hour),
 		},
 		"$set": bson.M{
 			"date_updated": now,
@@ -167,9 +175,9 @@ func (sa *Adapter) DecrementUserCoursePauses(appID string, orgID string, userIDs
 	return nil
 }
 
-// ResetUserCourseStreaks resets all matching user course streaks to 0
-func (sa *Adapter) ResetUserCourseStreaks(appID string, orgID string, userIDs []string, key string) error {
-	// should never reset streaks for all user courses matching the first three filter fields at once
+// UseUserCourseStreakFreezes increments all matching user course streaks for a day frozen in advance (the pauses have already been spent)
+func (sa *Adapter) UseUserCourseStreakFreezes(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
+	// should never use streak freezes for all user courses matching the first three filter fields at once
 	if len(userIDs) == 0 {
 		return errors.ErrorData(logutils.StatusMissing, "user ids", nil)
 	}
@@ -178,11 +186,14 @@ func (sa *Adapter) ResetUserCourseStreaks(appID string, orgID string, userIDs []
 	filter := bson.M{"app_id": appID, "org_id": orgID, "course.key": key, "user_id": bson.M{"$in": userIDs}}
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
+		"$inc": bson.M{
+			"streak":  1, // a frozen day counts toward the streak like any other pause
+			"version": 1,
+		},
 		"$push": bson.M{
-			"streak_resets": now.Truncate(time.Hour),
+			"pause_uses": processTime.Truncate(time.Hour),
 		},
 		"$set": bson.M{
-			"streak":       0,
 			"date_updated": now,
 		},
 	}
@@ -199,6 +210,39 @@ func (sa *Adapter) ResetUserCourseStreaks(appID string, orgID string, userIDs []
 	return nil
 }
 
+// ResetUserCourseStreaks resets all matching user course streaks to 0
+func (sa *Adapter) ResetUserCourseStreaks(appID string, orgID string, userIDs []string, key string, processTime time.Time) error {
+	// should never reset streaks for all user courses matching the first three filter fields at once
+	if len(userIDs) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, "user ids", nil)
+	}
+
+	now := time.Now().UTC()
+	filter := bson.M{"app_id": appID, "org_id": orgID, "course.key": key, "user_id": bson.M{"$in": userIDs}}
+	errArgs := logutils.FieldArgs(filter)
+	// the streak before the reset is kept so that it can be restored if progress made before the reset is synced later
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"streak_resets":       bson.M{"$concatArrays": bson.A{bson.M{"$ifNull": bson.A{"$streak_resets", bson.A{}}}, bson.A{processTime.Truncate(time.Hour)}}},
+			"streak_before_reset": "$streak",
+			"streak":              0,
+			"version":             bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+			"date_updated":        now,
+		}},
+	}
+	res, err := sa.db.userCourses.UpdateMany(sa.context, filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &errArgs, err)
+	}
+	if res.MatchedCount != res.ModifiedCount {
+		errArgs["matched"] = res.MatchedCount
+		errArgs["modified"] = res.ModifiedCount
+		return errors.ErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &errArgs)
+	}
+
+	return nil
+}
+
 // DeleteCustomCourse deletes a course
 func (sa *Adapter) DeleteCustomCourse(appID string, orgID string, key string) error {
 	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
@@ -316,10 +360,13 @@ func (sa *Adapter) UpdateCustomModule(key string, item model.Module) error {
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
 		"$set": bson.M{
-			"date_updated": time.Now(),
-			"name":         item.Name,
-			"unit_keys":    unitKeys,
-			"styles":       item.Styles,
+			"date_updated":  time.Now(),
+			"name":          item.Name,
+			"unit_keys":     unitKeys,
+			"prerequisites": item.Prerequisites,
+			"localizations": item.Localizations,
+			"search_text":   item.Localizations.SearchText(),
+			"styles":        item.Styles,
 		},
 	}
 	result, err := sa.db.customModules.UpdateOne(sa.context, filter, update, nil)
@@ -444,11 +491,13 @@ func (sa *Adapter) UpdateCustomUnit(key string, item model.Unit) error {
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
 		"$set": bson.M{
-			"name":         item.Name,
-			"content_keys": extractedKey,
-			"schedule":     item.Schedule,
-			"required":     item.Required,
-			"date_updated": time.Now(),
+			"name":          item.Name,
+			"content_keys":  extractedKey,
+			"schedule":      item.Schedule,
+			"required":      item.Required,
+			"localizations": item.Localizations,
+			"search_text":   item.Localizations.SearchText(),
+			"date_updated":  time.Now(),
 		},
 	}
 	result, err := sa.db.customUnits.UpdateOne(sa.context, filter, update, nil)
@@ -469,14 +518,15 @@ func (sa *Adapter) UpdateUserUnits(key string, item model.Unit) error {
 		contentKeys = append(contentKeys, val.Key)
 	}
 
-	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "unit.key": key}
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "unit.key": key, "course_version": bson.M{"$in": bson.A{nil, 0}}}
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
 		"$set": bson.M{
-			"unit.name":         item.Name,
-			"unit.content_keys": contentKeys,
-			"unit.schedule":     item.Schedule,
-			"unit.date_updated": time.Now(),
+			"unit.name":          item.Name,
+			"unit.content_keys":  contentKeys,
+			"unit.schedule":      item.Schedule,
+			"unit.localizations": item.Localizations,
+			"unit.date_updated":  time.Now(),
 		},
 	}
 	_, err := sa.db.userUnits.UpdateMany(sa.context, filter, update, nil)
@@ -519,6 +569,17 @@ func (sa *Adapter) DeleteUserUnits(appID string, orgID string, userID string, co
 	return nil
 }
 
+// DeleteUserUnitsByIDs deletes the user units with the given IDs
+func (sa *Adapter) DeleteUserUnitsByIDs(appID string, orgID string, ids []string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "_id": bson.M{"$in": ids}}
+	_, err := sa.db.userUnits.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserUnit, &errArgs, err)
+	}
+	return nil
+}
+
 // DeleteCustomUnit deletes a unit
 func (sa *Adapter) DeleteCustomUnit(appID string, orgID string, key string) error {
 	filter := bson.M{"org_id": orgID, "app_id": appID, "key": key}
@@ -573,6 +634,7 @@ func (sa *Adapter) UpdateUserContent(item model.UserContent, updateContent bool)
 	errArgs := logutils.FieldArgs(filter)
 	setUpdate := bson.M{
 		"response":     item.Response,
+		"evaluation":   item.Evaluation,
 		"date_updated": time.Now().UTC(),
 	}
 	if updateContent {
@@ -607,6 +669,17 @@ func (sa *Adapter) DeleteUserContents(appID string, orgID string, userID string,
 	return nil
 }
 
+// DeleteUserContentsByIDs deletes the user contents with the given IDs
+func (sa *Adapter) DeleteUserContentsByIDs(appID string, orgID string, ids []string) error {
+	filter := bson.M{"app_id": appID, "org_id": orgID, "_id": bson.M{"$in": ids}}
+	_, err := sa.db.userContents.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserContent, &errArgs, err)
+	}
+	return nil
+}
+
 // FindCustomContents finds contents by a set of parameters
 func (sa *Adapter) FindCustomContents(appID string, orgID string, id []string, name []string, key []string) ([]model.Content, error) {
 	filter := bson.M{"org_id": orgID, "app_id": appID}
@@ -647,7 +720,7 @@ func (sa *Adapter) FindCustomContent(appID string, orgID string, key string) (*m
 // InsertCustomContent inserts a content
 func (sa *Adapter) InsertCustomContent(item model.Content) error {
 	item.DateCreated = time.Now()
-	_, err := sa.db.customContents.InsertOne(sa.context, item)
+	_, err := sa.db.customContents.InsertOne(sa.context, sa.customContentToStorage(item))
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeContent, nil, err)
 	}
@@ -659,7 +732,7 @@ func (sa *Adapter) InsertCustomContents(items []model.Content) error {
 	storeItems := make([]interface{}, len(items))
 	for i, item := range items {
 		item.DateCreated = time.Now()
-		storeItems[i] = item
+		storeItems[i] = sa.customContentToStorage(item)
 	}
 
 	_, err := sa.db.customContents.InsertMany(sa.context, storeItems, nil)
@@ -675,13 +748,17 @@ func (sa *Adapter) UpdateCustomContent(key string, item model.Content) error {
 	errArgs := logutils.FieldArgs(filter)
 	update := bson.M{
 		"$set": bson.M{
-			"type":           item.Type,
-			"details":        item.Details,
-			"name":           item.Name,
-			"reference":      item.Reference,
-			"linked_content": item.LinkedContent,
-			"styles":         item.Styles,
-			"date_updated":   time.Now(),
+			"type":            item.Type,
+			"details":         item.Details,
+			"name":            item.Name,
+			"reference":       item.Reference,
+			"linked_content":  item.LinkedContent,
+			"evaluation":      item.Evaluation,
+			"response_schema": item.ResponseSchema,
+			"localizations":   item.Localizations,
+			"search_text":     item.Localizations.SearchText(),
+			"styles":          item.Styles,
+			"date_updated":    time.Now(),
 		},
 	}
 	result, err := sa.db.customContents.UpdateOne(sa.context, filter, update, nil)
@@ -766,6 +843,9 @@ func (sa *Adapter) UpdateCourseConfig(config model.CourseConfig) error {
 			"initial_pauses":               config.InitialPauses,
 			"max_pauses":                   config.MaxPauses,
 			"pause_progress_reward":        config.PauseProgressReward,
+			"max_freeze_length":            config.MaxFreezeLength,
+			"leaderboard_active":           config.LeaderboardActive,
+			"reset_streaks_on_restart":     config.ResetStreaksOnRestart,
 			"streaks_notifications_config": config.StreaksNotificationsConfig,
 			"date_updated":                 time.Now().UTC(),
 		},
@@ -943,9 +1023,18 @@ func (sa *Adapter) FindUserCourses(id []string, appID string, orgID string, name
 		return nil, nil
 	}
 
+	refs := make([]courseVersionRef, len(result))
+	for i, retrievedResult := range result {
+		refs[i] = userCourseVersionRef(retrievedResult)
+	}
+	snapshots, err := sa.findCourseVersionSnapshots(refs)
+	if err != nil {
+		return nil, err
+	}
+
 	var convertedResult []model.UserCourse
 	for _, retrievedResult := range result {
-		singleConverted, err := sa.userCourseFromStorage(retrievedResult)
+		singleConverted, err := sa.userCourseFromStorage(retrievedResult, snapshots)
 		if err != nil {
 			return nil, err
 		}
@@ -967,7 +1056,7 @@ func (sa *Adapter) FindUserCourse(appID string, orgID string, userID string, cou
 		return nil, nil
 	}
 
-	convertedResult, err := sa.userCourseFromStorage(result[0])
+	convertedResult, err := sa.userCourseFromStorage(result[0], nil)
 	if err != nil {
 		return nil, err
 	}
@@ -990,30 +1079,182 @@ func (sa *Adapter) InsertUserCourse(item model.UserCourse) error {
 func (sa *Adapter) UpdateUserCourse(item model.UserCourse) error {
 	filter := bson.M{"app_id": item.AppID, "org_id": item.OrgID, "course.key": item.Course.Key, "user_id": item.UserID}
 	errArgs := logutils.FieldArgs(filter)
+	// streak processing pushes to these arrays, so they must never be set to null
+	streakResets := item.StreakResets
+	if streakResets == nil {
+		streakResets = make([]time.Time, 0)
+	}
+	pauseUses := item.PauseUses
+	if pauseUses == nil {
+		pauseUses = make([]time.Time, 0)
+	}
 	update := bson.M{
 		"$set": bson.M{
-			"streak":            item.Streak,
-			"pauses":            item.Pauses,
-			"pause_progress":    item.PauseProgress,
-			"streak_restarts":   item.StreakRestarts,
-			"last_completed":    item.LastCompleted,
-			"last_responded":    item.LastResponded,
-			"completed_modules": item.CompletedModules,
-			"date_completed":    item.DateCompleted,
-			"date_dropped":      item.DateDropped,
-			"date_updated":      time.Now().UTC(),
+			"streak":              item.Streak,
+			"streak_resets":       streakResets,
+			"streak_before_reset": item.StreakBeforeReset,
+			"pauses":              item.Pauses,
+			"pause_progress":      item.PauseProgress,
+			"pause_uses":          pauseUses,
+			"streak_restarts":     item.StreakRestarts,
+			"streak_freezes":      item.StreakFreezes,
+			"leaderboard_name":    item.LeaderboardName,
+			"last_completed":      item.LastCompleted,
+			"last_responded":      item.LastResponded,
+			"completed_modules":   item.CompletedModules,
+			"date_completed":      item.DateCompleted,
+			"date_dropped":        item.DateDropped,
+			"date_updated":        time.Now().UTC(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
-	result, err := sa.db.userCourses.UpdateOne(sa.context, filter, update, nil)
+	result, err := sa.db.userCourses.UpdateOne(sa.context, versionedFilter(filter, item.Version), update, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserCourse, &errArgs, err)
 	}
 	if result.MatchedCount == 0 {
-		return errors.WrapErrorData(logutils.StatusMissing, model.TypeUserCourse, &errArgs, err)
+		return sa.versionedUpdateError(sa.db.userCourses, filter, model.TypeUserCourse, item.Version)
 	}
 	return nil
 }
 
+// versionedFilter adds the version a document was read at to an update filter so that the update fails if the document has been updated since
+func versionedFilter(filter bson.M, version int) bson.M {
+	result := bson.M{"version": version}
+	if version == 0 {
+		// documents created before versioning have no version
+		result["version"] = bson.M{"$in": bson.A{nil, 0}}
+	}
+	for key, value := range filter {
+		result[key] = value
+	}
+	return result
+}
+
+// versionedUpdateError returns a conflict error if a versioned update matched no document because the document has been updated since it was read, or a missing error otherwise
+func (sa *Adapter) versionedUpdateError(collection *collectionWrapper, filter bson.M, dataType logutils.MessageDataType, version int) error {
+	errArgs := logutils.FieldArgs(filter)
+	count, err := collection.CountDocuments(sa.context, filter)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCount, dataType, &errArgs, err)
+	}
+	if count == 0 {
+		return errors.ErrorData(logutils.StatusMissing, dataType, &errArgs)
+	}
+
+	errArgs["version"] = version
+	return errors.ErrorData(logutils.StatusInvalid, dataType, &errArgs).SetStatus(model.ErrorStatusConflict)
+}
+
+// FindLeaderboardEntries finds the users enrolled in a course who have not dropped it sorted in descending order by the leaderboard metric
+func (sa *Adapter) FindLeaderboardEntries(appID string, orgID string, courseKey string, metric string) ([]model.LeaderboardEntry, error) {
+	var value interface{}
+	switch metric {
+	case model.LeaderboardMetricStreak:
+		value = "$streak"
+	case model.LeaderboardMetricModules:
+		value = bson.M{"$size": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$completed_modules", bson.M{}}}}}
+	case model.LeaderboardMetricScheduleItems:
+		value = bson.M{"$sum": "$units.completed"}
+	default:
+		return nil, errors.ErrorData(logutils.StatusInvalid, "leaderboard metric", &logutils.FieldArgs{"metric": metric})
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "course.key": courseKey, "date_dropped": nil}},
+	}
+	if metric == model.LeaderboardMetricScheduleItems {
+		pipeline = append(pipeline, bson.M{"$lookup": bson.M{
+			"from": "user_units",
+			"let":  bson.M{"user_id": "$user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "course_key": courseKey, "$expr": bson.M{"$eq": bson.A{"$user_id", "$$user_id"}}}},
+				bson.M{"$project": bson.M{"completed": 1}},
+			},
+			"as": "units",
+		}})
+	}
+	pipeline = append(pipeline,
+		bson.M{"$project": bson.M{"_id": 0, "user_id": 1, "leaderboard_name": 1, "value": value}},
+		bson.M{"$sort": bson.D{{Key: "value", Value: -1}, {Key: "user_id", Value: 1}}},
+	)
+
+	var result []model.LeaderboardEntry
+	err := sa.db.userCourses.Aggregate(sa.context, pipeline, &result, nil)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLeaderboard, &logutils.FieldArgs{"app_id": appID, "org_id": orgID, "course_key": courseKey, "metric": metric}, err)
+	}
+
+	return result, nil
+}
+
+// FindLearnerProgress finds the progress of the users enrolled in a course matching the filter sorted by user ID
+func (sa *Adapter) FindLearnerProgress(appID string, orgID string, courseKey string, filter model.LearnerProgressFilter, limit int, offset int) ([]model.LearnerProgress, error) {
+	match := bson.M{"app_id": appID, "org_id": orgID, "course.key": courseKey}
+	if filter.CohortKey != nil {
+		match["cohort_key"] = *filter.CohortKey
+	}
+	if filter.InactiveSince != nil {
+		match["$or"] = bson.A{
+			bson.M{"last_responded": bson.M{"$lt": *filter.InactiveSince}},
+			bson.M{"last_responded": nil, "date_created": bson.M{"$lt": *filter.InactiveSince}},
+		}
+	}
+	if filter.StreakResetSince != nil {
+		match["streak_resets"] = bson.M{"$elemMatch": bson.M{"$gte": *filter.StreakResetSince}}
+	}
+	if filter.Completed != nil {
+		if *filter.Completed {
+			match["date_completed"] = bson.M{"$ne": nil}
+		} else {
+			match["date_completed"] = nil
+		}
+	}
+	if filter.Dropped != nil {
+		if *filter.Dropped {
+			match["date_dropped"] = bson.M{"$ne": nil}
+		} else {
+			match["date_dropped"] = nil
+		}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$sort": bson.M{"user_id": 1}},
+		bson.M{"$skip": offset},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+	pipeline = append(pipeline,
+		bson.M{"$lookup": bson.M{
+			"from": "user_units",
+			"let":  bson.M{"user_id": "$user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"app_id": appID, "org_id": orgID, "course_key": courseKey, "current": true, "$expr": bson.M{"$eq": bson.A{"$user_id", "$$user_id"}}}},
+				bson.M{"$sort": bson.D{{Key: "date_updated", Value: -1}, {Key: "date_created", Value: -1}}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"module_key": 1, "unit.key": 1}},
+			},
+			"as": "current",
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "user_id": 1, "cohort_key": 1, "streak": 1, "streak_resets": 1, "pauses": 1,
+			"completed_modules":  bson.M{"$size": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$completed_modules", bson.M{}}}}},
+			"current_module_key": bson.M{"$arrayElemAt": bson.A{"$current.module_key", 0}},
+			"current_unit_key":   bson.M{"$arrayElemAt": bson.A{"$current.unit.key", 0}},
+			"last_completed":     1, "last_responded": 1, "date_created": 1, "date_completed": 1, "date_dropped": 1}},
+	)
+
+	var result []model.LearnerProgress
+	err := sa.db.userCourses.Aggregate(sa.context, pipeline, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(match)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeLearnerProgress, &errArgs, err)
+	}
+
+	return result, nil
+}
+
 // UpdateUserTimezone updates a user's timezone information in all its related userCourse storage struct
 func (sa *Adapter) UpdateUserTimezone(appID string, orgID string, userID string, timezoneName string, timezoneOffset int) error {
 	filter := bson.M{"app_id": appID, "org_id": orgID, "user_id": userID}
@@ -1094,7 +1335,7 @@ func (sa *Adapter) FindUserUnit(appID string, orgID string, userID string, cours
 	}
 
 	// no function needs to return UserUnit so not implementating this function yet
-	convertedResult, err := sa.userUnitFromStorage(results[0])
+	convertedResult, err := sa.userUnitFromStorage(results[0], nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1122,9 +1363,18 @@ func (sa *Adapter) FindUserUnits(appID string, orgID string, userIDs []string, c
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, &errArgs, err)
 	}
 
+	refs := make([]courseVersionRef, len(results))
+	for i, result := range results {
+		refs[i] = userUnitVersionRef(result)
+	}
+	snapshots, err := sa.findCourseVersionSnapshots(refs)
+	if err != nil {
+		return nil, err
+	}
+
 	userUnits := make([]model.UserUnit, len(results))
 	for i, result := range results {
-		convertedResult, err := sa.userUnitFromStorage(result)
+		convertedResult, err := sa.userUnitFromStorage(result, snapshots)
 		if err != nil {
 			return nil, err
 		}
@@ -1145,9 +1395,18 @@ func (sa *Adapter) FindUserUnitsByUserID(userID string) ([]model.UserUnit, error
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserUnit, &errArgs, err)
 	}
 
+	refs := make([]courseVersionRef, len(results))
+	for i, result := range results {
+		refs[i] = userUnitVersionRef(result)
+	}
+	snapshots, err := sa.findCourseVersionSnapshots(refs)
+	if err != nil {
+		return nil, err
+	}
+
 	userUnits := make([]model.UserUnit, len(results))
 	for i, result := range results {
-		convertedResult, err := sa.userUnitFromStorage(result)
+		convertedResult, err := sa.userUnitFromStorage(result, snapshots)
 		if err != nil {
 			return nil, err
 		}
@@ -1179,13 +1438,14 @@ func (sa *Adapter) UpdateUserUnit(item model.UserUnit) error {
 			"current":       item.Current,
 			"date_updated":  time.Now().UTC(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
-	result, err := sa.db.userUnits.UpdateOne(sa.context, filter, update, nil)
+	result, err := sa.db.userUnits.UpdateOne(sa.context, versionedFilter(filter, item.Version), update, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserUnit, &errArgs, err)
 	}
 	if result.MatchedCount == 0 {
-		return errors.ErrorData(logutils.StatusMissing, model.TypeUserUnit, &errArgs)
+		return sa.versionedUpdateError(sa.db.userUnits, filter, model.TypeUserUnit, item.Version)
 	}
 	return nil
 }
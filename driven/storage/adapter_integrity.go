@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindCustomReferences finds the keys referred to by all custom courses, modules, units and contents of an app/org as stored
+func (sa *Adapter) FindCustomReferences(appID string, orgID string) (*model.CustomReferences, error) {
+	var result model.CustomReferences
+	var err error
+
+	result.Courses, err = sa.findItemReferences(sa.db.customCourses, appID, orgID, "module_keys", model.TypeCourse)
+	if err != nil {
+		return nil, err
+	}
+	result.Modules, err = sa.findItemReferences(sa.db.customModules, appID, orgID, "unit_keys", model.TypeModule)
+	if err != nil {
+		return nil, err
+	}
+	result.Units, err = sa.findItemReferences(sa.db.customUnits, appID, orgID, "content_keys", model.TypeUnit)
+	if err != nil {
+		return nil, err
+	}
+	result.Contents, err = sa.findItemReferences(sa.db.customContents, appID, orgID, "linked_content", model.TypeContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (sa *Adapter) findItemReferences(coll *collectionWrapper, appID string, orgID string, keysField string, dataType logutils.MessageDataType) ([]model.ItemReferences, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID}
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$project": bson.M{"_id": 0, "key": 1, "keys": bson.M{"$ifNull": bson.A{"$" + keysField, bson.A{}}}, "schedule": 1, "required": 1}},
+	}
+
+	var result []model.ItemReferences
+	err := coll.Aggregate(sa.context, pipeline, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, dataType, &errArgs, err)
+	}
+	return result, nil
+}
+
+// UpdateCustomReferences replaces the keys referred to by the given custom courses, modules, units and contents of an app/org
+func (sa *Adapter) UpdateCustomReferences(appID string, orgID string, refs model.CustomReferences) error {
+	err := sa.updateItemReferences(sa.db.customCourses, appID, orgID, refs.Courses, func(item model.ItemReferences) bson.M {
+		return bson.M{"module_keys": item.Keys}
+	}, model.TypeCourse)
+	if err != nil {
+		return err
+	}
+	err = sa.updateItemReferences(sa.db.customModules, appID, orgID, refs.Modules, func(item model.ItemReferences) bson.M {
+		return bson.M{"unit_keys": item.Keys}
+	}, model.TypeModule)
+	if err != nil {
+		return err
+	}
+	err = sa.updateItemReferences(sa.db.customUnits, appID, orgID, refs.Units, func(item model.ItemReferences) bson.M {
+		return bson.M{"content_keys": item.Keys, "schedule": item.Schedule, "required": item.Required}
+	}, model.TypeUnit)
+	if err != nil {
+		return err
+	}
+	return sa.updateItemReferences(sa.db.customContents, appID, orgID, refs.Contents, func(item model.ItemReferences) bson.M {
+		return bson.M{"linked_content": item.Keys}
+	}, model.TypeContent)
+}
+
+func (sa *Adapter) updateItemReferences(coll *collectionWrapper, appID string, orgID string, items []model.ItemReferences, set func(item model.ItemReferences) bson.M, dataType logutils.MessageDataType) error {
+	for _, item := range items {
+		filter := bson.M{"org_id": orgID, "app_id": appID, "key": item.Key}
+		_, err := coll.UpdateOne(sa.context, filter, bson.M{"$set": set(item)}, nil)
+		if err != nil {
+			errArgs := logutils.FieldArgs(filter)
+			return errors.WrapErrorAction(logutils.ActionUpdate, dataType, &errArgs, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindProgressArchives finds the previous attempts of a user in a course, most recent first
+func (sa *Adapter) FindProgressArchives(appID string, orgID string, userID string, courseKey string) ([]model.ProgressArchive, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": userID, "course_key": courseKey}
+	opts := options.Find().SetSort(bson.D{{Key: "date_created", Value: -1}})
+	var results []progressArchive
+	err := sa.db.progressArchives.Find(sa.context, filter, &results, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeProgressArchive, &errArgs, err)
+	}
+
+	archives := make([]model.ProgressArchive, len(results))
+	for i, result := range results {
+		archive, err := sa.progressArchiveFromStorage(result)
+		if err != nil {
+			return nil, err
+		}
+		archives[i] = archive
+	}
+	return archives, nil
+}
+
+// InsertProgressArchive inserts a previous attempt of a user
+func (sa *Adapter) InsertProgressArchive(item model.ProgressArchive) error {
+	_, err := sa.db.progressArchives.InsertOne(sa.context, sa.progressArchiveToStorage(item))
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeProgressArchive, &logutils.FieldArgs{"user_id": item.UserID, "course_key": item.CourseKey}, err)
+	}
+	return nil
+}
+
+// DeleteProgressArchives deletes the previous attempts of a user in a course
+func (sa *Adapter) DeleteProgressArchives(appID string, orgID string, userID string, courseKey string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": userID, "course_key": courseKey}
+	_, err := sa.db.progressArchives.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeProgressArchive, &errArgs, err)
+	}
+	return nil
+}
+
+// DeleteProgressArchivesByAccountsIDs deletes the previous attempts of the given accounts
+func (sa *Adapter) DeleteProgressArchivesByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.progressArchives.DeleteMany(nil, filter, nil)
+	return err
+}
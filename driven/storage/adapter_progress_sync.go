@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindProgressSyncReceipts finds the receipts of the responses a user has synced with the given idempotency keys
+func (sa *Adapter) FindProgressSyncReceipts(appID string, orgID string, userID string, idempotencyKeys []string) ([]model.ProgressSyncReceipt, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": userID, "idempotency_key": bson.M{"$in": idempotencyKeys}}
+	var result []model.ProgressSyncReceipt
+	err := sa.db.progressSyncReceipts.Find(sa.context, filter, &result, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeProgressSyncReceipt, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// InsertProgressSyncReceipt inserts a progress sync receipt (fails if the response has already been synced)
+func (sa *Adapter) InsertProgressSyncReceipt(item model.ProgressSyncReceipt) error {
+	_, err := sa.db.progressSyncReceipts.InsertOne(sa.context, item)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeProgressSyncReceipt, &logutils.FieldArgs{"user_id": item.UserID, "idempotency_key": item.IdempotencyKey}, err)
+	}
+	return nil
+}
+
+// DeleteProgressSyncReceiptsByAccountsIDs deletes the progress sync receipts of the given accounts
+func (sa *Adapter) DeleteProgressSyncReceiptsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.progressSyncReceipts.DeleteMany(nil, filter, nil)
+	return err
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchCustomModules finds the modules matching a text search sorted by relevance, optionally only within the given keys
+func (sa *Adapter) SearchCustomModules(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error) {
+	return sa.searchCustom(sa.db.customModules, model.SearchResultTypeModule, appID, orgID, text, keys, limit)
+}
+
+// SearchCustomUnits finds the units matching a text search sorted by relevance, optionally only within the given keys
+func (sa *Adapter) SearchCustomUnits(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error) {
+	return sa.searchCustom(sa.db.customUnits, model.SearchResultTypeUnit, appID, orgID, text, keys, limit)
+}
+
+// SearchCustomContents finds the contents matching a text search sorted by relevance, optionally only within the given keys
+func (sa *Adapter) SearchCustomContents(appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error) {
+	return sa.searchCustom(sa.db.customContents, model.SearchResultTypeContent, appID, orgID, text, keys, limit)
+}
+
+// searchCustom runs a text search on a collection with a text index (keys restrict the search if not nil)
+func (sa *Adapter) searchCustom(coll *collectionWrapper, resultType string, appID string, orgID string, text string, keys []string, limit int) ([]model.SearchResult, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "$text": bson.M{"$search": text}}
+	if keys != nil {
+		filter["key"] = bson.M{"$in": keys}
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().SetProjection(bson.M{"key": 1, "name": 1, "score": score}).SetSort(bson.D{{Key: "score", Value: score}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	var result []model.SearchResult
+	err := coll.Find(sa.context, filter, &result, opts)
+	if err != nil {
+		errArgs := logutils.FieldArgs{"app_id": appID, "org_id": orgID, "type": resultType, "text": text}
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSearchResult, &errArgs, err)
+	}
+
+	for i := range result {
+		result[i].Type = resultType
+	}
+	return result, nil
+}
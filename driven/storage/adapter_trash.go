@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"lms/core/model"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TrashCustomCourse moves a course with its user courses, achievements, user achievements, published versions and cohorts to the trash
+func (sa *Adapter) TrashCustomCourse(item model.TrashItem) error {
+	trash := trashItem{TrashItem: item}
+	filter := bson.M{"org_id": item.OrgID, "app_id": item.AppID, "course_key": item.Key}
+	err := sa.trashItemDocument(&trash, sa.db.customCourses, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}, "name")
+	if err != nil {
+		return err
+	}
+	err = sa.trashDocuments(&trash, sa.db.userCourses, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "course.key": item.Key})
+	if err != nil {
+		return err
+	}
+	for _, coll := range []*collectionWrapper{sa.db.achievements, sa.db.userAchievements, sa.db.courseVersions, sa.db.cohorts} {
+		err = sa.trashDocuments(&trash, coll, filter)
+		if err != nil {
+			return err
+		}
+	}
+	return sa.insertTrashItem(trash)
+}
+
+// TrashCustomModule moves a module to the trash and removes it from the courses containing it
+func (sa *Adapter) TrashCustomModule(item model.TrashItem) error {
+	trash := trashItem{TrashItem: item}
+	err := sa.trashItemDocument(&trash, sa.db.customModules, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}, "name")
+	if err != nil {
+		return err
+	}
+	err = sa.trashReferences(&trash, sa.db.customCourses, "module_keys")
+	if err != nil {
+		return err
+	}
+	return sa.insertTrashItem(trash)
+}
+
+// TrashCustomUnit moves a unit to the trash and removes it from the modules containing it
+func (sa *Adapter) TrashCustomUnit(item model.TrashItem) error {
+	trash := trashItem{TrashItem: item}
+	err := sa.trashItemDocument(&trash, sa.db.customUnits, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}, "name")
+	if err != nil {
+		return err
+	}
+	err = sa.trashReferences(&trash, sa.db.customModules, "unit_keys")
+	if err != nil {
+		return err
+	}
+	return sa.insertTrashItem(trash)
+}
+
+// TrashCustomContent moves a content to the trash and removes it from the units and linked content of other contents containing it
+func (sa *Adapter) TrashCustomContent(item model.TrashItem) error {
+	trash := trashItem{TrashItem: item}
+	err := sa.trashItemDocument(&trash, sa.db.customContents, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "key": item.Key}, "name")
+	if err != nil {
+		return err
+	}
+	err = sa.trashReferences(&trash, sa.db.customContents, "linked_content")
+	if err != nil {
+		return err
+	}
+	err = sa.trashReferences(&trash, sa.db.customUnits, "content_keys")
+	if err != nil {
+		return err
+	}
+	return sa.insertTrashItem(trash)
+}
+
+// TrashCourseConfig moves the config of a course to the trash
+func (sa *Adapter) TrashCourseConfig(item model.TrashItem) error {
+	trash := trashItem{TrashItem: item}
+	err := sa.trashItemDocument(&trash, sa.db.courseConfigs, bson.M{"org_id": item.OrgID, "app_id": item.AppID, "course_key": item.Key}, "course_key")
+	if err != nil {
+		return err
+	}
+	return sa.insertTrashItem(trash)
+}
+
+// FindTrashItems finds the trash items which have not expired sorted by most recently deleted, optionally of a single type
+func (sa *Adapter) FindTrashItems(appID string, orgID string, itemType *string) ([]model.TrashItem, error) {
+	// expired items may remain until they are purged by the TTL index
+	filter := bson.M{"org_id": orgID, "app_id": appID, "date_expires": bson.M{"$gt": time.Now().UTC()}}
+	if itemType != nil {
+		filter["type"] = *itemType
+	}
+
+	var result []model.TrashItem
+	err := sa.db.trash.Find(sa.context, filter, &result, options.Find().SetSort(bson.D{{Key: "date_deleted", Value: -1}}).SetProjection(bson.M{"references": 0}))
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, &errArgs, err)
+	}
+
+	return result, nil
+}
+
+// FindTrashItem finds a trash item by ID (nil if it does not exist or has expired)
+func (sa *Adapter) FindTrashItem(appID string, orgID string, id string) (*model.TrashItem, error) {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "_id": id, "date_expires": bson.M{"$gt": time.Now().UTC()}}
+	var result []model.TrashItem
+	err := sa.db.trash.Find(sa.context, filter, &result, options.Find().SetProjection(bson.M{"references": 0}))
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, &errArgs, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// RestoreTrashItem reinserts all documents moved to the trash with an item, adds the item back to the documents which referenced it if they still exist, and removes it from the trash
+func (sa *Adapter) RestoreTrashItem(appID string, orgID string, id string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "_id": id, "date_expires": bson.M{"$gt": time.Now().UTC()}}
+	errArgs := logutils.FieldArgs(filter)
+	var items []trashItem
+	err := sa.db.trash.Find(sa.context, filter, &items, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, model.TypeTrashItem, &errArgs, err)
+	}
+	if len(items) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeTrashItem, &errArgs)
+	}
+	trash := items[0]
+
+	var documents []trashDocument
+	err = sa.db.trashDocuments.Find(sa.context, bson.M{"trash_id": id}, &documents, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, "trash document", &errArgs, err)
+	}
+	byCollection := make(map[string][]interface{})
+	for _, document := range documents {
+		byCollection[document.Collection] = append(byCollection[document.Collection], document.Document)
+	}
+	for name, collDocuments := range byCollection {
+		coll := sa.trashCollection(name)
+		if coll == nil {
+			return errors.ErrorData(logutils.StatusInvalid, "trash collection", &logutils.FieldArgs{"name": name})
+		}
+		// fails if a document with the same key has been created since the item was deleted
+		_, err = coll.InsertMany(sa.context, collDocuments, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, "trash document", &logutils.FieldArgs{"collection": name, "trash_id": id}, err)
+		}
+	}
+
+	// restore references in order of position so that each key returns to its original index
+	sort.SliceStable(trash.References, func(i, j int) bool {
+		return trash.References[i].Index < trash.References[j].Index
+	})
+	for _, reference := range trash.References {
+		coll := sa.trashCollection(reference.Collection)
+		if coll == nil {
+			return errors.ErrorData(logutils.StatusInvalid, "trash collection", &logutils.FieldArgs{"name": reference.Collection})
+		}
+		referenceFilter := bson.M{"org_id": orgID, "app_id": appID, "key": reference.Key, reference.Field: bson.M{"$ne": trash.Key}}
+		update := bson.M{"$push": bson.M{reference.Field: bson.M{"$each": []string{trash.Key}, "$position": reference.Index}}}
+		_, err = coll.UpdateOne(sa.context, referenceFilter, update, nil)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, "trash reference", &logutils.FieldArgs{"collection": reference.Collection, "key": reference.Key}, err)
+		}
+	}
+
+	return sa.DeleteTrashItem(appID, orgID, id)
+}
+
+// DeleteTrashItem permanently deletes a trash item and the documents moved to the trash with it
+func (sa *Adapter) DeleteTrashItem(appID string, orgID string, id string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "_id": id}
+	errArgs := logutils.FieldArgs(filter)
+	result, err := sa.db.trash.DeleteOne(sa.context, filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeTrashItem, &errArgs, err)
+	}
+	if result.DeletedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeTrashItem, &errArgs)
+	}
+
+	_, err = sa.db.trashDocuments.DeleteMany(sa.context, bson.M{"trash_id": id}, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, "trash document", &errArgs, err)
+	}
+	return nil
+}
+
+// DeleteTrashDocumentsByAccountsIDs permanently deletes the user data of the given accounts from the trash
+func (sa *Adapter) DeleteTrashDocumentsByAccountsIDs(log *logs.Log, appID string, orgID string, accountsIDs []string) error {
+	filter := bson.M{"org_id": orgID, "app_id": appID, "document.user_id": bson.M{"$in": accountsIDs}}
+	_, err := sa.db.trashDocuments.DeleteMany(nil, filter, nil)
+	return err
+}
+
+// trashItemDocument moves the document of the deleted item to the trash, naming the item by the given field
+func (sa *Adapter) trashItemDocument(trash *trashItem, coll *collectionWrapper, filter bson.M, nameField string) error {
+	itemType := logutils.MessageDataType(trash.Type)
+	var documents []bson.Raw
+	err := coll.Find(sa.context, filter, &documents, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionFind, itemType, &errArgs, err)
+	}
+	if len(documents) == 0 {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.ErrorData(logutils.StatusMissing, itemType, &errArgs)
+	}
+	trash.Name, _ = documents[0].Lookup(nameField).StringValueOK()
+
+	return sa.moveToTrash(trash, coll, filter, documents)
+}
+
+// trashDocuments moves the documents matching the filter to the trash
+func (sa *Adapter) trashDocuments(trash *trashItem, coll *collectionWrapper, filter bson.M) error {
+	var documents []bson.Raw
+	err := coll.Find(sa.context, filter, &documents, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionFind, "trash document", &errArgs, err)
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	return sa.moveToTrash(trash, coll, filter, documents)
+}
+
+func (sa *Adapter) moveToTrash(trash *trashItem, coll *collectionWrapper, filter bson.M, documents []bson.Raw) error {
+	name := coll.coll.Name()
+	trashDocuments := make([]interface{}, len(documents))
+	for i, document := range documents {
+		trashDocuments[i] = trashDocument{ID: uuid.NewString(), TrashID: trash.ID, AppID: trash.AppID, OrgID: trash.OrgID, Collection: name,
+			Document: document, DateExpires: trash.DateExpires}
+	}
+	_, err := sa.db.trashDocuments.InsertMany(sa.context, trashDocuments, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, "trash document", &logutils.FieldArgs{"collection": name}, err)
+	}
+
+	_, err = coll.DeleteMany(sa.context, filter, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionDelete, "trash document", &errArgs, err)
+	}
+
+	trash.Counts[name] += len(documents)
+	return nil
+}
+
+// trashReferences removes the key of the deleted item from the array field of the documents referencing it, recording where it was so it can be restored
+func (sa *Adapter) trashReferences(trash *trashItem, coll *collectionWrapper, field string) error {
+	name := coll.coll.Name()
+	filter := bson.M{"org_id": trash.OrgID, "app_id": trash.AppID, field: trash.Key}
+	var documents []bson.M
+	err := coll.Find(sa.context, filter, &documents, options.Find().SetProjection(bson.M{"key": 1, field: 1}))
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionFind, "trash reference", &errArgs, err)
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	for _, document := range documents {
+		key, _ := document["key"].(string)
+		values, _ := document[field].(bson.A)
+		for i, value := range values {
+			if value == trash.Key {
+				trash.References = append(trash.References, trashReference{Collection: name, Key: key, Field: field, Index: i})
+				break
+			}
+		}
+	}
+
+	_, err = coll.UpdateMany(sa.context, filter, bson.M{"$pull": bson.M{field: trash.Key}}, nil)
+	if err != nil {
+		errArgs := logutils.FieldArgs(filter)
+		return errors.WrapErrorAction(logutils.ActionUpdate, "trash reference", &errArgs, err)
+	}
+
+	trash.Counts[name+"_references"] += len(documents)
+	return nil
+}
+
+func (sa *Adapter) insertTrashItem(trash trashItem) error {
+	_, err := sa.db.trash.InsertOne(sa.context, trash)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeTrashItem, &logutils.FieldArgs{"type": trash.Type, "key": trash.Key}, err)
+	}
+	return nil
+}
+
+// trashCollection returns the collection with the given name which documents may be restored to
+func (sa *Adapter) trashCollection(name string) *collectionWrapper {
+	for _, coll := range []*collectionWrapper{sa.db.customCourses, sa.db.customModules, sa.db.customUnits, sa.db.customContents, sa.db.courseConfigs,
+		sa.db.userCourses, sa.db.achievements, sa.db.userAchievements, sa.db.courseVersions, sa.db.cohorts} {
+		if coll.coll.Name() == name {
+			return coll
+		}
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"lms/core/model"
+
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
 
 // customCourseToStorage formats API struct to storage struct
@@ -19,6 +22,9 @@ func (sa *Adapter) customCourseToStorage(item model.Course) course {
 	course.Key = item.Key
 	course.Name = item.Name
 	course.ModuleKeys = moduleKeys
+	course.Availability = item.Availability
+	course.Visibility = item.Visibility
+	course.Localizations = item.Localizations
 	course.DateCreated = item.DateCreated
 	course.DateUpdated = item.DateUpdated
 
@@ -33,6 +39,9 @@ func (sa *Adapter) customCourseFromStorage(item course) (model.Course, error) {
 	result.OrgID = item.OrgID
 	result.Key = item.Key
 	result.Name = item.Name
+	result.Availability = item.Availability
+	result.Visibility = item.Visibility
+	result.Localizations = item.Localizations
 	result.DateCreated = item.DateCreated
 	result.DateUpdated = item.DateUpdated
 	if len(item.ModuleKeys) > 0 {
@@ -62,6 +71,8 @@ func (sa *Adapter) customModuleFromStorage(item module) (model.Module, error) {
 	result.OrgID = item.OrgID
 	result.Key = item.Key
 	result.Name = item.Name
+	result.Prerequisites = item.Prerequisites
+	result.Localizations = item.Localizations
 	result.Styles = item.Styles
 	result.DateCreated = item.DateCreated
 	result.DateUpdated = item.DateUpdated
@@ -100,6 +111,9 @@ func (sa *Adapter) customModuleToStorage(item model.Module) module {
 	module.Key = item.Key
 	module.Name = item.Name
 	module.UnitKeys = unitKeys
+	module.Prerequisites = item.Prerequisites
+	module.Localizations = item.Localizations
+	module.SearchText = item.Localizations.SearchText()
 	module.Styles = item.Styles
 	module.DateCreated = item.DateCreated
 	module.DateUpdated = item.DateUpdated
@@ -110,7 +124,7 @@ func (sa *Adapter) customModuleToStorage(item model.Module) module {
 // customUnitFromStorage formats storage struct to appropriate struct for API request
 func (sa *Adapter) customUnitFromStorage(item unit) (model.Unit, error) {
 	result := model.Unit{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, Key: item.Key, Name: item.Name, Schedule: item.Schedule,
-		Required: item.Required, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+		Required: item.Required, Localizations: item.Localizations, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
 
 	if len(item.ContentKeys) > 0 {
 		contents, err := sa.FindCustomContents(item.AppID, item.OrgID, nil, nil, item.ContentKeys)
@@ -138,19 +152,42 @@ func (sa *Adapter) customUnitToStorage(item model.Unit) unit {
 	result.ContentKeys = extractedKey
 	result.Schedule = item.Schedule
 	result.Required = item.Required
+	result.Localizations = item.Localizations
+	result.SearchText = item.Localizations.SearchText()
 	result.DateCreated = item.DateCreated
 	result.DateUpdated = item.DateUpdated
 
 	return result
 }
 
+// customContentToStorage adds the localized text indexed for search to a content
+func (sa *Adapter) customContentToStorage(item model.Content) content {
+	return content{Content: item, SearchText: item.Localizations.SearchText()}
+}
+
 // userCourseConversionHelper formats storage struct to appropriate struct for API request
-func (sa *Adapter) userCourseFromStorage(item userCourse) (model.UserCourse, error) {
+//
+//	snapshots holds the course versions of the batch the user course was found in (nil if it was found alone)
+func (sa *Adapter) userCourseFromStorage(item userCourse, snapshots courseVersionSnapshots) (model.UserCourse, error) {
 	timezone := model.Timezone{Name: item.TimezoneName, Offset: item.TimezoneOffset}
 	result := model.UserCourse{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, Timezone: timezone, Streak: item.Streak,
-		StreakResets: item.StreakResets, StreakRestarts: item.StreakRestarts, Pauses: item.Pauses, PauseProgress: item.PauseProgress, PauseUses: item.PauseUses,
-		LastCompleted: item.LastCompleted, LastResponded: item.LastResponded, CompletedModules: item.CompletedModules, DateCreated: item.DateCreated,
-		DateUpdated: item.DateUpdated, DateCompleted: item.DateCompleted, DateDropped: item.DateDropped}
+		StreakResets: item.StreakResets, StreakRestarts: item.StreakRestarts, Pauses: item.Pauses, PauseProgress: item.PauseProgress, PauseUses: item.PauseUses, StreakBeforeReset: item.StreakBeforeReset,
+		StreakFreezes: item.StreakFreezes, LeaderboardName: item.LeaderboardName, LastCompleted: item.LastCompleted, LastResponded: item.LastResponded, CompletedModules: item.CompletedModules, DateCreated: item.DateCreated,
+		DateUpdated: item.DateUpdated, DateCompleted: item.DateCompleted, DateDropped: item.DateDropped, CourseVersion: item.CourseVersion, Version: item.Version,
+		CohortKey: item.CohortKey, CohortStart: item.CohortStart}
+
+	// users on a published version see the version snapshot instead of the current draft
+	if item.CourseVersion > 0 {
+		courseVersion, err := sa.findSnapshotCourseVersion(snapshots, userCourseVersionRef(item))
+		if err != nil {
+			return result, err
+		}
+		if courseVersion == nil {
+			return result, errors.ErrorData(logutils.StatusMissing, model.TypeCourseVersion, &logutils.FieldArgs{"course_key": item.Course.Key, "version": item.CourseVersion})
+		}
+		result.Course = courseVersion.Course
+		return result, nil
+	}
 
 	convertedCourse, err := sa.customCourseFromStorage(item.Course)
 	if err != nil {
@@ -161,17 +198,41 @@ func (sa *Adapter) userCourseFromStorage(item userCourse) (model.UserCourse, err
 	return result, nil
 }
 
+func userCourseVersionRef(item userCourse) courseVersionRef {
+	return courseVersionRef{AppID: item.AppID, OrgID: item.OrgID, CourseKey: item.Course.Key, Version: item.CourseVersion}
+}
+
 func (sa *Adapter) userCourseToStorage(item model.UserCourse) userCourse {
 	course := sa.customCourseToStorage(item.Course)
 	return userCourse{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, TimezoneName: item.Timezone.Name, TimezoneOffset: item.Timezone.Offset,
-		Streak: item.Streak, StreakResets: item.StreakResets, StreakRestarts: item.StreakRestarts, Pauses: item.Pauses, PauseUses: item.PauseUses,
-		PauseProgress: item.PauseProgress, LastCompleted: item.LastCompleted, LastResponded: item.LastResponded, CompletedModules: item.CompletedModules,
-		Course: course, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated, DateCompleted: item.DateCompleted, DateDropped: item.DateDropped}
+		Streak: item.Streak, StreakResets: item.StreakResets, StreakRestarts: item.StreakRestarts, Pauses: item.Pauses, PauseUses: item.PauseUses, StreakBeforeReset: item.StreakBeforeReset,
+		StreakFreezes: item.StreakFreezes, LeaderboardName: item.LeaderboardName, PauseProgress: item.PauseProgress, LastCompleted: item.LastCompleted, LastResponded: item.LastResponded, CompletedModules: item.CompletedModules,
+		Course: course, CourseVersion: item.CourseVersion, Version: item.Version, CohortKey: item.CohortKey, CohortStart: item.CohortStart, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated, DateCompleted: item.DateCompleted, DateDropped: item.DateDropped}
 }
 
-func (sa *Adapter) userUnitFromStorage(item userUnit) (model.UserUnit, error) {
+// userUnitFromStorage formats storage struct to appropriate struct for API request
+//
+//	snapshots holds the course versions of the batch the user unit was found in (nil if it was found alone)
+func (sa *Adapter) userUnitFromStorage(item userUnit, snapshots courseVersionSnapshots) (model.UserUnit, error) {
 	result := model.UserUnit{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, ModuleKey: item.ModuleKey,
-		Completed: item.Completed, Current: item.Current, UserSchedule: item.UserSchedule, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+		CourseVersion: item.CourseVersion, Version: item.Version, Completed: item.Completed, Current: item.Current, UserSchedule: item.UserSchedule, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+
+	// contents of units taken from a published version come from the version snapshot instead of the current drafts
+	if item.CourseVersion > 0 {
+		courseVersion, err := sa.findSnapshotCourseVersion(snapshots, userUnitVersionRef(item))
+		if err != nil {
+			return result, err
+		}
+		var versionUnit *model.Unit
+		if courseVersion != nil {
+			versionUnit = courseVersion.Course.GetUnit(item.ModuleKey, item.Unit.Key)
+		}
+		if versionUnit != nil {
+			result.Unit = model.Unit{ID: item.Unit.ID, AppID: item.Unit.AppID, OrgID: item.Unit.OrgID, Key: item.Unit.Key, Name: item.Unit.Name, Contents: versionUnit.Contents,
+				Schedule: item.Unit.Schedule, Required: item.Unit.Required, Localizations: item.Unit.Localizations, DateCreated: item.Unit.DateCreated, DateUpdated: item.Unit.DateUpdated}
+			return result, nil
+		}
+	}
 
 	unit, err := sa.customUnitFromStorage(item.Unit)
 	if err != nil {
@@ -182,8 +243,60 @@ func (sa *Adapter) userUnitFromStorage(item userUnit) (model.UserUnit, error) {
 	return result, nil
 }
 
+func userUnitVersionRef(item userUnit) courseVersionRef {
+	return courseVersionRef{AppID: item.AppID, OrgID: item.OrgID, CourseKey: item.CourseKey, Version: item.CourseVersion}
+}
+
 func (sa *Adapter) userUnitToStorage(item model.UserUnit) userUnit {
 	unit := sa.customUnitToStorage(item.Unit)
 	return userUnit{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, ModuleKey: item.ModuleKey, Unit: unit,
-		Current: item.Current, Completed: item.Completed, UserSchedule: item.UserSchedule, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+		CourseVersion: item.CourseVersion, Version: item.Version, Current: item.Current, Completed: item.Completed, UserSchedule: item.UserSchedule, DateCreated: item.DateCreated, DateUpdated: item.DateUpdated}
+}
+
+func (sa *Adapter) progressArchiveFromStorage(item progressArchive) (model.ProgressArchive, error) {
+	result := model.ProgressArchive{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, Scope: item.Scope, ModuleKey: item.ModuleKey,
+		UnitKey: item.UnitKey, RestartedBy: item.RestartedBy, UserUnits: make([]model.UserUnit, len(item.UserUnits)), UserContents: item.UserContents, DateCreated: item.DateCreated}
+	refs := make([]courseVersionRef, 0)
+	if item.UserCourse != nil {
+		refs = append(refs, userCourseVersionRef(*item.UserCourse))
+	}
+	for _, userUnit := range item.UserUnits {
+		refs = append(refs, userUnitVersionRef(userUnit))
+	}
+	snapshots, err := sa.findCourseVersionSnapshots(refs)
+	if err != nil {
+		return result, err
+	}
+
+	if item.UserCourse != nil {
+		userCourse, err := sa.userCourseFromStorage(*item.UserCourse, snapshots)
+		if err != nil {
+			return result, err
+		}
+		result.UserCourse = &userCourse
+	}
+	for i, userUnit := range item.UserUnits {
+		convertedUnit, err := sa.userUnitFromStorage(userUnit, snapshots)
+		if err != nil {
+			return result, err
+		}
+		result.UserUnits[i] = convertedUnit
+	}
+	if result.UserContents == nil {
+		result.UserContents = make([]model.UserContent, 0)
+	}
+	return result, nil
+}
+
+func (sa *Adapter) progressArchiveToStorage(item model.ProgressArchive) progressArchive {
+	result := progressArchive{ID: item.ID, AppID: item.AppID, OrgID: item.OrgID, UserID: item.UserID, CourseKey: item.CourseKey, Scope: item.Scope, ModuleKey: item.ModuleKey,
+		UnitKey: item.UnitKey, RestartedBy: item.RestartedBy, UserUnits: make([]userUnit, len(item.UserUnits)), UserContents: item.UserContents, DateCreated: item.DateCreated}
+	if item.UserCourse != nil {
+		userCourse := sa.userCourseToStorage(*item.UserCourse)
+		result.UserCourse = &userCourse
+	}
+	for i, userUnit := range item.UserUnits {
+		result.UserUnits[i] = sa.userUnitToStorage(userUnit)
+	}
+	return result
 }
@@ -17,6 +17,7 @@ package storage
 import (
 	"context"
 	"lms/core/interfaces"
+	"lms/core/model"
 	"log"
 	"time"
 
@@ -39,20 +40,30 @@ type database struct {
 	db       *mongo.Database
 	dbClient *mongo.Client
 
-	configs         *collectionWrapper
-	users           *collectionWrapper
-	nudges          *collectionWrapper
-	sentNudges      *collectionWrapper
-	nudgesProcesses *collectionWrapper
-	nudgesBlocks    *collectionWrapper
-	courseConfigs   *collectionWrapper
-	customCourses   *collectionWrapper
-	customModules   *collectionWrapper
-	customUnits     *collectionWrapper
-	customContents  *collectionWrapper
-	userCourses     *collectionWrapper
-	userUnits       *collectionWrapper
-	userContents    *collectionWrapper
+	configs              *collectionWrapper
+	users                *collectionWrapper
+	nudges               *collectionWrapper
+	sentNudges           *collectionWrapper
+	nudgesProcesses      *collectionWrapper
+	nudgesBlocks         *collectionWrapper
+	courseConfigs        *collectionWrapper
+	customCourses        *collectionWrapper
+	customModules        *collectionWrapper
+	customUnits          *collectionWrapper
+	customContents       *collectionWrapper
+	userCourses          *collectionWrapper
+	userUnits            *collectionWrapper
+	userContents         *collectionWrapper
+	achievements         *collectionWrapper
+	userAchievements     *collectionWrapper
+	courseVersions       *collectionWrapper
+	cohorts              *collectionWrapper
+	certificates         *collectionWrapper
+	trash                *collectionWrapper
+	trashDocuments       *collectionWrapper
+	auditLogs            *collectionWrapper
+	progressSyncReceipts *collectionWrapper
+	progressArchives     *collectionWrapper
 }
 
 func (m *database) start() error {
@@ -163,6 +174,66 @@ func (m *database) start() error {
 		return err
 	}
 
+	achievements := &collectionWrapper{database: m, coll: db.Collection("achievements")}
+	err = m.applyAchievementsChecks(achievements)
+	if err != nil {
+		return err
+	}
+
+	userAchievements := &collectionWrapper{database: m, coll: db.Collection("user_achievements")}
+	err = m.applyUserAchievementsChecks(userAchievements)
+	if err != nil {
+		return err
+	}
+
+	courseVersions := &collectionWrapper{database: m, coll: db.Collection("course_versions")}
+	err = m.applyCourseVersionsChecks(courseVersions)
+	if err != nil {
+		return err
+	}
+
+	cohorts := &collectionWrapper{database: m, coll: db.Collection("cohorts")}
+	err = m.applyCohortsChecks(cohorts)
+	if err != nil {
+		return err
+	}
+
+	certificates := &collectionWrapper{database: m, coll: db.Collection("certificates")}
+	err = m.applyCertificatesChecks(certificates)
+	if err != nil {
+		return err
+	}
+
+	trash := &collectionWrapper{database: m, coll: db.Collection("trash")}
+	err = m.applyTrashChecks(trash)
+	if err != nil {
+		return err
+	}
+
+	trashDocuments := &collectionWrapper{database: m, coll: db.Collection("trash_documents")}
+	err = m.applyTrashDocumentsChecks(trashDocuments)
+	if err != nil {
+		return err
+	}
+
+	auditLogs := &collectionWrapper{database: m, coll: db.Collection("audit_logs")}
+	err = m.applyAuditLogsChecks(auditLogs)
+	if err != nil {
+		return err
+	}
+
+	progressSyncReceipts := &collectionWrapper{database: m, coll: db.Collection("progress_sync_receipts")}
+	err = m.applyProgressSyncReceiptsChecks(progressSyncReceipts)
+	if err != nil {
+		return err
+	}
+
+	progressArchives := &collectionWrapper{database: m, coll: db.Collection("progress_archives")}
+	err = m.applyProgressArchivesChecks(progressArchives)
+	if err != nil {
+		return err
+	}
+
 	//asign the db, db client and the collections
 	m.db = db
 	m.dbClient = client
@@ -181,6 +252,16 @@ func (m *database) start() error {
 	m.userCourses = userCourses
 	m.userUnits = userUnits
 	m.userContents = userContents
+	m.achievements = achievements
+	m.userAchievements = userAchievements
+	m.courseVersions = courseVersions
+	m.cohorts = cohorts
+	m.certificates = certificates
+	m.trash = trash
+	m.trashDocuments = trashDocuments
+	m.auditLogs = auditLogs
+	m.progressSyncReceipts = progressSyncReceipts
+	m.progressArchives = progressArchives
 
 	go m.configs.Watch(nil, m.logger)
 
@@ -327,6 +408,18 @@ func (m *database) applyCustomModulesChecks(customModules *collectionWrapper) er
 	if err != nil {
 		return err
 	}
+
+	// text index for searching (localized text is stored in search_text)
+	err = customModules.AddIndexWithOptions(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "name", Value: "text"},
+			primitive.E{Key: "search_text", Value: "text"},
+		}, options.Index().SetWeights(bson.M{"name": 10, "search_text": 5}))
+	if err != nil {
+		return err
+	}
 	m.logger.Info("custom module check passed")
 	return nil
 }
@@ -343,6 +436,18 @@ func (m *database) applyCustomUnitsChecks(customUnits *collectionWrapper) error
 	if err != nil {
 		return err
 	}
+
+	// text index for searching (localized text is stored in search_text)
+	err = customUnits.AddIndexWithOptions(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "name", Value: "text"},
+			primitive.E{Key: "search_text", Value: "text"},
+		}, options.Index().SetWeights(bson.M{"name": 10, "search_text": 5}))
+	if err != nil {
+		return err
+	}
 	m.logger.Info("custom unit check passed")
 	return nil
 }
@@ -359,6 +464,20 @@ func (m *database) applyCustomContentChecks(customContents *collectionWrapper) e
 	if err != nil {
 		return err
 	}
+
+	// text index for searching (localized text is stored in search_text)
+	err = customContents.AddIndexWithOptions(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "name", Value: "text"},
+			primitive.E{Key: "reference.name", Value: "text"},
+			primitive.E{Key: "search_text", Value: "text"},
+			primitive.E{Key: "details", Value: "text"},
+		}, options.Index().SetWeights(bson.M{"name": 10, "reference.name": 5, "search_text": 3, "details": 1}))
+	if err != nil {
+		return err
+	}
 	m.logger.Info("custom content check passed")
 	return nil
 }
@@ -376,6 +495,16 @@ func (m *database) applyUserCoursesChecks(userCourses *collectionWrapper) error
 	if err != nil {
 		return err
 	}
+
+	err = userCourses.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "cohort_key", Value: 1},
+		}, false)
+	if err != nil {
+		return err
+	}
 	m.logger.Info("user course check passed")
 	return nil
 }
@@ -423,6 +552,192 @@ func (m *database) applyUserContentsChecks(userContents *collectionWrapper) erro
 	return nil
 }
 
+// Achievement
+func (m *database) applyAchievementsChecks(achievements *collectionWrapper) error {
+	m.logger.Info("apply achievement check.....")
+	err := achievements.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "key", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("achievement check passed")
+	return nil
+}
+
+// User Achievement
+func (m *database) applyUserAchievementsChecks(userAchievements *collectionWrapper) error {
+	m.logger.Info("apply user achievement check.....")
+	// a user can only be awarded each achievement once
+	err := userAchievements.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "user_id", Value: 1},
+			primitive.E{Key: "achievement.key", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("user achievement check passed")
+	return nil
+}
+
+// Course Version
+func (m *database) applyCourseVersionsChecks(courseVersions *collectionWrapper) error {
+	m.logger.Info("apply course version check.....")
+	err := courseVersions.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "course_key", Value: 1},
+			primitive.E{Key: "version", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("course version check passed")
+	return nil
+}
+
+// Cohort
+func (m *database) applyCohortsChecks(cohorts *collectionWrapper) error {
+	m.logger.Info("apply cohort check.....")
+	err := cohorts.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "key", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("cohort check passed")
+	return nil
+}
+
+// Certificate
+func (m *database) applyCertificatesChecks(certificates *collectionWrapper) error {
+	m.logger.Info("apply certificate check.....")
+	err := certificates.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "user_id", Value: 1},
+			primitive.E{Key: "course_key", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("certificate check passed")
+	return nil
+}
+
+// Trash
+func (m *database) applyTrashChecks(trash *collectionWrapper) error {
+	m.logger.Info("apply trash check.....")
+	err := trash.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "date_deleted", Value: 1},
+		}, false)
+	if err != nil {
+		return err
+	}
+
+	// items are purged once they expire
+	err = trash.AddIndexWithOptions(bson.D{primitive.E{Key: "date_expires", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+	if err != nil {
+		return err
+	}
+	m.logger.Info("trash check passed")
+	return nil
+}
+
+// Trash Document
+func (m *database) applyTrashDocumentsChecks(trashDocuments *collectionWrapper) error {
+	m.logger.Info("apply trash document check.....")
+	err := trashDocuments.AddIndex(bson.D{primitive.E{Key: "trash_id", Value: 1}}, false)
+	if err != nil {
+		return err
+	}
+
+	// documents are purged with their trash items
+	err = trashDocuments.AddIndexWithOptions(bson.D{primitive.E{Key: "date_expires", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+	if err != nil {
+		return err
+	}
+	m.logger.Info("trash document check passed")
+	return nil
+}
+
+// Audit Log
+func (m *database) applyAuditLogsChecks(auditLogs *collectionWrapper) error {
+	m.logger.Info("apply audit log check.....")
+	err := auditLogs.AddIndex(bson.D{primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = auditLogs.AddIndex(bson.D{primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "entity_type", Value: 1}, primitive.E{Key: "entity_key", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+
+	err = auditLogs.AddIndex(bson.D{primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "org_id", Value: 1}, primitive.E{Key: "actor", Value: 1}, primitive.E{Key: "date_created", Value: -1}}, false)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("audit log check passed")
+	return nil
+}
+
+// Progress Sync Receipt
+func (m *database) applyProgressSyncReceiptsChecks(progressSyncReceipts *collectionWrapper) error {
+	m.logger.Info("apply progress sync receipt check.....")
+	err := progressSyncReceipts.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "user_id", Value: 1},
+			primitive.E{Key: "idempotency_key", Value: 1},
+		}, true)
+	if err != nil {
+		return err
+	}
+
+	// receipts are kept until responses with the same timestamp could no longer be synced
+	expireAfter := int32(2 * model.MaxProgressSyncAge / time.Second)
+	err = progressSyncReceipts.AddIndexWithOptions(bson.D{primitive.E{Key: "date_created", Value: 1}}, options.Index().SetExpireAfterSeconds(expireAfter))
+	if err != nil {
+		return err
+	}
+	m.logger.Info("progress sync receipt check passed")
+	return nil
+}
+
+// Progress Archive
+func (m *database) applyProgressArchivesChecks(progressArchives *collectionWrapper) error {
+	m.logger.Info("apply progress archive check.....")
+	err := progressArchives.AddIndex(
+		bson.D{
+			primitive.E{Key: "app_id", Value: 1},
+			primitive.E{Key: "org_id", Value: 1},
+			primitive.E{Key: "user_id", Value: 1},
+			primitive.E{Key: "course_key", Value: 1},
+			primitive.E{Key: "date_created", Value: -1},
+		}, false)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("progress archive check passed")
+	return nil
+}
+
 // Event
 func (m *database) onDataChanged(changeDoc map[string]interface{}) {
 	if changeDoc == nil {
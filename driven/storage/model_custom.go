@@ -17,6 +17,8 @@ package storage
 import (
 	"lms/core/model"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type userCourse struct {
@@ -35,11 +37,23 @@ type userCourse struct {
 	PauseProgress  int         `bson:"pause_progress"`
 	PauseUses      []time.Time `bson:"pause_uses,omitempty"`
 
+	StreakBeforeReset *int `bson:"streak_before_reset,omitempty"`
+
+	StreakFreezes []model.StreakFreeze `bson:"streak_freezes,omitempty"`
+
+	LeaderboardName *string `bson:"leaderboard_name,omitempty"`
+
 	LastCompleted    *time.Time           `bson:"last_completed"`
 	LastResponded    *time.Time           `bson:"last_responded"`
 	CompletedModules map[string]time.Time `bson:"completed_modules,omitempty"`
 
-	Course course `bson:"course"`
+	Course        course `bson:"course"`
+	CourseVersion int    `bson:"course_version"` // 0 if the user follows the unversioned course
+
+	CohortKey   *string    `bson:"cohort_key,omitempty"`
+	CohortStart *time.Time `bson:"cohort_start,omitempty"`
+
+	Version int `bson:"version"`
 
 	DateCreated   time.Time  `bson:"date_created"`
 	DateUpdated   *time.Time `bson:"date_updated"`
@@ -56,6 +70,11 @@ type course struct {
 	Name       string   `bson:"name"`
 	ModuleKeys []string `bson:"module_keys"`
 
+	Availability *model.CourseAvailability `bson:"availability,omitempty"`
+	Visibility   *model.CourseVisibility   `bson:"visibility,omitempty"`
+
+	Localizations model.Localizations `bson:"localizations,omitempty"`
+
 	DateCreated time.Time  `bson:"date_created"`
 	DateUpdated *time.Time `bson:"date_updated"`
 }
@@ -69,12 +88,23 @@ type module struct {
 	Name     string   `bson:"name"`
 	UnitKeys []string `bson:"unit_keys"`
 
+	Prerequisites []model.ModulePrerequisite `bson:"prerequisites,omitempty"`
+
+	Localizations model.Localizations `bson:"localizations,omitempty"`
+	SearchText    []string            `bson:"search_text,omitempty"` // localized text indexed for search
+
 	Styles model.Styles `bson:"styles"`
 
 	DateCreated time.Time  `bson:"date_created"`
 	DateUpdated *time.Time `bson:"date_updated"`
 }
 
+type content struct {
+	model.Content `bson:",inline"`
+
+	SearchText []string `bson:"search_text,omitempty"` // localized text indexed for search
+}
+
 type userUnit struct {
 	ID        string `bson:"_id"`
 	AppID     string `bson:"app_id"`
@@ -84,10 +114,14 @@ type userUnit struct {
 	ModuleKey string `bson:"module_key"`
 	Unit      unit   `bson:"unit"`
 
+	CourseVersion int `bson:"course_version"`
+
 	Completed    int                      `bson:"completed"` // number of schedule items the user has completed
 	Current      bool                     `bson:"current"`
 	UserSchedule []model.UserScheduleItem `bson:"user_schedule"`
 
+	Version int `bson:"version"`
+
 	DateCreated time.Time  `bson:"date_created"`
 	DateUpdated *time.Time `bson:"date_updated"`
 }
@@ -104,6 +138,53 @@ type unit struct {
 
 	Required int `bson:"required"` // number of schedule items required to be completed
 
+	Localizations model.Localizations `bson:"localizations,omitempty"`
+	SearchText    []string            `bson:"search_text,omitempty"` // localized text indexed for search
+
 	DateCreated time.Time  `bson:"date_created"`
 	DateUpdated *time.Time `bson:"date_updated"`
 }
+
+type trashItem struct {
+	model.TrashItem `bson:",inline"`
+
+	References []trashReference `bson:"references,omitempty"` // positions the item key was removed from
+}
+
+type trashReference struct {
+	Collection string `bson:"collection"` // collection of the document which referenced the item
+	Key        string `bson:"key"`        // key of the document which referenced the item
+	Field      string `bson:"field"`      // array field the item key was removed from
+	Index      int    `bson:"index"`
+}
+
+type trashDocument struct {
+	ID      string `bson:"_id"`
+	TrashID string `bson:"trash_id"`
+	AppID   string `bson:"app_id"`
+	OrgID   string `bson:"org_id"`
+
+	Collection string   `bson:"collection"` // collection the document was deleted from
+	Document   bson.Raw `bson:"document"`
+
+	DateExpires time.Time `bson:"date_expires"`
+}
+
+type progressArchive struct {
+	ID        string  `bson:"_id"`
+	AppID     string  `bson:"app_id"`
+	OrgID     string  `bson:"org_id"`
+	UserID    string  `bson:"user_id"`
+	CourseKey string  `bson:"course_key"`
+	Scope     string  `bson:"scope"`
+	ModuleKey *string `bson:"module_key,omitempty"`
+	UnitKey   *string `bson:"unit_key,omitempty"`
+
+	RestartedBy string `bson:"restarted_by"`
+
+	UserCourse   *userCourse         `bson:"user_course,omitempty"`
+	UserUnits    []userUnit          `bson:"user_units"`
+	UserContents []model.UserContent `bson:"user_contents"`
+
+	DateCreated time.Time `bson:"date_created"`
+}
@@ -72,6 +72,11 @@ func (a *Adapter) Start() {
 
 	mainRouter := subrouter.PathPrefix("/api").Subrouter()
 	mainRouter.HandleFunc("/user-data", a.wrapFunc(a.manualAPIsHandler.getUserData, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/certificates/{id}/html", a.wrapFunc(a.manualAPIsHandler.getCertificateHTML, nil)).Methods("GET")
+
+	adminRouter := subrouter.PathPrefix("/admin").Subrouter()
+	adminRouter.HandleFunc("/courses/{key}/bundle", a.wrapFunc(a.manualAPIsHandler.exportCourseBundle, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/courses/{key}/bundle", a.wrapFunc(a.manualAPIsHandler.importCourseBundle, a.auth.admin.Permissions)).Methods("PUT")
 
 	err := a.routeAPIs(router)
 	if err != nil {
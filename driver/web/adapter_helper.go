@@ -15,18 +15,40 @@ import (
 // apiDataType represents any stored data type that may be read/written by an API
 type apiDataType interface {
 	string |
+		model.Achievement |
 		model.AssignmentGroup |
+		model.AuditLog |
+		model.Certificate |
+		model.CertificateVerification |
+		model.Cohort |
+		model.CohortEnrollment |
 		model.Content |
 		model.Course |
+		model.CourseAnalytics |
 		model.CourseConfig |
+		model.CourseMigration |
+		model.CourseVersion |
+		model.CourseVersionPreview |
+		model.IntegrityReport |
+		model.Leaderboard |
+		model.LearnerDetails |
+		model.LearnerProgress |
 		model.Module |
 		model.Nudge |
 		model.NudgesConfig |
 		model.NudgesProcess |
+		model.ProgressArchive |
+		model.ProgressSummary |
+		model.ProgressSyncResult |
 		model.ProviderCourse |
+		model.SearchResult |
 		model.SentNudge |
+		model.StreakHistory |
+		model.StreaksSimulation |
+		model.TrashItem |
 		model.Unit |
 		model.User |
+		model.UserAchievement |
 		model.UserContent |
 		model.UserCourse |
 		model.UserUnit
@@ -35,7 +57,10 @@ type apiDataType interface {
 // requestDataType represents any data type that may be sent in an API request body
 type requestDataType interface {
 	apiDataType |
+		model.CourseClone |
 		Def.NudgesConfig |
+		model.ProgressSync |
+		model.StreakFreeze |
 		model.Timezone |
 		model.UserResponse |
 		Def.AdminReqCreateNudge |
@@ -74,6 +99,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[string, string, string](&handler, a.paths, a.logger)).Methods(method)
+	case "model.Achievement":
+		handler := apiHandler[model.Achievement, model.Achievement, model.Achievement]{authorization: authorization, messageDataType: model.TypeAchievement}
+		err = setCoreHandler[model.Achievement, model.Achievement, model.Achievement](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.Achievement, model.Achievement, model.Achievement](&handler, a.paths, a.logger)).Methods(method)
 	case "model.AssignmentGroup":
 		handler := apiHandler[model.AssignmentGroup, model.AssignmentGroup, model.AssignmentGroup]{authorization: authorization, messageDataType: model.TypeAssignmentGroup}
 		err = setCoreHandler[model.AssignmentGroup, model.AssignmentGroup, model.AssignmentGroup](&handler, coreHandler, method, tag, coreFunc)
@@ -82,6 +115,46 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.AssignmentGroup, model.AssignmentGroup, model.AssignmentGroup](&handler, a.paths, a.logger)).Methods(method)
+	case "model.AuditLog":
+		handler := apiHandler[model.AuditLog, model.AuditLog, model.AuditLog]{authorization: authorization, messageDataType: model.TypeAuditLog}
+		err = setCoreHandler[model.AuditLog, model.AuditLog, model.AuditLog](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.AuditLog, model.AuditLog, model.AuditLog](&handler, a.paths, a.logger)).Methods(method)
+	case "model.Certificate":
+		handler := apiHandler[model.Certificate, model.Certificate, model.Certificate]{authorization: authorization, messageDataType: model.TypeCertificate}
+		err = setCoreHandler[model.Certificate, model.Certificate, model.Certificate](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.Certificate, model.Certificate, model.Certificate](&handler, a.paths, a.logger)).Methods(method)
+	case "model.CertificateVerification":
+		handler := apiHandler[model.CertificateVerification, model.CertificateVerification, model.CertificateVerification]{authorization: authorization, messageDataType: model.TypeCertificateVerification}
+		err = setCoreHandler[model.CertificateVerification, model.CertificateVerification, model.CertificateVerification](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CertificateVerification, model.CertificateVerification, model.CertificateVerification](&handler, a.paths, a.logger)).Methods(method)
+	case "model.Cohort":
+		handler := apiHandler[model.Cohort, model.Cohort, model.Cohort]{authorization: authorization, messageDataType: model.TypeCohort}
+		err = setCoreHandler[model.Cohort, model.Cohort, model.Cohort](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.Cohort, model.Cohort, model.Cohort](&handler, a.paths, a.logger)).Methods(method)
+	case "model.CohortEnrollment":
+		handler := apiHandler[model.CohortEnrollment, model.CohortEnrollment, model.CohortEnrollment]{authorization: authorization, messageDataType: model.TypeCohortEnrollment}
+		err = setCoreHandler[model.CohortEnrollment, model.CohortEnrollment, model.CohortEnrollment](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CohortEnrollment, model.CohortEnrollment, model.CohortEnrollment](&handler, a.paths, a.logger)).Methods(method)
 	case "model.Content":
 		handler := apiHandler[model.Content, model.Content, model.Content]{authorization: authorization, messageDataType: model.TypeContent}
 		err = setCoreHandler[model.Content, model.Content, model.Content](&handler, coreHandler, method, tag, coreFunc)
@@ -92,6 +165,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		router.HandleFunc(pathStr, handleRequest[model.Content, model.Content, model.Content](&handler, a.paths, a.logger)).Methods(method)
 	case "model.Course":
 		switch requestBody {
+		case "#/components/schemas/CourseClone":
+			handler := apiHandler[model.Course, model.Course, model.CourseClone]{authorization: authorization, messageDataType: model.TypeCourse}
+			err = setCoreHandler[model.Course, model.Course, model.CourseClone](&handler, coreHandler, method, tag, coreFunc)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+			}
+
+			router.HandleFunc(pathStr, handleRequest[model.Course, model.Course, model.CourseClone](&handler, a.paths, a.logger)).Methods(method)
 		case "#/components/schemas/_admin_req_update_course":
 			convert, ok := convFunc.(func(*tokenauth.Claims, *Def.AdminReqUpdateCourse) (*model.Course, error))
 			if !ok {
@@ -114,6 +195,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 
 			router.HandleFunc(pathStr, handleRequest[model.Course, model.Course, model.Course](&handler, a.paths, a.logger)).Methods(method)
 		}
+	case "model.CourseAnalytics":
+		handler := apiHandler[model.CourseAnalytics, model.CourseAnalytics, model.CourseAnalytics]{authorization: authorization, messageDataType: model.TypeCourseAnalytics}
+		err = setCoreHandler[model.CourseAnalytics, model.CourseAnalytics, model.CourseAnalytics](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CourseAnalytics, model.CourseAnalytics, model.CourseAnalytics](&handler, a.paths, a.logger)).Methods(method)
 	case "model.CourseConfig":
 		handler := apiHandler[model.CourseConfig, model.CourseConfig, model.CourseConfig]{authorization: authorization, messageDataType: model.TypeCourseConfig}
 		err = setCoreHandler[model.CourseConfig, model.CourseConfig, model.CourseConfig](&handler, coreHandler, method, tag, coreFunc)
@@ -122,6 +211,62 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.CourseConfig, model.CourseConfig, model.CourseConfig](&handler, a.paths, a.logger)).Methods(method)
+	case "model.CourseMigration":
+		handler := apiHandler[model.CourseMigration, model.CourseMigration, model.CourseMigration]{authorization: authorization, messageDataType: model.TypeCourseMigration}
+		err = setCoreHandler[model.CourseMigration, model.CourseMigration, model.CourseMigration](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CourseMigration, model.CourseMigration, model.CourseMigration](&handler, a.paths, a.logger)).Methods(method)
+	case "model.CourseVersion":
+		handler := apiHandler[model.CourseVersion, model.CourseVersion, model.CourseVersion]{authorization: authorization, messageDataType: model.TypeCourseVersion}
+		err = setCoreHandler[model.CourseVersion, model.CourseVersion, model.CourseVersion](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CourseVersion, model.CourseVersion, model.CourseVersion](&handler, a.paths, a.logger)).Methods(method)
+	case "model.CourseVersionPreview":
+		handler := apiHandler[model.CourseVersionPreview, model.CourseVersionPreview, model.CourseVersionPreview]{authorization: authorization, messageDataType: model.TypeCourseVersionPreview}
+		err = setCoreHandler[model.CourseVersionPreview, model.CourseVersionPreview, model.CourseVersionPreview](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.CourseVersionPreview, model.CourseVersionPreview, model.CourseVersionPreview](&handler, a.paths, a.logger)).Methods(method)
+	case "model.IntegrityReport":
+		handler := apiHandler[model.IntegrityReport, model.IntegrityReport, model.IntegrityReport]{authorization: authorization, messageDataType: model.TypeIntegrityReport}
+		err = setCoreHandler[model.IntegrityReport, model.IntegrityReport, model.IntegrityReport](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.IntegrityReport, model.IntegrityReport, model.IntegrityReport](&handler, a.paths, a.logger)).Methods(method)
+	case "model.Leaderboard":
+		handler := apiHandler[model.Leaderboard, model.Leaderboard, model.Leaderboard]{authorization: authorization, messageDataType: model.TypeLeaderboard}
+		err = setCoreHandler[model.Leaderboard, model.Leaderboard, model.Leaderboard](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.Leaderboard, model.Leaderboard, model.Leaderboard](&handler, a.paths, a.logger)).Methods(method)
+	case "model.LearnerDetails":
+		handler := apiHandler[model.LearnerDetails, model.LearnerDetails, model.LearnerDetails]{authorization: authorization, messageDataType: model.TypeLearnerDetails}
+		err = setCoreHandler[model.LearnerDetails, model.LearnerDetails, model.LearnerDetails](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.LearnerDetails, model.LearnerDetails, model.LearnerDetails](&handler, a.paths, a.logger)).Methods(method)
+	case "model.LearnerProgress":
+		handler := apiHandler[model.LearnerProgress, model.LearnerProgress, model.LearnerProgress]{authorization: authorization, messageDataType: model.TypeLearnerProgress}
+		err = setCoreHandler[model.LearnerProgress, model.LearnerProgress, model.LearnerProgress](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.LearnerProgress, model.LearnerProgress, model.LearnerProgress](&handler, a.paths, a.logger)).Methods(method)
 	case "model.Module":
 		switch requestBody {
 		case "#/components/schemas/_admin_req_update_module":
@@ -215,6 +360,41 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.NudgesProcess, model.NudgesProcess, model.NudgesProcess](&handler, a.paths, a.logger)).Methods(method)
+	case "model.ProgressArchive":
+		handler := apiHandler[model.ProgressArchive, model.ProgressArchive, model.ProgressArchive]{authorization: authorization, messageDataType: model.TypeProgressArchive}
+		err = setCoreHandler[model.ProgressArchive, model.ProgressArchive, model.ProgressArchive](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.ProgressArchive, model.ProgressArchive, model.ProgressArchive](&handler, a.paths, a.logger)).Methods(method)
+	case "model.ProgressSummary":
+		handler := apiHandler[model.ProgressSummary, model.ProgressSummary, model.ProgressSummary]{authorization: authorization, messageDataType: model.TypeProgressSummary}
+		err = setCoreHandler[model.ProgressSummary, model.ProgressSummary, model.ProgressSummary](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.ProgressSummary, model.ProgressSummary, model.ProgressSummary](&handler, a.paths, a.logger)).Methods(method)
+	case "model.ProgressSyncResult":
+		switch requestBody {
+		case "#/components/schemas/ProgressSync":
+			handler := apiHandler[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSync]{authorization: authorization, messageDataType: model.TypeProgressSyncResult}
+			err = setCoreHandler[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSync](&handler, coreHandler, method, tag, coreFunc)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+			}
+
+			router.HandleFunc(pathStr, handleRequest[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSync](&handler, a.paths, a.logger)).Methods(method)
+		default:
+			handler := apiHandler[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSyncResult]{authorization: authorization, messageDataType: model.TypeProgressSyncResult}
+			err = setCoreHandler[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSyncResult](&handler, coreHandler, method, tag, coreFunc)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+			}
+
+			router.HandleFunc(pathStr, handleRequest[model.ProgressSyncResult, model.ProgressSyncResult, model.ProgressSyncResult](&handler, a.paths, a.logger)).Methods(method)
+		}
 	case "model.ProviderCourse":
 		handler := apiHandler[model.ProviderCourse, model.ProviderCourse, model.ProviderCourse]{authorization: authorization, messageDataType: model.TypeProviderCourse}
 		err = setCoreHandler[model.ProviderCourse, model.ProviderCourse, model.ProviderCourse](&handler, coreHandler, method, tag, coreFunc)
@@ -223,6 +403,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.ProviderCourse, model.ProviderCourse, model.ProviderCourse](&handler, a.paths, a.logger)).Methods(method)
+	case "model.SearchResult":
+		handler := apiHandler[model.SearchResult, model.SearchResult, model.SearchResult]{authorization: authorization, messageDataType: model.TypeSearchResult}
+		err = setCoreHandler[model.SearchResult, model.SearchResult, model.SearchResult](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.SearchResult, model.SearchResult, model.SearchResult](&handler, a.paths, a.logger)).Methods(method)
 	case "model.SentNudge":
 		handler := apiHandler[model.SentNudge, model.SentNudge, model.SentNudge]{authorization: authorization, messageDataType: model.TypeSentNudge}
 		err = setCoreHandler[model.SentNudge, model.SentNudge, model.SentNudge](&handler, coreHandler, method, tag, coreFunc)
@@ -231,6 +419,30 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.SentNudge, model.SentNudge, model.SentNudge](&handler, a.paths, a.logger)).Methods(method)
+	case "model.StreakHistory":
+		handler := apiHandler[model.StreakHistory, model.StreakHistory, model.StreakHistory]{authorization: authorization, messageDataType: model.TypeStreakHistory}
+		err = setCoreHandler[model.StreakHistory, model.StreakHistory, model.StreakHistory](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.StreakHistory, model.StreakHistory, model.StreakHistory](&handler, a.paths, a.logger)).Methods(method)
+	case "model.StreaksSimulation":
+		handler := apiHandler[model.StreaksSimulation, model.StreaksSimulation, model.StreaksSimulation]{authorization: authorization, messageDataType: model.TypeStreaksSimulation}
+		err = setCoreHandler[model.StreaksSimulation, model.StreaksSimulation, model.StreaksSimulation](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.StreaksSimulation, model.StreaksSimulation, model.StreaksSimulation](&handler, a.paths, a.logger)).Methods(method)
+	case "model.TrashItem":
+		handler := apiHandler[model.TrashItem, model.TrashItem, model.TrashItem]{authorization: authorization, messageDataType: model.TypeTrashItem}
+		err = setCoreHandler[model.TrashItem, model.TrashItem, model.TrashItem](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.TrashItem, model.TrashItem, model.TrashItem](&handler, a.paths, a.logger)).Methods(method)
 	case "model.Unit":
 		switch requestBody {
 		case "#/components/schemas/_admin_req_update_unit":
@@ -263,6 +475,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		}
 
 		router.HandleFunc(pathStr, handleRequest[model.User, model.User, model.User](&handler, a.paths, a.logger)).Methods(method)
+	case "model.UserAchievement":
+		handler := apiHandler[model.UserAchievement, model.UserAchievement, model.UserAchievement]{authorization: authorization, messageDataType: model.TypeUserAchievement}
+		err = setCoreHandler[model.UserAchievement, model.UserAchievement, model.UserAchievement](&handler, coreHandler, method, tag, coreFunc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+		}
+
+		router.HandleFunc(pathStr, handleRequest[model.UserAchievement, model.UserAchievement, model.UserAchievement](&handler, a.paths, a.logger)).Methods(method)
 	case "model.UserContent":
 		handler := apiHandler[model.UserContent, model.UserContent, model.UserContent]{authorization: authorization, messageDataType: model.TypeUserContent}
 		err = setCoreHandler[model.UserContent, model.UserContent, model.UserContent](&handler, coreHandler, method, tag, coreFunc)
@@ -273,6 +493,14 @@ func (a *Adapter) registerHandler(router *mux.Router, pathStr string, method str
 		router.HandleFunc(pathStr, handleRequest[model.UserContent, model.UserContent, model.UserContent](&handler, a.paths, a.logger)).Methods(method)
 	case "model.UserCourse":
 		switch requestBody {
+		case "#/components/schemas/StreakFreeze":
+			handler := apiHandler[model.UserCourse, model.UserCourse, model.StreakFreeze]{authorization: authorization, messageDataType: model.TypeUserCourse}
+			err = setCoreHandler[model.UserCourse, model.UserCourse, model.StreakFreeze](&handler, coreHandler, method, tag, coreFunc)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionApply, "api core handler", &logutils.FieldArgs{"name": tag + "." + coreFunc}, err)
+			}
+
+			router.HandleFunc(pathStr, handleRequest[model.UserCourse, model.UserCourse, model.StreakFreeze](&handler, a.paths, a.logger)).Methods(method)
 		case "#/components/schemas/Timezone":
 			handler := apiHandler[model.UserCourse, model.UserCourse, model.Timezone]{authorization: authorization, messageDataType: model.TypeUserCourse}
 			err = setCoreHandler[model.UserCourse, model.UserCourse, model.Timezone](&handler, coreHandler, method, tag, coreFunc)
@@ -369,18 +597,44 @@ func (a *Adapter) getCoreHandler(tag string, ref string) (interface{}, error) {
 		return a.apisHandler.clientDeleteUserCourse, nil
 	case "ClientUpdateUserCourse":
 		return a.apisHandler.clientUpdateUserCourse, nil
+	case "ClientGetUserCourseStreakHistory":
+		return a.apisHandler.clientGetUserCourseStreakHistory, nil
+	case "ClientCreateUserCourseStreakFreeze":
+		return a.apisHandler.clientCreateUserCourseStreakFreeze, nil
+	case "ClientDeleteUserCourseStreakFreezes":
+		return a.apisHandler.clientDeleteUserCourseStreakFreezes, nil
+	case "ClientGetUserCourseCertificate":
+		return a.apisHandler.clientGetUserCourseCertificate, nil
+	case "ClientSyncUserCourseProgress":
+		return a.apisHandler.clientSyncUserCourseProgress, nil
+	case "ClientRestartUserCourse":
+		return a.apisHandler.clientRestartUserCourse, nil
+	case "ClientGetUserCourseProgressArchives":
+		return a.apisHandler.clientGetUserCourseProgressArchives, nil
+	case "ClientGetUserCourseProgressSummary":
+		return a.apisHandler.clientGetUserCourseProgressSummary, nil
 	case "ClientUpdateUserCourseModuleProgress":
 		return a.apisHandler.clientUpdateUserCourseModuleProgress, nil
+	case "ClientGetUserAchievements":
+		return a.apisHandler.clientGetUserAchievements, nil
 	case "ClientGetUserContents":
 		return a.apisHandler.clientGetUserContents, nil
 	case "ClientGetUserCourseUnits":
 		return a.apisHandler.clientGetUserCourseUnits, nil
+	case "ClientSearchUserCourses":
+		return a.apisHandler.clientSearchUserCourses, nil
 	case "ClientGetCustomCourses":
 		return a.apisHandler.clientGetCustomCourses, nil
 	case "ClientGetCustomCourse":
 		return a.apisHandler.clientGetCustomCourse, nil
+	case "ClientGetCustomCourseLeaderboard":
+		return a.apisHandler.clientGetCustomCourseLeaderboard, nil
 	case "ClientGetCustomCourseConfig":
 		return a.apisHandler.clientGetCustomCourseConfig, nil
+	case "DefaultVerifyCertificate":
+		return a.apisHandler.defaultVerifyCertificate, nil
+	case "DefaultGetCertificateVerification":
+		return a.apisHandler.defaultGetCertificateVerification, nil
 	case "AdminGetNudgesConfig":
 		return a.apisHandler.adminGetNudgesConfig, nil
 	case "AdminUpdateNudgesConfig":
@@ -401,6 +655,8 @@ func (a *Adapter) getCoreHandler(tag string, ref string) (interface{}, error) {
 		return a.apisHandler.adminClearTestSentNudges, nil
 	case "AdminFindNudgesProcesses":
 		return a.apisHandler.adminFindNudgesProcesses, nil
+	case "AdminGetAuditLogs":
+		return a.apisHandler.adminGetAuditLogs, nil
 	case "AdminGetCustomCourses":
 		return a.apisHandler.adminGetCustomCourses, nil
 	case "AdminCreateCustomCourse":
@@ -411,6 +667,26 @@ func (a *Adapter) getCoreHandler(tag string, ref string) (interface{}, error) {
 		return a.apisHandler.adminUpdateCustomCourse, nil
 	case "AdminDeleteCustomCourse":
 		return a.apisHandler.adminDeleteCustomCourse, nil
+	case "AdminGetCustomCourseVersions":
+		return a.apisHandler.adminGetCustomCourseVersions, nil
+	case "AdminPublishCustomCourse":
+		return a.apisHandler.adminPublishCustomCourse, nil
+	case "AdminGetCustomCoursePreview":
+		return a.apisHandler.adminGetCustomCoursePreview, nil
+	case "AdminGetCustomCourseMigration":
+		return a.apisHandler.adminGetCustomCourseMigration, nil
+	case "AdminMigrateCustomCourse":
+		return a.apisHandler.adminMigrateCustomCourse, nil
+	case "AdminCloneCustomCourse":
+		return a.apisHandler.adminCloneCustomCourse, nil
+	case "AdminGetCustomCourseLearners":
+		return a.apisHandler.adminGetCustomCourseLearners, nil
+	case "AdminGetCustomCourseLearner":
+		return a.apisHandler.adminGetCustomCourseLearner, nil
+	case "AdminRestartCustomCourseLearner":
+		return a.apisHandler.adminRestartCustomCourseLearner, nil
+	case "AdminGetCustomCourseAnalytics":
+		return a.apisHandler.adminGetCustomCourseAnalytics, nil
 	case "AdminGetCustomModules":
 		return a.apisHandler.adminGetCustomModules, nil
 	case "AdminCreateCustomModule":
@@ -441,6 +717,14 @@ func (a *Adapter) getCoreHandler(tag string, ref string) (interface{}, error) {
 		return a.apisHandler.adminUpdateCustomContent, nil
 	case "AdminDeleteCustomContent":
 		return a.apisHandler.adminDeleteCustomContent, nil
+	case "AdminSearchCustomCourses":
+		return a.apisHandler.adminSearchCustomCourses, nil
+	case "AdminGetTrashItems":
+		return a.apisHandler.adminGetTrashItems, nil
+	case "AdminDeleteTrashItem":
+		return a.apisHandler.adminDeleteTrashItem, nil
+	case "AdminRestoreTrashItem":
+		return a.apisHandler.adminRestoreTrashItem, nil
 	case "AdminGetCustomCourseConfigs":
 		return a.apisHandler.adminGetCustomCourseConfigs, nil
 	case "AdminCreateCustomCourseConfig":
@@ -451,6 +735,36 @@ func (a *Adapter) getCoreHandler(tag string, ref string) (interface{}, error) {
 		return a.apisHandler.adminUpdateCustomCourseConfig, nil
 	case "AdminDeleteCustomCourseConfig":
 		return a.apisHandler.adminDeleteCustomCourseConfig, nil
+	case "AdminGetStreaksSimulation":
+		return a.apisHandler.adminGetStreaksSimulation, nil
+	case "AdminGetAchievements":
+		return a.apisHandler.adminGetAchievements, nil
+	case "AdminCreateAchievement":
+		return a.apisHandler.adminCreateAchievement, nil
+	case "AdminGetAchievement":
+		return a.apisHandler.adminGetAchievement, nil
+	case "AdminUpdateAchievement":
+		return a.apisHandler.adminUpdateAchievement, nil
+	case "AdminDeleteAchievement":
+		return a.apisHandler.adminDeleteAchievement, nil
+	case "AdminGetCustomIntegrityReport":
+		return a.apisHandler.adminGetCustomIntegrityReport, nil
+	case "AdminRepairCustomIntegrity":
+		return a.apisHandler.adminRepairCustomIntegrity, nil
+	case "AdminGetCohorts":
+		return a.apisHandler.adminGetCohorts, nil
+	case "AdminCreateCohort":
+		return a.apisHandler.adminCreateCohort, nil
+	case "AdminGetCohort":
+		return a.apisHandler.adminGetCohort, nil
+	case "AdminUpdateCohort":
+		return a.apisHandler.adminUpdateCohort, nil
+	case "AdminDeleteCohort":
+		return a.apisHandler.adminDeleteCohort, nil
+	case "AdminEnrollCohort":
+		return a.apisHandler.adminEnrollCohort, nil
+	case "AdminGetCohortMembers":
+		return a.apisHandler.adminGetCohortMembers, nil
 	default:
 		return nil, errors.ErrorData(logutils.StatusInvalid, "core function", logutils.StringArgs(tag+ref))
 	}
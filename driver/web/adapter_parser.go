@@ -2,6 +2,7 @@ package web
 
 import (
 	"encoding/json"
+	"lms/core/model"
 	"net/http"
 	"strconv"
 	"time"
@@ -153,6 +154,13 @@ func handle[A apiDataType, R requestDataType, S requestDataType](r *http.Request
 	}
 
 	if err != nil {
+		if errors.Status(err) == model.ErrorStatusConflict {
+			// the client must refresh the data it based its request on before trying again
+			return l.HTTPResponseErrorAction(actionType, handler.messageDataType, nil, err, http.StatusConflict, true)
+		}
+		if errors.Status(err) == model.ErrorStatusNotFound {
+			return l.HTTPResponseErrorAction(actionType, handler.messageDataType, nil, err, http.StatusNotFound, true)
+		}
 		return l.HTTPResponseErrorAction(actionType, handler.messageDataType, nil, err, http.StatusInternalServerError, true)
 	}
 	if (obj == (*A)(nil) || obj == nil) && r.Method != http.MethodGet {
@@ -212,6 +220,9 @@ func getParams(l *logs.Log, r *http.Request, params openapi3.Parameters) (map[st
 		case openapi3.ParameterInQuery:
 			values = r.URL.Query()[param.Value.Name]
 			messageDataType = logutils.TypeQueryParam
+		case openapi3.ParameterInHeader:
+			values = r.Header.Values(param.Value.Name)
+			messageDataType = logutils.TypeHeader
 		}
 
 		// Check if values are present
@@ -22,6 +22,19 @@ func (a APIsHandler) defaultGetVersion(claims *tokenauth.Claims, params map[stri
 	return a.app.Default.GetVersion()
 }
 
+func (a APIsHandler) defaultVerifyCertificate(claims *tokenauth.Claims, params map[string]interface{}, item *model.CertificateVerification) (*model.CertificateVerification, error) {
+	return a.app.Default.VerifyCertificate(*item)
+}
+
+func (a APIsHandler) defaultGetCertificateVerification(claims *tokenauth.Claims, params map[string]interface{}) (*model.CertificateVerification, error) {
+	id, err := utils.GetValue[string](params, "id", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("id"), err)
+	}
+
+	return a.app.Default.GetCertificateVerification(id)
+}
+
 // Client
 
 func (a APIsHandler) clientGetCourses(claims *tokenauth.Claims, params map[string]interface{}) ([]model.ProviderCourse, error) {
@@ -95,7 +108,17 @@ func (a APIsHandler) clientGetUserCourses(claims *tokenauth.Claims, params map[s
 		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
 	}
 
-	return a.app.Client.GetUserCourses(claims, id, name, key)
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetUserCourses(claims, id, name, key, locale, acceptLanguage)
 }
 
 func (a APIsHandler) clientGetUserCourse(claims *tokenauth.Claims, params map[string]interface{}) (*model.UserCourse, error) {
@@ -104,7 +127,17 @@ func (a APIsHandler) clientGetUserCourse(claims *tokenauth.Claims, params map[st
 		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
 	}
 
-	return a.app.Client.GetUserCourse(claims, key)
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetUserCourse(claims, key, locale, acceptLanguage)
 }
 
 func (a APIsHandler) clientCreateUserCourse(claims *tokenauth.Claims, params map[string]interface{}, item *model.Timezone) (*model.UserCourse, error) {
@@ -136,7 +169,114 @@ func (a APIsHandler) clientUpdateUserCourse(claims *tokenauth.Claims, params map
 		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("drop"), err)
 	}
 
-	return a.app.Client.UpdateUserCourse(claims, key, drop)
+	leaderboardOptIn, err := utils.GetValue[*bool](params, "leaderboard_opt_in", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("leaderboard_opt_in"), err)
+	}
+
+	return a.app.Client.UpdateUserCourse(claims, key, drop, leaderboardOptIn)
+}
+
+func (a APIsHandler) clientGetUserCourseStreakHistory(claims *tokenauth.Claims, params map[string]interface{}) (*model.StreakHistory, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	start, err := utils.GetValue[*string](params, "start", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("start"), err)
+	}
+
+	end, err := utils.GetValue[*string](params, "end", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("end"), err)
+	}
+
+	return a.app.Client.GetUserCourseStreakHistory(claims, key, start, end)
+}
+
+func (a APIsHandler) clientCreateUserCourseStreakFreeze(claims *tokenauth.Claims, params map[string]interface{}, item *model.StreakFreeze) (*model.UserCourse, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Client.CreateUserCourseStreakFreeze(claims, key, *item)
+}
+
+func (a APIsHandler) clientDeleteUserCourseStreakFreezes(claims *tokenauth.Claims, params map[string]interface{}) error {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Client.DeleteUserCourseStreakFreezes(claims, key)
+}
+
+func (a APIsHandler) clientGetUserCourseCertificate(claims *tokenauth.Claims, params map[string]interface{}) (*model.Certificate, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Client.GetUserCourseCertificate(claims, key)
+}
+
+func (a APIsHandler) clientSyncUserCourseProgress(claims *tokenauth.Claims, params map[string]interface{}, item *model.ProgressSync) (*model.ProgressSyncResult, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Client.SyncUserCourseProgress(claims, key, *item)
+}
+
+func (a APIsHandler) clientRestartUserCourse(claims *tokenauth.Claims, params map[string]interface{}, item *model.UserCourse) (*model.UserCourse, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	moduleKey, err := utils.GetValue[*string](params, "module_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("module_key"), err)
+	}
+
+	unitKey, err := utils.GetValue[*string](params, "unit_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("unit_key"), err)
+	}
+
+	return a.app.Client.RestartUserCourse(claims, key, moduleKey, unitKey)
+}
+
+func (a APIsHandler) clientGetUserCourseProgressArchives(claims *tokenauth.Claims, params map[string]interface{}) ([]model.ProgressArchive, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Client.GetUserCourseProgressArchives(claims, key)
+}
+
+func (a APIsHandler) clientGetUserCourseProgressSummary(claims *tokenauth.Claims, params map[string]interface{}) (*model.ProgressSummary, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetUserCourseProgressSummary(claims, key, locale, acceptLanguage)
 }
 
 func (a APIsHandler) clientUpdateUserCourseModuleProgress(claims *tokenauth.Claims, params map[string]interface{}, item *model.UserResponse) (*model.UserUnit, error) {
@@ -153,6 +293,15 @@ func (a APIsHandler) clientUpdateUserCourseModuleProgress(claims *tokenauth.Clai
 	return a.app.Client.UpdateUserCourseModuleProgress(claims, courseKey, moduleKey, *item)
 }
 
+func (a APIsHandler) clientGetUserAchievements(claims *tokenauth.Claims, params map[string]interface{}) ([]model.UserAchievement, error) {
+	courseKey, err := utils.GetValue[*string](params, "course_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("course_key"), err)
+	}
+
+	return a.app.Client.GetUserAchievements(claims, courseKey)
+}
+
 func (a APIsHandler) clientGetUserContents(claims *tokenauth.Claims, params map[string]interface{}) ([]model.UserContent, error) {
 	ids, err := utils.GetValue[string](params, "ids", true)
 	if err != nil {
@@ -168,11 +317,60 @@ func (a APIsHandler) clientGetUserCourseUnits(claims *tokenauth.Claims, params m
 		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
 	}
 
-	return a.app.Client.GetUserCourseUnits(claims, key)
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetUserCourseUnits(claims, key, locale, acceptLanguage)
+}
+
+func (a APIsHandler) clientSearchUserCourses(claims *tokenauth.Claims, params map[string]interface{}) ([]model.SearchResult, error) {
+	text, err := utils.GetValue[string](params, "text", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("text"), err)
+	}
+
+	courseKey, err := utils.GetValue[*string](params, "course_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("course_key"), err)
+	}
+
+	limit, err := utils.GetValue[*int](params, "limit", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("limit"), err)
+	}
+
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.SearchUserCourses(claims, text, courseKey, limit, locale, acceptLanguage)
 }
 
 func (a APIsHandler) clientGetCustomCourses(claims *tokenauth.Claims, params map[string]interface{}) ([]model.Course, error) {
-	return a.app.Client.GetCustomCourses(claims)
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetCustomCourses(claims, locale, acceptLanguage)
 }
 
 func (a APIsHandler) clientGetCustomCourse(claims *tokenauth.Claims, params map[string]interface{}) (*model.Course, error) {
@@ -181,7 +379,41 @@ func (a APIsHandler) clientGetCustomCourse(claims *tokenauth.Claims, params map[
 		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
 	}
 
-	return a.app.Client.GetCustomCourse(claims, key)
+	locale, err := utils.GetValue[*string](params, "locale", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("locale"), err)
+	}
+
+	acceptLanguage, err := utils.GetValue[*string](params, "accept-language", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("accept-language"), err)
+	}
+
+	return a.app.Client.GetCustomCourse(claims, key, locale, acceptLanguage)
+}
+
+func (a APIsHandler) clientGetCustomCourseLeaderboard(claims *tokenauth.Claims, params map[string]interface{}) (*model.Leaderboard, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	metric, err := utils.GetValue[*string](params, "metric", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("metric"), err)
+	}
+
+	limit, err := utils.GetValue[*int](params, "limit", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("limit"), err)
+	}
+
+	offset, err := utils.GetValue[*int](params, "offset", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("offset"), err)
+	}
+
+	return a.app.Client.GetCustomCourseLeaderboard(claims, key, metric, limit, offset)
 }
 
 func (a APIsHandler) clientGetCustomCourseConfig(claims *tokenauth.Claims, params map[string]interface{}) (*model.CourseConfig, error) {
@@ -280,6 +512,45 @@ func (a APIsHandler) adminFindNudgesProcesses(claims *tokenauth.Claims, params m
 	return a.app.Admin.FindNudgesProcesses(claims, limit, offset)
 }
 
+func (a APIsHandler) adminGetAuditLogs(claims *tokenauth.Claims, params map[string]interface{}) ([]model.AuditLog, error) {
+	entityType, err := utils.GetValue[*string](params, "entity_type", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("entity_type"), err)
+	}
+
+	entityKey, err := utils.GetValue[*string](params, "entity_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("entity_key"), err)
+	}
+
+	actor, err := utils.GetValue[*string](params, "actor", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("actor"), err)
+	}
+
+	startDate, err := utils.GetValue[*string](params, "start_date", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("start_date"), err)
+	}
+
+	endDate, err := utils.GetValue[*string](params, "end_date", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("end_date"), err)
+	}
+
+	limit, err := utils.GetValue[*int](params, "limit", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("limit"), err)
+	}
+
+	offset, err := utils.GetValue[*int](params, "offset", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("offset"), err)
+	}
+
+	return a.app.Admin.GetAuditLogs(claims, entityType, entityKey, actor, startDate, endDate, limit, offset)
+}
+
 func (a APIsHandler) adminGetCustomCourses(claims *tokenauth.Claims, params map[string]interface{}) ([]model.Course, error) {
 	id, err := utils.GetValue[*string](params, "id", false)
 	if err != nil {
@@ -335,6 +606,166 @@ func (a APIsHandler) adminDeleteCustomCourse(claims *tokenauth.Claims, params ma
 	return a.app.Admin.DeleteCustomCourse(claims, key)
 }
 
+func (a APIsHandler) adminGetCustomCourseVersions(claims *tokenauth.Claims, params map[string]interface{}) ([]model.CourseVersion, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.GetCustomCourseVersions(claims, key)
+}
+
+func (a APIsHandler) adminPublishCustomCourse(claims *tokenauth.Claims, params map[string]interface{}, item *model.CourseVersion) (*model.CourseVersion, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.PublishCustomCourse(claims, key, *item)
+}
+
+func (a APIsHandler) adminGetCustomCoursePreview(claims *tokenauth.Claims, params map[string]interface{}) (*model.CourseVersionPreview, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.GetCustomCoursePreview(claims, key)
+}
+
+func (a APIsHandler) adminGetCustomCourseMigration(claims *tokenauth.Claims, params map[string]interface{}) (*model.CourseMigration, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	version, err := utils.GetValue[*int](params, "version", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("version"), err)
+	}
+
+	return a.app.Admin.GetCustomCourseMigration(claims, key, version)
+}
+
+func (a APIsHandler) adminMigrateCustomCourse(claims *tokenauth.Claims, params map[string]interface{}, item *model.CourseMigration) (*model.CourseMigration, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	version, err := utils.GetValue[*int](params, "version", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("version"), err)
+	}
+
+	return a.app.Admin.MigrateCustomCourse(claims, key, version)
+}
+
+func (a APIsHandler) adminCloneCustomCourse(claims *tokenauth.Claims, params map[string]interface{}, item *model.CourseClone) (*model.Course, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.CloneCustomCourse(claims, key, *item)
+}
+
+func (a APIsHandler) adminGetCustomCourseLearners(claims *tokenauth.Claims, params map[string]interface{}) ([]model.LearnerProgress, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	cohortKey, err := utils.GetValue[*string](params, "cohort_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("cohort_key"), err)
+	}
+
+	inactiveDays, err := utils.GetValue[*int](params, "inactive_days", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("inactive_days"), err)
+	}
+
+	streakResetDays, err := utils.GetValue[*int](params, "streak_reset_days", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("streak_reset_days"), err)
+	}
+
+	completed, err := utils.GetValue[*bool](params, "completed", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("completed"), err)
+	}
+
+	dropped, err := utils.GetValue[*bool](params, "dropped", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("dropped"), err)
+	}
+
+	limit, err := utils.GetValue[*int](params, "limit", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("limit"), err)
+	}
+
+	offset, err := utils.GetValue[*int](params, "offset", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("offset"), err)
+	}
+
+	return a.app.Admin.GetCustomCourseLearners(claims, key, cohortKey, inactiveDays, streakResetDays, completed, dropped, limit, offset)
+}
+
+func (a APIsHandler) adminGetCustomCourseLearner(claims *tokenauth.Claims, params map[string]interface{}) (*model.LearnerDetails, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	userID, err := utils.GetValue[string](params, "user_id", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("user_id"), err)
+	}
+
+	return a.app.Admin.GetCustomCourseLearner(claims, key, userID)
+}
+
+func (a APIsHandler) adminRestartCustomCourseLearner(claims *tokenauth.Claims, params map[string]interface{}, item *model.UserCourse) (*model.UserCourse, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	userID, err := utils.GetValue[string](params, "user_id", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("user_id"), err)
+	}
+
+	moduleKey, err := utils.GetValue[*string](params, "module_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("module_key"), err)
+	}
+
+	unitKey, err := utils.GetValue[*string](params, "unit_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("unit_key"), err)
+	}
+
+	return a.app.Admin.RestartCustomCourseLearner(claims, key, userID, moduleKey, unitKey)
+}
+
+func (a APIsHandler) adminGetCustomCourseAnalytics(claims *tokenauth.Claims, params map[string]interface{}) (*model.CourseAnalytics, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	inactiveDays, err := utils.GetValue[*int](params, "inactive_days", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("inactive_days"), err)
+	}
+
+	return a.app.Admin.GetCustomCourseAnalytics(claims, key, inactiveDays)
+}
+
 func (a APIsHandler) adminGetCustomModules(claims *tokenauth.Claims, params map[string]interface{}) ([]model.Module, error) {
 	id, err := utils.GetValue[*string](params, "id", false)
 	if err != nil {
@@ -495,6 +926,52 @@ func (a APIsHandler) adminDeleteCustomContent(claims *tokenauth.Claims, params m
 	return a.app.Admin.DeleteCustomContent(claims, key)
 }
 
+func (a APIsHandler) adminSearchCustomCourses(claims *tokenauth.Claims, params map[string]interface{}) ([]model.SearchResult, error) {
+	text, err := utils.GetValue[string](params, "text", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("text"), err)
+	}
+
+	courseKey, err := utils.GetValue[*string](params, "course_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("course_key"), err)
+	}
+
+	limit, err := utils.GetValue[*int](params, "limit", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("limit"), err)
+	}
+
+	return a.app.Admin.SearchCustomCourses(claims, text, courseKey, limit)
+}
+
+func (a APIsHandler) adminGetTrashItems(claims *tokenauth.Claims, params map[string]interface{}) ([]model.TrashItem, error) {
+	itemType, err := utils.GetValue[*string](params, "item_type", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("item_type"), err)
+	}
+
+	return a.app.Admin.GetTrashItems(claims, itemType)
+}
+
+func (a APIsHandler) adminDeleteTrashItem(claims *tokenauth.Claims, params map[string]interface{}) error {
+	id, err := utils.GetValue[string](params, "id", true)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("id"), err)
+	}
+
+	return a.app.Admin.DeleteTrashItem(claims, id)
+}
+
+func (a APIsHandler) adminRestoreTrashItem(claims *tokenauth.Claims, params map[string]interface{}, item *model.TrashItem) (*model.TrashItem, error) {
+	id, err := utils.GetValue[string](params, "id", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("id"), err)
+	}
+
+	return a.app.Admin.RestoreTrashItem(claims, id)
+}
+
 func (a APIsHandler) adminGetCustomCourseConfigs(claims *tokenauth.Claims, params map[string]interface{}) ([]model.CourseConfig, error) {
 	return a.app.Admin.GetCustomCourseConfigs(claims)
 }
@@ -530,6 +1007,131 @@ func (a APIsHandler) adminDeleteCustomCourseConfig(claims *tokenauth.Claims, par
 	return a.app.Admin.DeleteCustomCourseConfig(claims, key)
 }
 
+func (a APIsHandler) adminGetStreaksSimulation(claims *tokenauth.Claims, params map[string]interface{}) (*model.StreaksSimulation, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	start, err := utils.GetValue[*string](params, "start", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("start"), err)
+	}
+
+	hours, err := utils.GetValue[*int](params, "hours", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("hours"), err)
+	}
+
+	return a.app.Admin.GetStreaksSimulation(claims, key, start, hours)
+}
+
+func (a APIsHandler) adminGetAchievements(claims *tokenauth.Claims, params map[string]interface{}) ([]model.Achievement, error) {
+	courseKey, err := utils.GetValue[*string](params, "course_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("course_key"), err)
+	}
+
+	return a.app.Admin.GetAchievements(claims, courseKey)
+}
+
+func (a APIsHandler) adminCreateAchievement(claims *tokenauth.Claims, params map[string]interface{}, item *model.Achievement) (*model.Achievement, error) {
+	return a.app.Admin.CreateAchievement(claims, *item)
+}
+
+func (a APIsHandler) adminGetAchievement(claims *tokenauth.Claims, params map[string]interface{}) (*model.Achievement, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.GetAchievement(claims, key)
+}
+
+func (a APIsHandler) adminUpdateAchievement(claims *tokenauth.Claims, params map[string]interface{}, item *model.Achievement) (*model.Achievement, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.UpdateAchievement(claims, key, *item)
+}
+
+func (a APIsHandler) adminDeleteAchievement(claims *tokenauth.Claims, params map[string]interface{}) error {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.DeleteAchievement(claims, key)
+}
+
+func (a APIsHandler) adminGetCustomIntegrityReport(claims *tokenauth.Claims, params map[string]interface{}) (*model.IntegrityReport, error) {
+	return a.app.Admin.GetCustomIntegrityReport(claims)
+}
+
+func (a APIsHandler) adminRepairCustomIntegrity(claims *tokenauth.Claims, params map[string]interface{}, item *model.IntegrityReport) (*model.IntegrityReport, error) {
+	return a.app.Admin.RepairCustomIntegrity(claims)
+}
+
+func (a APIsHandler) adminGetCohorts(claims *tokenauth.Claims, params map[string]interface{}) ([]model.Cohort, error) {
+	courseKey, err := utils.GetValue[*string](params, "course_key", false)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("course_key"), err)
+	}
+
+	return a.app.Admin.GetCohorts(claims, courseKey)
+}
+
+func (a APIsHandler) adminCreateCohort(claims *tokenauth.Claims, params map[string]interface{}, item *model.Cohort) (*model.Cohort, error) {
+	return a.app.Admin.CreateCohort(claims, *item)
+}
+
+func (a APIsHandler) adminGetCohort(claims *tokenauth.Claims, params map[string]interface{}) (*model.Cohort, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.GetCohort(claims, key)
+}
+
+func (a APIsHandler) adminUpdateCohort(claims *tokenauth.Claims, params map[string]interface{}, item *model.Cohort) (*model.Cohort, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.UpdateCohort(claims, key, *item)
+}
+
+func (a APIsHandler) adminDeleteCohort(claims *tokenauth.Claims, params map[string]interface{}) error {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.DeleteCohort(claims, key)
+}
+
+func (a APIsHandler) adminEnrollCohort(claims *tokenauth.Claims, params map[string]interface{}, item *model.CohortEnrollment) (*model.CohortEnrollment, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.EnrollCohort(claims, key)
+}
+
+func (a APIsHandler) adminGetCohortMembers(claims *tokenauth.Claims, params map[string]interface{}) ([]model.UserCourse, error) {
+	key, err := utils.GetValue[string](params, "key", true)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, logutils.TypePathParam, logutils.StringArgs("key"), err)
+	}
+
+	return a.app.Admin.GetCohortMembers(claims, key)
+}
+
 // NewAPIsHandler creates new API handler instance
 func NewAPIsHandler(app *core.Application) APIsHandler {
 	return APIsHandler{app: app}
@@ -16,10 +16,15 @@ package web
 
 import (
 	"encoding/json"
+	"io"
 	"lms/core"
+	"lms/core/model"
 	"net/http"
 
+	"github.com/gorilla/mux"
+	"github.com/oasdiff/yaml"
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/tokenauth"
+	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
 )
@@ -29,6 +34,19 @@ type ManualAPIsHandler struct {
 	app *core.Application
 }
 
+func (h ManualAPIsHandler) getCertificateHTML(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	id := mux.Vars(r)["id"]
+
+	response, err := h.app.Manual.GetCertificateHTML(id)
+	if err != nil {
+		if errors.Status(err) == model.ErrorStatusNotFound {
+			return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeCertificate, nil, err, http.StatusNotFound, true)
+		}
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeCertificate, nil, err, http.StatusInternalServerError, true)
+	}
+	return l.HTTPResponseSuccessBytes(response, "text/html; charset=utf-8")
+}
+
 func (h ManualAPIsHandler) getUserData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
 	userData, err := h.app.Manual.GetUserData(claims)
 	if err != nil {
@@ -43,6 +61,60 @@ func (h ManualAPIsHandler) getUserData(l *logs.Log, r *http.Request, claims *tok
 	return l.HTTPResponseSuccessJSON(response)
 }
 
+func (h ManualAPIsHandler) exportCourseBundle(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	key := mux.Vars(r)["key"]
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "yaml" {
+		return l.HTTPResponseErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, &logutils.FieldArgs{"format": format}, nil, http.StatusBadRequest, false)
+	}
+
+	bundle, err := h.app.Manual.ExportCourseBundle(claims, key)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeCourseBundle, nil, err, http.StatusInternalServerError, true)
+	}
+
+	if format == "yaml" {
+		response, err := yaml.Marshal(bundle)
+		if err != nil {
+			return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		}
+		return l.HTTPResponseSuccessBytes(response, "application/yaml; charset=utf-8")
+	}
+
+	response, err := json.Marshal(bundle)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(response)
+}
+
+func (h ManualAPIsHandler) importCourseBundle(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
+	key := mux.Vars(r)["key"]
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionRead, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, false)
+	}
+
+	// YAML is a superset of JSON, so both formats are accepted
+	var bundle model.CourseBundle
+	err = yaml.Unmarshal(data, &bundle)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, model.TypeCourseBundle, nil, err, http.StatusBadRequest, true)
+	}
+
+	course, err := h.app.Manual.ImportCourseBundle(claims, key, bundle)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionApply, model.TypeCourseBundle, nil, err, http.StatusBadRequest, true)
+	}
+
+	response, err := json.Marshal(course)
+	if err != nil {
+		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+	}
+	return l.HTTPResponseSuccessJSON(response)
+}
+
 // NewManualAPIsHandler creates new manual API handler instance
 func NewManualAPIsHandler(app *core.Application) ManualAPIsHandler {
 	return ManualAPIsHandler{app: app}
@@ -17,6 +17,7 @@ package web
 import (
 	"lms/core/model"
 	Def "lms/driver/web/docs/gen"
+	"lms/utils"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/tokenauth"
 )
@@ -85,7 +86,23 @@ func customCourseUpdateFromDef(claims *tokenauth.Claims, item *Def.AdminReqUpdat
 	for i, key := range item.ModuleKeys {
 		modules[i] = model.Module{Key: key}
 	}
-	return &model.Course{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Modules: modules}, nil
+	var availability *model.CourseAvailability
+	if item.Availability != nil {
+		availability = &model.CourseAvailability{EnrollmentStart: item.Availability.EnrollmentStart, EnrollmentEnd: item.Availability.EnrollmentEnd,
+			CourseEnd: item.Availability.CourseEnd}
+	}
+	var visibility *model.CourseVisibility
+	if item.Visibility != nil {
+		visibility = &model.CourseVisibility{}
+		if item.Visibility.Groups != nil {
+			visibility.Groups = *item.Visibility.Groups
+		}
+		if item.Visibility.Roles != nil {
+			visibility.Roles = *item.Visibility.Roles
+		}
+	}
+	return &model.Course{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Modules: modules, Availability: availability, Visibility: visibility,
+		Localizations: localizationsFromDef(item.Localizations)}, nil
 }
 
 func customModuleUpdateFromDef(claims *tokenauth.Claims, item *Def.AdminReqUpdateModule) (*model.Module, error) {
@@ -97,7 +114,16 @@ func customModuleUpdateFromDef(claims *tokenauth.Claims, item *Def.AdminReqUpdat
 	for i, key := range item.UnitKeys {
 		units[i] = model.Unit{Key: key}
 	}
-	return &model.Module{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Units: units}, nil
+	var prerequisites []model.ModulePrerequisite
+	if item.Prerequisites != nil {
+		prerequisites = make([]model.ModulePrerequisite, len(*item.Prerequisites))
+		for i, prerequisite := range *item.Prerequisites {
+			prerequisites[i] = model.ModulePrerequisite{Type: string(prerequisite.Type), ModuleKey: utils.GetString(prerequisite.ModuleKey), Date: prerequisite.Date,
+				Days: utils.GetInt(prerequisite.Days)}
+		}
+	}
+	return &model.Module{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Units: units, Prerequisites: prerequisites,
+		Localizations: localizationsFromDef(item.Localizations)}, nil
 }
 
 func customUnitUpdateFromDef(claims *tokenauth.Claims, item *Def.AdminReqUpdateUnit) (*model.Unit, error) {
@@ -115,5 +141,22 @@ func customUnitUpdateFromDef(claims *tokenauth.Claims, item *Def.AdminReqUpdateU
 		schedule[i] = model.ScheduleItem{Name: si.Name, Duration: si.Duration, ContentKeys: item.ContentKeys}
 	}
 
-	return &model.Unit{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Contents: contents, Schedule: schedule}, nil
+	return &model.Unit{AppID: claims.AppID, OrgID: claims.OrgID, Name: item.Name, Contents: contents, Schedule: schedule,
+		Localizations: localizationsFromDef(item.Localizations)}, nil
+}
+
+func localizationsFromDef(item *Def.Localizations) model.Localizations {
+	if item == nil {
+		return nil
+	}
+
+	localizations := make(model.Localizations, len(*item))
+	for locale, localization := range *item {
+		var localizedStrings map[string]interface{}
+		if localization.Strings != nil {
+			localizedStrings = *localization.Strings
+		}
+		localizations[locale] = model.Localization{Name: utils.GetString(localization.Name), Details: utils.GetString(localization.Details), Strings: localizedStrings}
+	}
+	return localizations
 }
@@ -19,6 +19,13 @@ const (
 	ContentTypeReward     ContentType = "reward"
 )
 
+// Defines values for ModulePrerequisiteType.
+const (
+	ModulePrerequisiteTypeDate           ModulePrerequisiteType = "date"
+	ModulePrerequisiteTypeEnrollmentDays ModulePrerequisiteType = "enrollment_days"
+	ModulePrerequisiteTypeModule         ModulePrerequisiteType = "module"
+)
+
 // Defines values for NudgesConfigMode.
 const (
 	NudgesConfigModeNormal NudgesConfigMode = "normal"
@@ -73,6 +80,14 @@ type Course struct {
 	OrgId   *string  `json:"org_id,omitempty"`
 }
 
+// CourseAvailability when users may enroll in and take a course. Unset times do not restrict the course
+type CourseAvailability struct {
+	// CourseEnd the course is no longer listed or open for enrollment after this time
+	CourseEnd       *time.Time `json:"course_end,omitempty"`
+	EnrollmentEnd   *time.Time `json:"enrollment_end,omitempty"`
+	EnrollmentStart *time.Time `json:"enrollment_start,omitempty"`
+}
+
 // CourseConfig defines model for CourseConfig.
 type CourseConfig struct {
 	AppId                      string                     `json:"app_id"`
@@ -85,6 +100,15 @@ type CourseConfig struct {
 	StreaksNotificationsConfig StreaksNotificationsConfig `json:"streaks_notifications_config"`
 }
 
+// CourseVisibility restricts a course to members of any of the listed groups or users with any of the listed roles
+type CourseVisibility struct {
+	// Groups Groups BB group titles
+	Groups *[]string `json:"groups,omitempty"`
+
+	// Roles Core role names or IDs
+	Roles *[]string `json:"roles,omitempty"`
+}
+
 // Enrollment defines model for Enrollment.
 type Enrollment struct {
 	Grade *Grade  `json:"grade,omitempty"`
@@ -97,6 +121,19 @@ type Grade struct {
 	CurrentScore *float32 `json:"current_score,omitempty"`
 }
 
+// Localization text in a locale other than the default. Empty fields fall back to the default text
+type Localization struct {
+	// Details content only
+	Details *string `json:"details,omitempty"`
+	Name    *string `json:"name,omitempty"`
+
+	// Strings replace the matching styles strings entries (modules and content only)
+	Strings *map[string]interface{} `json:"strings,omitempty"`
+}
+
+// Localizations localized text by locale (BCP 47 language tag such as "es" or "zh-Hant"). Client endpoints resolve the best locale and return only the resolved text
+type Localizations map[string]Localization
+
 // Module defines model for Module.
 type Module struct {
 	AppId  *string `json:"app_id,omitempty"`
@@ -108,6 +145,22 @@ type Module struct {
 	Units  []Unit  `json:"units"`
 }
 
+// ModulePrerequisite defines model for ModulePrerequisite.
+type ModulePrerequisite struct {
+	// Date date the module opens (date type only)
+	Date *time.Time `json:"date,omitempty"`
+
+	// Days number of days after enrollment the module opens (enrollment_days type only)
+	Days *int `json:"days,omitempty"`
+
+	// ModuleKey module which must be completed (module type only)
+	ModuleKey *string                `json:"module_key,omitempty"`
+	Type      ModulePrerequisiteType `json:"type"`
+}
+
+// ModulePrerequisiteType defines model for ModulePrerequisite.Type.
+type ModulePrerequisiteType string
+
 // Notification defines model for Notification.
 type Notification struct {
 	Active       bool                   `json:"active"`
@@ -301,14 +354,25 @@ type AdminReqCreateNudge struct {
 
 // AdminReqUpdateCourse defines model for _admin_req_update_course.
 type AdminReqUpdateCourse struct {
-	ModuleKeys []string `json:"module_keys"`
-	Name       string   `json:"name"`
+	// Availability when users may enroll in and take a course. Unset times do not restrict the course
+	Availability *CourseAvailability `json:"availability,omitempty"`
+
+	// Localizations localized text by locale (BCP 47 language tag such as "es" or "zh-Hant"). Client endpoints resolve the best locale and return only the resolved text
+	Localizations *Localizations `json:"localizations,omitempty"`
+	ModuleKeys    []string       `json:"module_keys"`
+	Name          string         `json:"name"`
+
+	// Visibility restricts a course to members of any of the listed groups or users with any of the listed roles
+	Visibility *CourseVisibility `json:"visibility,omitempty"`
 }
 
 // AdminReqUpdateModule defines model for _admin_req_update_module.
 type AdminReqUpdateModule struct {
-	Name     string   `json:"name"`
-	UnitKeys []string `json:"unit_keys"`
+	// Localizations localized text by locale (BCP 47 language tag such as "es" or "zh-Hant"). Client endpoints resolve the best locale and return only the resolved text
+	Localizations *Localizations        `json:"localizations,omitempty"`
+	Name          string                `json:"name"`
+	Prerequisites *[]ModulePrerequisite `json:"prerequisites,omitempty"`
+	UnitKeys      []string              `json:"unit_keys"`
 }
 
 // AdminReqUpdateNudge defines model for _admin_req_update_nudge.
@@ -323,9 +387,12 @@ type AdminReqUpdateNudge struct {
 
 // AdminReqUpdateUnit defines model for _admin_req_update_unit.
 type AdminReqUpdateUnit struct {
-	ContentKeys []string       `json:"content_keys"`
-	Name        string         `json:"name"`
-	Schedule    []ScheduleItem `json:"schedule"`
+	ContentKeys []string `json:"content_keys"`
+
+	// Localizations localized text by locale (BCP 47 language tag such as "es" or "zh-Hant"). Client endpoints resolve the best locale and return only the resolved text
+	Localizations *Localizations `json:"localizations,omitempty"`
+	Name          string         `json:"name"`
+	Schedule      []ScheduleItem `json:"schedule"`
 }
 
 // GetAdminContentParams defines parameters for GetAdminContent.
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"encoding/pem"
 	"lms/core"
 	cacheadapter "lms/driven/cache"
 	"lms/driven/corebb"
@@ -24,7 +25,9 @@ import (
 	storage "lms/driven/storage"
 	driver "lms/driver/web"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth"
 	"github.com/rokwire/rokwire-building-block-sdk-go/services/core/auth/keys"
@@ -113,8 +116,28 @@ func main() {
 	coreAdapter := corebb.NewCoreAdapter(cHost, cServiceAccountManager, org, app)
 
 	// application
+	defaultLocale := envLoader.GetAndLogEnvVar(envPrefix+"DEFAULT_LOCALE", false, false)
+	if defaultLocale == "" {
+		defaultLocale = "en"
+	}
+
+	trashRetentionDays := 30
+	trashRetentionDaysRaw := envLoader.GetAndLogEnvVar(envPrefix+"TRASH_RETENTION_DAYS", false, false)
+	if trashRetentionDaysRaw != "" {
+		trashRetentionDays, err = strconv.Atoi(trashRetentionDaysRaw)
+		if err != nil || trashRetentionDays <= 0 {
+			logger.Fatalf("Invalid trash retention days: %s", trashRetentionDaysRaw)
+		}
+	}
+
+	// certificates
+	certificateKey, retiredCertificateKeys := getCertificateKeys(logger, envLoader, envPrefix)
+
+	leaderboardKey := envLoader.GetAndLogEnvVar(envPrefix+"LEADERBOARD_KEY", false, true)
+
 	application := core.NewApplication(Version, Build, storageAdapter, providerAdapter,
-		groupsBBAdapter, notificationsBBAdapter, cacheAdapter, coreAdapter, serviceID, logger)
+		groupsBBAdapter, notificationsBBAdapter, cacheAdapter, coreAdapter, serviceID, defaultLocale,
+		time.Duration(trashRetentionDays)*24*time.Hour, certificateKey, retiredCertificateKeys, []byte(leaderboardKey), logger)
 	application.Start()
 
 	// web adapter
@@ -159,3 +182,32 @@ func getCoreBBAdapterValues(logger *logs.Logger, serviceID string, serviceRegMan
 	}
 	return coreBBHost, serviceAccountManager
 }
+
+// getCertificateKeys loads the key used to sign new certificates and the public keys of retired signing keys which still verify previously issued certificates
+func getCertificateKeys(logger *logs.Logger, envLoader envloader.EnvLoader, envPrefix string) (*keys.PrivKey, []keys.PubKey) {
+	var privKey *keys.PrivKey
+	privKeyRaw := envLoader.GetAndLogEnvVar(envPrefix+"CERTIFICATE_PRIV_KEY", false, true)
+	if privKeyRaw != "" {
+		var err error
+		privKey, err = keys.NewPrivKey(keys.RS256, strings.ReplaceAll(privKeyRaw, "\\n", "\n"))
+		if err != nil {
+			logger.Fatalf("Error parsing certificate priv key: %v", err)
+		}
+	}
+
+	pubKeys := make([]keys.PubKey, 0)
+	rest := []byte(strings.ReplaceAll(envLoader.GetAndLogEnvVar(envPrefix+"CERTIFICATE_PUB_KEYS", false, false), "\\n", "\n"))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		pubKey, err := keys.NewPubKey(keys.RS256, string(pem.EncodeToMemory(block)))
+		if err != nil {
+			logger.Fatalf("Error parsing certificate pub key: %v", err)
+		}
+		pubKeys = append(pubKeys, *pubKey)
+	}
+	return privKey, pubKeys
+}
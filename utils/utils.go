@@ -15,8 +15,12 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"log"
 	"math"
 	"net/http"
@@ -30,6 +34,7 @@ import (
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/errors"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logs"
 	"github.com/rokwire/rokwire-building-block-sdk-go/utils/logging/logutils"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 const (
@@ -45,6 +50,9 @@ const (
 	MinTZOffset int = -12 * SecondsInHour
 	// MaxTZOffset is the maximum allowed timezone offset from UTC in seconds (UTC+14 = 50400)
 	MaxTZOffset int = 14 * SecondsInHour
+
+	// jsonSchemaURL identifies a JSON Schema being compiled, so that references within it resolve without a file or network location
+	jsonSchemaURL string = "urn:lms:response-schema"
 )
 
 // Filter represents find filter for finding entities by the their fields
@@ -459,3 +467,71 @@ func onTimer(timer *time.Timer, timerDone chan bool, initialDuration *time.Durat
 		timer = nil
 	}
 }
+
+// ValidateJSONSchema checks that schema is a valid JSON Schema (draft 2020-12 unless another draft is declared by $schema)
+func ValidateJSONSchema(schema map[string]interface{}) error {
+	_, err := parseJSONSchema(schema)
+	return err
+}
+
+// ValidateJSON checks that value conforms to schema and returns an error naming the first invalid field if it does not
+func ValidateJSON(schema map[string]interface{}, value interface{}) error {
+	parsedSchema, err := parseJSONSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	// the validator only accepts values as decoded from JSON
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionMarshal, "value", nil, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded interface{}
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, "value", nil, err)
+	}
+
+	err = parsedSchema.Validate(decoded)
+	if err != nil {
+		var validationErr *jsonschema.ValidationError
+		if goerrors.As(err, &validationErr) {
+			// the innermost cause names the keyword the value failed
+			for len(validationErr.Causes) > 0 {
+				validationErr = validationErr.Causes[0]
+			}
+			field := validationErr.InstanceLocation
+			if field == "" {
+				field = "/"
+			}
+			return errors.ErrorData(logutils.StatusInvalid, "field", &logutils.FieldArgs{"field": field, "reason": validationErr.Message})
+		}
+		return errors.WrapErrorData(logutils.StatusInvalid, "value", nil, err)
+	}
+	return nil
+}
+
+func parseJSONSchema(schema map[string]interface{}) (*jsonschema.Schema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionMarshal, "json schema", nil, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	// schemas are entered by admins, so references must stay within the schema instead of loading files or URLs
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, "json schema reference", &logutils.FieldArgs{"url": url})
+	}
+	err = compiler.AddResource(jsonSchemaURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.WrapErrorData(logutils.StatusInvalid, "json schema", nil, err)
+	}
+	parsedSchema, err := compiler.Compile(jsonSchemaURL)
+	if err != nil {
+		return nil, errors.WrapErrorData(logutils.StatusInvalid, "json schema", nil, err)
+	}
+	return parsedSchema, nil
+}